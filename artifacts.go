@@ -0,0 +1,144 @@
+package chromium
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-rod/rod/lib/proto"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// artifactTimeout bounds the capture of each artifact, such that a hung page cannot block a failing helper.
+	artifactTimeout = 10 * time.Second
+	// artifactTimeLayout is the layout of the timestamp that prefixes names of artifacts.
+	artifactTimeLayout = "20060102-150405.000"
+)
+
+// WithArtifactsOnFailure makes every page of the browser capture its artifacts into dir as CaptureArtifacts does,
+// whenever a helper that a Recorder captures fails with an error other than cancellation, such as ElementMissing or
+// TaskTimeout. Pages out of the pool, such as of NewPageWithProxy and Pages, capture their artifacts as well.
+// Failures of the capture are logged to the logger of the page, if any.
+func WithArtifactsOnFailure(dir string) BrowserOption {
+	return func(o *browserOptions) {
+		o.artifactDir = dir
+	}
+}
+
+// SetArtifactsOnFailure sets the directory that this page captures its artifacts into once a helper fails,
+// as WithArtifactsOnFailure does. Empty dir stops the capture.
+func (p *Page) SetArtifactsOnFailure(dir string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.artifactDir = dir
+}
+
+// artifactsOnFailure returns the directory that this page captures its artifacts into on failure, or empty if none.
+func (p *Page) artifactsOnFailure() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.artifactDir
+}
+
+// CaptureArtifacts writes screenshot.png, page.html, console.log and cookies.json of this page into dir for
+// post-mortem debugging, each named with the timestamp of the capture as its prefix, such as
+// "20060102-150405.000-page.html". The directory is created if missing. The console log tells messages and exceptions
+// captured by CaptureConsole, which is empty unless the capture is in progress.
+// Each artifact is written even if others fail, and the paths of the written ones are returned along with the first
+// error, if any.
+func (p *Page) CaptureArtifacts(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	prefix := filepath.Join(dir, time.Now().Format(artifactTimeLayout)+"-")
+	artifacts := []struct {
+		name    string
+		capture func(ctx context.Context) ([]byte, error)
+	}{
+		{"screenshot.png", p.screenshotArtifact},
+		{"page.html", p.htmlArtifact},
+		{"console.log", p.consoleArtifact},
+		{"cookies.json", p.cookiesArtifact},
+	}
+	paths := make([]string, 0, len(artifacts))
+	var first error
+	for _, a := range artifacts {
+		ctx, cancel := context.WithTimeout(p.GetContext(), artifactTimeout)
+		data, err := a.capture(ctx)
+		cancel()
+		if err == nil {
+			err = os.WriteFile(prefix+a.name, data, 0o644)
+		}
+		if err != nil {
+			if first == nil {
+				first = fmt.Errorf("%s: %w", a.name, replaceTimeoutError(replaceAbortedError(err)))
+			}
+			continue
+		}
+		paths = append(paths, prefix+a.name)
+	}
+	return paths, first
+}
+
+func (p *Page) screenshotArtifact(ctx context.Context) ([]byte, error) {
+	return p.Page.Context(ctx).Screenshot(false, &proto.PageCaptureScreenshot{Format: proto.PageCaptureScreenshotFormatPng})
+}
+
+func (p *Page) htmlArtifact(ctx context.Context) ([]byte, error) {
+	html, err := p.Page.Context(ctx).HTML()
+	return []byte(html), err
+}
+
+// consoleArtifact formats the console messages and exceptions, a line each, followed by their stacks if any.
+func (p *Page) consoleArtifact(context.Context) ([]byte, error) {
+	b := &strings.Builder{}
+	for _, m := range p.ConsoleLogs() {
+		fmt.Fprintf(b, "%s [%s] %s\n", m.Time.Format(time.RFC3339Nano), m.Type, m.Text)
+		writeStack(b, m.Stack)
+	}
+	for _, e := range p.JSErrors() {
+		fmt.Fprintf(b, "%s [exception] %s (%s:%d:%d)\n", e.Time.Format(time.RFC3339Nano), e.Message, e.URL, e.Line+1, e.Column+1)
+		writeStack(b, e.Stack)
+	}
+	return []byte(b.String()), nil
+}
+
+// writeStack writes the frames of the stack, a line each.
+func writeStack(b *strings.Builder, stack []StackFrame) {
+	for _, f := range stack {
+		fmt.Fprintf(b, "\tat %s (%s:%d:%d)\n", f.Function, f.URL, f.Line+1, f.Column+1)
+	}
+}
+
+func (p *Page) cookiesArtifact(ctx context.Context) ([]byte, error) {
+	cookies, err := p.Page.Context(ctx).Cookies(nil)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*http.Cookie, 0, len(cookies))
+	for _, c := range cookies {
+		res = append(res, fromCookie(c))
+	}
+	return json.MarshalIndent(res, "", "  ")
+}
+
+// captureOnFailure captures the artifacts of this page if it is set to capture them on failure,
+// and the error is not a cancellation.
+func (p *Page) captureOnFailure(err error) {
+	dir := p.artifactsOnFailure()
+	if len(dir) == 0 || err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	paths, captureErr := p.CaptureArtifacts(dir)
+	if captureErr != nil {
+		p.log(LevelWarn, "artifacts capture failed", "dir", dir, "error", captureErr)
+	}
+	if len(paths) > 0 {
+		p.log(LevelInfo, "artifacts captured", "paths", paths, "cause", err)
+	}
+}
@@ -0,0 +1,92 @@
+package chromium
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_consoleArtifact_Formats_Messages_And_Exceptions(t *testing.T) {
+	p := newPage(nil, func() {})
+	at := time.Date(2022, 9, 30, 12, 0, 0, 0, time.UTC)
+	p.console = &consoleRecorder{
+		mu:       &sync.Mutex{},
+		messages: []ConsoleMessage{{Type: "log", Text: "hello", Time: at}},
+		errors: []JSError{{Message: "boom", URL: "https://example.com/app.js", Line: 9, Column: 4, Time: at,
+			Stack: []StackFrame{{Function: "run", URL: "https://example.com/app.js", Line: 9, Column: 4}}}},
+	}
+	data, err := p.consoleArtifact(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "2022-09-30T12:00:00Z [log] hello\n"+
+		"2022-09-30T12:00:00Z [exception] boom (https://example.com/app.js:10:5)\n"+
+		"\tat run (https://example.com/app.js:10:5)\n", string(data))
+}
+
+func Test_captureOnFailure_Skips_Cancellation(t *testing.T) {
+	p := newPage(nil, func() {})
+	dir := t.TempDir()
+	p.SetArtifactsOnFailure(dir)
+	p.captureOnFailure(nil)
+	p.captureOnFailure(context.Canceled)
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// artifactNames returns names of files in the directory without their timestamps, in order.
+func artifactNames(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimPrefix(e.Name(), e.Name()[:len(artifactTimeLayout)+1]))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Test_CaptureArtifacts_Writes_Artifacts(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.NoError(t, p.SetCookies([]*http.Cookie{{Name: "session", Value: "abc", Domain: "127.0.0.1", Path: "/"}}))
+	dir := filepath.Join(t.TempDir(), "artifacts")
+
+	paths, err := p.CaptureArtifacts(dir)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 4)
+	assert.Equal(t, []string{"console.log", "cookies.json", "page.html", "screenshot.png"}, artifactNames(t, dir))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		switch filepath.Ext(path) {
+		case ".html":
+			assert.Contains(t, string(data), "item0")
+		case ".json":
+			var cookies []*http.Cookie
+			assert.NoError(t, json.Unmarshal(data, &cookies))
+			assert.Equal(t, "session", cookies[0].Name)
+		case ".png":
+			assert.Equal(t, "\x89PNG", string(data[:4]))
+		}
+	}
+}
+
+func Test_SetArtifactsOnFailure_Captures_On_Failed_Helper(t *testing.T) {
+	_, p, s := setup(t)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetDefaultTimeout(100 * time.Millisecond)
+	dir := t.TempDir()
+	p.SetArtifactsOnFailure(dir)
+
+	assert.Error(t, p.TryInput("#missing", "text"))
+	assert.Equal(t, []string{"console.log", "cookies.json", "page.html", "screenshot.png"}, artifactNames(t, dir))
+}
@@ -0,0 +1,123 @@
+package chromium
+
+import (
+	"context"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"net/url"
+)
+
+// EnableAuth answers HTTP authentication challenges of servers to this page with the credentials, such as of basic
+// or digest authentication, which otherwise leave a navigation hanging on the credentials that no one provides.
+// Each request is answered once, such that a request with rejected credentials fails with 401 rather than retrying
+// forever. Challenges of proxies are answered by the credentials of proxies instead, as of WithProxyRotation.
+// Requests are paused for the challenges only on a session of this page, thus other pages are left as they are.
+// The credentials are kept once the page is recycled, until DisableAuth is called.
+func (p *Page) EnableAuth(username, password string) error {
+	auth := p.authenticator()
+	auth.setServer(p.FrameID, url.UserPassword(username, password))
+	if err := p.listenAuth(p.Page); err != nil {
+		auth.setServer(p.FrameID, nil)
+		return err
+	}
+	return nil
+}
+
+// DisableAuth stops answering HTTP authentication challenges of servers to this page, as enabled by EnableAuth,
+// which no longer pauses requests of this page.
+func (p *Page) DisableAuth() {
+	p.authenticator().setServer(p.FrameID, nil)
+	p.stopAuth()
+}
+
+// authenticator returns the answerer of authentication challenges of this page, which is shared by pages of the same
+// browser. A page that is not of a Browser gets its own answerer on the first call.
+func (p *Page) authenticator() *proxyAuth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.auth == nil {
+		p.auth = newProxyAuth()
+	}
+	return p.auth
+}
+
+// authSession is a session of its own to a tab, on which requests are paused to answer challenges of servers.
+// Being apart from the session of the page, it is left alone by routers of the page, such as of Intercept.
+type authSession struct {
+	target  proto.TargetTargetID
+	session *rod.Page
+	cancel  context.CancelFunc
+}
+
+// startAuthSession attaches a session to the tab, then answers challenges on it by auth until stopped.
+func startAuthSession(page *rod.Page, auth *proxyAuth) (*authSession, error) {
+	b := page.Browser()
+	res, err := proto.TargetAttachToTarget{TargetID: page.TargetID, Flatten: true}.Call(b)
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	session := b.PageFromSession(res.SessionID)
+	ctx, cancel := context.WithCancel(b.GetContext())
+	events := b.Context(ctx).Event()
+	if err = (proto.FetchEnable{HandleAuthRequests: true}).Call(session); err != nil {
+		cancel()
+		_ = proto.TargetDetachFromTarget{SessionID: res.SessionID}.Call(b)
+		return nil, replaceAbortedError(err)
+	}
+	go auth.serve(session, res.SessionID, events)
+	return &authSession{target: page.TargetID, session: session, cancel: cancel}, nil
+}
+
+// stop disables Fetch on the session and detaches it, which the tab may have closed already.
+func (s *authSession) stop() {
+	_ = proto.FetchDisable{}.Call(s.session)
+	_ = proto.TargetDetachFromTarget{SessionID: s.session.SessionID}.Call(s.session.Browser())
+	s.cancel()
+}
+
+// listenAuth answers challenges of servers to the tab on a session of its own, replacing the session to another tab,
+// e.g. the one that the page had before being recycled.
+func (p *Page) listenAuth(page *rod.Page) error {
+	auth := p.authenticator()
+	p.mu.RLock()
+	current := p.authSession
+	p.mu.RUnlock()
+	if current != nil && current.target == page.TargetID {
+		return nil
+	}
+	s, err := startAuthSession(page, auth)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	old := p.authSession
+	p.authSession = s
+	p.mu.Unlock()
+	if old != nil {
+		old.stop()
+	}
+	return nil
+}
+
+// stopAuth stops answering challenges of servers to this page, as no credentials are left for it.
+func (p *Page) stopAuth() {
+	p.mu.Lock()
+	s := p.authSession
+	p.authSession = nil
+	p.mu.Unlock()
+	if s != nil {
+		s.stop()
+	}
+}
+
+// restoreAuth answers challenges of servers to given tab if this page does so, as the tab is about to replace
+// the current tab.
+func (p *Page) restoreAuth(page *rod.Page) error {
+	p.mu.RLock()
+	enabled := p.authSession != nil
+	p.mu.RUnlock()
+	if !enabled {
+		return nil
+	}
+	return p.listenAuth(page)
+}
@@ -0,0 +1,135 @@
+// Package auth automates logins of websites by pages of package chromium, such that jobs can log in once,
+// then log in again only when their sessions expire.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/state303/chromium"
+	"time"
+)
+
+// LoginFailed is returned when the login form is submitted, but the page does not tell the success of the login.
+var LoginFailed = errors.New("login failed")
+
+const (
+	// loginBackoff is the backoff of retries of navigating to the login page, until the page shows the username field.
+	loginBackoff = time.Second
+	// defaultTimeout bounds the submission of a flow without its own timeout.
+	defaultTimeout = time.Second * 30
+)
+
+// Credentials are what a user types into the login form.
+type Credentials struct {
+	Username string
+	Password string
+	// OTP provides one-time passwords for the flow with OTPSel, such as TOTP with the secret of the account.
+	OTP TokenProvider
+}
+
+// LoginFlow describes the login form of a website, and how to tell whether the session of a page is valid.
+type LoginFlow struct {
+	// URL of the login page, which the page navigates to before filling in the form. Empty URL fills in the current page.
+	URL         string
+	UsernameSel string
+	PasswordSel string
+	SubmitSel   string
+	// OTPSel is the field of one-time passwords that shows up once the form is submitted, if the account has
+	// a second factor. The password from OTP of the credentials is typed into it, then submitted by OTPSubmitSel,
+	// or by SubmitSel if empty.
+	OTPSel       string
+	OTPSubmitSel string
+	// SuccessPredicate matches the page once logged in, such as chromium.HasSelector("#logout").
+	// Nil predicate takes the navigation after the submission as the success.
+	SuccessPredicate chromium.Predicate[*chromium.Page]
+	// SessionExpired matches the page whose session has ended, such as chromium.HasSelector("#login"),
+	// by which Ensure decides whether to log in again. Nil predicate never takes the session as expired.
+	SessionExpired chromium.Predicate[*chromium.Page]
+	// Timeout bounds the navigation after the submission, then the wait for SuccessPredicate to match.
+	// Zero or negative timeout waits for 30 seconds.
+	Timeout time.Duration
+}
+
+// Login navigates the page to the login page of the flow if any, fills in the username by TryInput and the password
+// by TryInputSecret, submits the form by ClickNavigate, submits a one-time password if the flow has OTPSel,
+// then waits until the page matches SuccessPredicate of the flow.
+// Errors of each step are returned as they are, which tell the selector of the step but never the credentials,
+// and neither do the history and the recorder of the page.
+// It returns an error wrapping LoginFailed if SuccessPredicate does not match in time.
+func Login(ctx context.Context, p *chromium.Page, flow LoginFlow, creds Credentials) error {
+	timeout := flow.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if len(flow.URL) > 0 {
+		if err := p.TryNavigateContext(ctx, flow.URL, chromium.HasSelector(flow.UsernameSel), loginBackoff); err != nil {
+			return err
+		}
+	}
+	if err := p.TryInputContext(ctx, flow.UsernameSel, creds.Username); err != nil {
+		return err
+	}
+	if err := p.TryInputSecretContext(ctx, flow.PasswordSel, creds.Password); err != nil {
+		return err
+	}
+	if err := p.ClickNavigateContext(ctx, flow.SubmitSel, timeout); err != nil {
+		return err
+	}
+	if len(flow.OTPSel) > 0 {
+		if err := submitOTP(ctx, p, flow, creds.OTP, timeout); err != nil {
+			return err
+		}
+	}
+	if flow.SuccessPredicate == nil {
+		return nil
+	}
+	_, err := chromium.Await(ctx, func() (bool, bool, error) {
+		return true, matches(p, flow.SuccessPredicate), nil
+	}, 0, timeout)
+	if errors.Is(err, chromium.TaskTimeout) {
+		return fmt.Errorf("%w: %s", LoginFailed, flow.SubmitSel)
+	}
+	return err
+}
+
+// submitOTP types a one-time password from the provider into the field of the flow by TryInputSecret, then submits it.
+func submitOTP(ctx context.Context, p *chromium.Page, flow LoginFlow, provider TokenProvider, timeout time.Duration) error {
+	if provider == nil {
+		return fmt.Errorf("%w: no token provider for %s", LoginFailed, flow.OTPSel)
+	}
+	if _, err := p.WaitElementFor(flow.OTPSel, timeout); err != nil {
+		return err
+	}
+	token, err := provider.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: token for %s: %v", LoginFailed, flow.OTPSel, err)
+	}
+	if err = p.TryInputSecretContext(ctx, flow.OTPSel, token); err != nil {
+		return err
+	}
+	submit := flow.OTPSubmitSel
+	if len(submit) == 0 {
+		submit = flow.SubmitSel
+	}
+	return p.ClickNavigateContext(ctx, submit, timeout)
+}
+
+// Ensure logs the page in by Login if it matches SessionExpired of the flow, reporting whether it has logged in.
+// Call it before each job on a page that has logged in before, to recover from sessions that expire meanwhile.
+func Ensure(ctx context.Context, p *chromium.Page, flow LoginFlow, creds Credentials) (bool, error) {
+	if flow.SessionExpired == nil || !matches(p, flow.SessionExpired) {
+		return false, nil
+	}
+	return true, Login(ctx, p, flow, creds)
+}
+
+// matches examines the page by the predicate, taking a panic of the predicate, such as from Must functions, as a mismatch.
+func matches(p *chromium.Page, predicate chromium.Predicate[*chromium.Page]) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return predicate(p)
+}
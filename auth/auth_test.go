@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"github.com/state303/chromium"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const loginHTML = `<html><body><form method="post" action="/login">
+<input id="username" name="username"><input id="password" name="password" type="password">
+<button id="submit" type="submit">Sign in</button>
+</form></body></html>`
+
+// setup returns a page of a new browser, and a server whose login succeeds only by "user" and "pass",
+// or by "otp" and "pass" followed by the one-time password "123456".
+func setup(t *testing.T) (*chromium.Page, *httptest.Server) {
+	t.Parallel()
+	b, err := chromium.NewBrowser(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := b.GetPage()
+	t.Cleanup(func() { b.PutPage(p); b.CleanUp() })
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method != http.MethodPost:
+		case r.FormValue("username") == "otp" && r.FormValue("password") == "pass":
+			_, _ = w.Write([]byte(`<html><body><form method="post" action="/otp">
+<input id="otp" name="otp"><button id="verify" type="submit">Verify</button></form></body></html>`))
+			return
+		case r.FormValue("username") == "user" && r.FormValue("password") == "pass", r.FormValue("otp") == "123456":
+			_, _ = w.Write([]byte(`<html><body><a id="logout">Sign out</a></body></html>`))
+			return
+		}
+		_, _ = w.Write([]byte(loginHTML))
+	}))
+	t.Cleanup(s.Close)
+	return p, s
+}
+
+func flowOf(s *httptest.Server) LoginFlow {
+	return LoginFlow{
+		URL:              s.URL,
+		UsernameSel:      "#username",
+		PasswordSel:      "#password",
+		SubmitSel:        "#submit",
+		SuccessPredicate: chromium.HasSelector("#logout"),
+		SessionExpired:   chromium.HasSelector("#username"),
+		Timeout:          time.Second * 5,
+	}
+}
+
+func Test_Login_Fills_In_And_Verifies_Success(t *testing.T) {
+	p, s := setup(t)
+	assert.NoError(t, Login(context.Background(), p, flowOf(s), Credentials{Username: "user", Password: "pass"}))
+	assert.True(t, p.MustHas("#logout"))
+}
+
+func Test_Login_Returns_LoginFailed_On_Wrong_Credentials(t *testing.T) {
+	p, s := setup(t)
+	flow := flowOf(s)
+	flow.Timeout = time.Second
+	err := Login(context.Background(), p, flow, Credentials{Username: "user", Password: "wrong"})
+	assert.ErrorIs(t, err, LoginFailed)
+	assert.NotContains(t, err.Error(), "wrong")
+}
+
+func Test_Login_Submits_OTP(t *testing.T) {
+	p, s := setup(t)
+	flow := flowOf(s)
+	flow.OTPSel, flow.OTPSubmitSel = "#otp", "#verify"
+	otp := TokenFunc(func(context.Context) (string, error) { return "123456", nil })
+
+	err := Login(context.Background(), p, flow, Credentials{Username: "otp", Password: "pass"})
+	assert.ErrorIs(t, err, LoginFailed)
+	assert.NoError(t, Login(context.Background(), p, flow, Credentials{Username: "otp", Password: "pass", OTP: otp}))
+	assert.True(t, p.MustHas("#logout"))
+}
+
+func Test_Ensure_Logs_In_Only_Once_Session_Expired(t *testing.T) {
+	p, s := setup(t)
+	flow, creds := flowOf(s), Credentials{Username: "user", Password: "pass"}
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	relogged, err := Ensure(context.Background(), p, flow, creds)
+	assert.True(t, relogged)
+	assert.NoError(t, err)
+	relogged, err = Ensure(context.Background(), p, flow, creds)
+	assert.False(t, relogged)
+	assert.NoError(t, err)
+}
+
+func Test_matches_Takes_Panic_As_Mismatch(t *testing.T) {
+	assert.False(t, matches(nil, func(*chromium.Page) bool { panic("closed") }))
+	assert.True(t, matches(nil, func(*chromium.Page) bool { return true }))
+}
+
+func Test_Login_Keeps_Password_Out_Of_History_And_Recording(t *testing.T) {
+	p, s := setup(t)
+	rec := chromium.NewRecorder()
+	p.Record(rec)
+	assert.NoError(t, Login(context.Background(), p, flowOf(s), Credentials{Username: "user", Password: "pass"}))
+	for _, record := range p.History() {
+		assert.NotEqual(t, "pass", record.Text)
+	}
+	for _, step := range rec.Script().Steps {
+		assert.NotEqual(t, "pass", step.Text)
+	}
+}
+
+func Test_Login_Keeps_OTP_Out_Of_History(t *testing.T) {
+	p, s := setup(t)
+	flow := flowOf(s)
+	flow.OTPSel, flow.OTPSubmitSel = "#otp", "#verify"
+	otp := TokenFunc(func(context.Context) (string, error) { return "123456", nil })
+	assert.NoError(t, Login(context.Background(), p, flow, Credentials{Username: "otp", Password: "pass", OTP: otp}))
+	for _, record := range p.History() {
+		assert.NotEqual(t, "123456", record.Text)
+	}
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenProvider provides one-time passwords for the second factor of logins, such as TOTP or codes relayed from SMS.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenFunc is a function that implements TokenProvider.
+type TokenFunc func(ctx context.Context) (string, error)
+
+// Token calls the function.
+func (f TokenFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+const (
+	defaultTOTPDigits = 6
+	defaultTOTPPeriod = time.Second * 30
+)
+
+// TOTP provides time-based one-time passwords of RFC 6238 with HMAC-SHA1, which authenticator apps generate
+// from the secret shown along with the QR code on enrollment.
+type TOTP struct {
+	Secret string        // secret encoded in base32, of which spaces, padding and case are ignored.
+	Digits int           // number of digits of a password, which defaults to 6 if not positive.
+	Period time.Duration // period that a password is valid for, which defaults to 30 seconds if not positive.
+}
+
+// Token returns the password at present.
+func (t TOTP) Token(context.Context) (string, error) {
+	return t.At(time.Now())
+}
+
+// At returns the password at the time.
+func (t TOTP) At(at time.Time) (string, error) {
+	secret := strings.ToUpper(strings.TrimRight(strings.ReplaceAll(t.Secret, " ", ""), "="))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret: %w", err)
+	}
+	digits, period := t.Digits, t.Period
+	if digits <= 0 {
+		digits = defaultTOTPDigits
+	}
+	if period <= 0 {
+		period = defaultTOTPPeriod
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.UnixNano()/int64(period)))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	code := uint64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+
+	modulo := uint64(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, code%modulo), nil
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_TOTP_At_Matches_RFC6238_Vectors(t *testing.T) {
+	totp := TOTP{Secret: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq", Digits: 8}
+	vectors := map[int64]string{
+		59:         "94287082",
+		1111111109: "07081804",
+		1234567890: "89005924",
+		2000000000: "69279037",
+	}
+	for at, want := range vectors {
+		got, err := totp.At(time.Unix(at, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	got, err := TOTP{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"}.At(time.Unix(59, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", got)
+}
+
+func Test_TOTP_Returns_Err_On_Invalid_Secret(t *testing.T) {
+	_, err := TOTP{Secret: "not base32!"}.Token(context.Background())
+	assert.ErrorContains(t, err, "invalid secret")
+}
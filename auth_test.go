@@ -0,0 +1,94 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func Test_proxyAuth_Answers_Server_Challenges_Of_Frames(t *testing.T) {
+	a := newProxyAuth()
+	a.setServer("main", url.UserPassword("alice", "secret"))
+	challenge := func(id string, frame proto.PageFrameID) *proto.FetchAuthRequired {
+		return &proto.FetchAuthRequired{RequestID: proto.FetchRequestID(id), FrameID: frame,
+			AuthChallenge: &proto.FetchAuthChallenge{Source: proto.FetchAuthChallengeSourceServer, Origin: "http://a.test"}}
+	}
+
+	got := a.answer(challenge("1", "main"))
+	assert.Equal(t, proto.FetchAuthChallengeResponseResponseProvideCredentials, got.Response)
+	assert.Equal(t, "alice", got.Username)
+	assert.Equal(t, "secret", got.Password)
+	got = a.answer(challenge("1", "main"))
+	assert.Equal(t, proto.FetchAuthChallengeResponseResponseCancelAuth, got.Response)
+	got = a.answer(challenge("2", "other"))
+	assert.Equal(t, proto.FetchAuthChallengeResponseResponseDefault, got.Response)
+
+	a.moveServer("main", "recycled")
+	got = a.answer(challenge("3", "recycled"))
+	assert.Equal(t, "alice", got.Username)
+	a.setServer("recycled", nil)
+	got = a.answer(challenge("4", "recycled"))
+	assert.Equal(t, proto.FetchAuthChallengeResponseResponseDefault, got.Response)
+}
+
+func Test_EnableAuth_Answers_Basic_Auth(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1)
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(testfile.BlankHTML)
+	}))
+	t.Cleanup(s.Close)
+	p := b.GetPage()
+	defer b.PutPage(p)
+
+	assert.NoError(t, p.EnableAuth("user", "wrong"))
+	res, err := p.NavigateResult(s.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.Status)
+
+	assert.NoError(t, p.EnableAuth("user", "pass"))
+	res, err = p.NavigateResult(s.URL + "/again")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.Status)
+	p.DisableAuth()
+}
+
+func Test_EnableAuth_Leaves_Intercept_Of_Page(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1)
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok || r.Header.Get("X-Test") != "intercepted" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(testfile.BlankHTML)
+	}))
+	t.Cleanup(s.Close)
+	p := b.GetPage()
+	defer b.PutPage(p)
+
+	remove, err := p.Intercept("*", func(req *InterceptedRequest) { req.SetHeader("X-Test", "intercepted") })
+	assert.NoError(t, err)
+	defer func() { _ = remove() }()
+	assert.NoError(t, p.EnableAuth("user", "pass"))
+	res, err := p.NavigateResult(s.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.Status)
+
+	p.DisableAuth()
+	assert.Nil(t, p.authSession)
+}
@@ -1,9 +1,17 @@
 package chromium
 
 import (
+	"context"
+	"fmt"
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"path/filepath"
 	"sync"
+	"time"
 )
 
 // Browser is a wrapper that embeds rod.Browser instance
@@ -12,58 +20,398 @@ type Browser struct {
 	wg       *sync.WaitGroup
 	pagePool PagePool
 	launcher *launcher.Launcher
+	// disconnect closes the connection to a remote browser, which is nil for a launched browser.
+	disconnect func() error
+
+	tasks *taskQueue
+	// reset is applied to pages put back to the pool, which is nil if pages are put back as they are.
+	reset *ResetPolicy
+	// healthTimeout bounds the ping of pages on acquisition, which disables health checks if not positive.
+	healthTimeout time.Duration
+	// leakThreshold is the duration after which a checked out page is reported as leaked, which disables the watchdog if not positive.
+	leakThreshold time.Duration
+
+	mu           *sync.Mutex
+	closed       chan struct{}
+	closing      bool
+	disconnected error
+	onDisconnect []func(err error)
+	usage        poolUsage
+	proxyAuth    *proxyAuth
+	pooled       map[proto.TargetTargetID]bool // targets of the tabs of pages of the pool.
+	limiter      RateLimiter                   // limiter of navigations shared by pages, if any.
+	robots       *robotsPolicy                 // policy of robots.txt shared by pages, if any.
+	collector    Collector                     // collector of metrics shared by pages, if any.
+	tracer       trace.Tracer                  // tracer of operations shared by pages, if any.
+	logger       Logger                        // logger of events shared by pages, if any.
+	artifactDir  string                        // directory of artifacts captured by pages on failure, if any.
 }
 
 // CleanUp wait then wipe all resources under this browser instance.
 func (b *Browser) CleanUp() {
+	b.log(LevelInfo, "cleaning up browser")
+	defer b.log(LevelInfo, "browser cleaned up")
+	b.stopServing()
+	b.tasks.drain()
 	go b.pagePool.CleanUp()
 	b.wg.Wait()
-	b.MustClose()
-	b.launcher.Cleanup()
+	if b.launcher != nil {
+		b.MustClose()
+		b.launcher.Cleanup()
+	} else if b.disconnect != nil {
+		_ = b.disconnect()
+	}
 }
 
 // GetPage return a page from this Browser's page pool.
 // Note that it will block until a page is available from the pool.
 // It is required for a caller to put back the page to the pool via PutPage function.
+// Once the browser begins to shut down or clean up, it returns nil instead.
+// If the browser is configured WithHealthCheck, a dead page is replaced by a fresh one before handed out.
 func (b *Browser) GetPage() *Page {
-	return <-b.pagePool
+	p, _ := b.GetPageContext(context.Background())
+	return p
+}
+
+// GetPageContext returns a page from this Browser's page pool, waiting until a page is available or the context is done.
+// It returns TaskTimeout when the deadline of the context has exceeded, and BrowserClosed once the browser begins to shut down.
+// It is required for a caller to put back the page to the pool via PutPage function.
+func (b *Browser) GetPageContext(ctx context.Context) (*Page, error) {
+	select {
+	case <-b.closed:
+		return nil, BrowserClosed
+	default:
+	}
+	p, ok := b.pagePool.TryGet()
+	if !ok {
+		b.wait(1)
+		defer b.wait(-1)
+		select {
+		case p = <-b.pagePool:
+		case <-b.closed:
+			return nil, BrowserClosed
+		case <-ctx.Done():
+			return nil, replaceTimeoutError(ctx.Err())
+		}
+	}
+	p = b.checkPage(p)
+	b.acquired(p)
+	b.poolChanged()
+	return p, nil
+}
+
+// GetPageTimeout returns a page from this Browser's page pool, or TaskTimeout if none is available within the duration.
+func (b *Browser) GetPageTimeout(d time.Duration) (*Page, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return b.GetPageContext(ctx)
+}
+
+// TryGetPage returns a page from this Browser's page pool without blocking.
+// It reports false when no page is available or the browser begins to shut down.
+func (b *Browser) TryGetPage() (*Page, bool) {
+	select {
+	case <-b.closed:
+		return nil, false
+	default:
+	}
+	p, ok := b.pagePool.TryGet()
+	if !ok {
+		return nil, false
+	}
+	p = b.checkPage(p)
+	b.acquired(p)
+	b.poolChanged()
+	return p, true
+}
+
+// stopServing marks this browser as closing, such that GetPage stops handing out pages.
+func (b *Browser) stopServing() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closing {
+		b.closing = true
+		close(b.closed)
+	}
 }
 
 // PutPage puts a page back to the browser's page pool.
 // Note that GetPage will be blocked until there is a page available from the pool.
 // By putting a page via this function will ensure next page resource to be served from a caller of GetPage function.
+// If the browser is configured WithPageReset, the page is reset before put back.
 func (b *Browser) PutPage(p *Page) {
+	b.released(p)
+	b.resetPage(p)
 	b.pagePool <- p
+	b.poolChanged()
+}
+
+// OnDisconnect registers a handler that is fired when the connection to the browser drops.
+// Handlers are not fired when the connection is closed by CleanUp.
+// If the browser has already been disconnected, the handler is fired immediately.
+func (b *Browser) OnDisconnect(handler func(err error)) {
+	b.mu.Lock()
+	if err := b.disconnected; err != nil {
+		b.mu.Unlock()
+		handler(err)
+		return
+	}
+	b.onDisconnect = append(b.onDisconnect, handler)
+	b.mu.Unlock()
+}
+
+// watchDisconnect drains given events until the stream ends, then fires the disconnect handlers.
+func (b *Browser) watchDisconnect(events <-chan *rod.Message) {
+	for range events {
+	}
+	b.mu.Lock()
+	if b.closing {
+		b.mu.Unlock()
+		return
+	}
+	b.disconnected = Disconnected
+	handlers := b.onDisconnect
+	b.onDisconnect = nil
+	b.mu.Unlock()
+	b.log(LevelError, "browser disconnected", "error", Disconnected)
+	for _, handler := range handlers {
+		handler(Disconnected)
+	}
+}
+
+// BrowserOption configures a Browser on its initialization.
+type BrowserOption func(o *browserOptions)
+
+// browserOptions holds configurations that are set by BrowserOption.
+type browserOptions struct {
+	taskQueueSize int
+	taskTimeout   time.Duration
+	reset         *ResetPolicy
+	healthTimeout time.Duration
+	leakThreshold time.Duration
+	onLeak        func(PageLeak)
+	human         bool
+	isolated      bool
+	proxies       []string
+	stealth       bool
+	limiter       RateLimiter
+	robots        string
+	collector     Collector
+	tracer        trace.Tracer
+	logger        Logger
+	artifactDir   string
+	hostRules     map[string]string
+	fakeMedia     bool
+	fakeVideo     string
+}
+
+// setFlags sets the flags of the options that are applied on launch of a browser, such as WithHostRules.
+func (o *browserOptions) setFlags(l *launcher.Launcher) error {
+	if len(o.hostRules) > 0 {
+		rules, err := hostResolverRules(o.hostRules)
+		if err != nil {
+			return err
+		}
+		l.Set("host-resolver-rules", rules)
+	}
+	if o.fakeMedia {
+		l.Set("use-fake-device-for-media-stream").Set("use-fake-ui-for-media-stream")
+		if len(o.fakeVideo) > 0 {
+			video, err := filepath.Abs(o.fakeVideo)
+			if err == nil {
+				_, err = os.Stat(video)
+			}
+			if err != nil {
+				return err
+			}
+			l.Set("use-file-for-fake-video-capture", video)
+		}
+	}
+	return nil
+}
+
+// launchOption returns the name of the first option that is applied only on launch of a browser, if any.
+func (o *browserOptions) launchOption() string {
+	if len(o.hostRules) > 0 {
+		return "WithHostRules"
+	} else if o.fakeMedia {
+		return "WithFakeMedia"
+	}
+	return ""
+}
+
+// WithTaskQueue sets the maximum number of tasks waiting for Browser.Submit, and the timeout of each task.
+// Zero or negative timeout lets the task run until it returns.
+func WithTaskQueue(size int, timeout time.Duration) BrowserOption {
+	return func(o *browserOptions) {
+		o.taskQueueSize = size
+		o.taskTimeout = timeout
+	}
 }
 
 // NewBrowser returns new browser with given pool size.
 // Note that the pagePoolSize cannot be changed after the initialization.
-func NewBrowser(pagePoolSize int) (*Browser, error) {
-	return NewBrowserWithProxy(pagePoolSize, "")
+func NewBrowser(pagePoolSize int, opts ...BrowserOption) (*Browser, error) {
+	return NewBrowserWithProxy(pagePoolSize, "", opts...)
 }
 
 // NewBrowserWithProxy returns new browser with given pool size and proxy.
+// The proxy may carry credentials such as "user:pass@host:port", which are provided to the proxy once it asks for them.
 // Note that the pagePoolSize and proxy cannot be changed after the initialization.
-func NewBrowserWithProxy(pagePoolSize int, proxy string) (*Browser, error) {
+func NewBrowserWithProxy(pagePoolSize int, proxy string, opts ...BrowserOption) (*Browser, error) {
+	server, user, err := splitProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	o := &browserOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
 	l := launcher.New().Leakless(true)
-	if len(proxy) > 0 {
-		l = l.Proxy(proxy)
+	if len(server) > 0 {
+		l = l.Proxy(server)
+	}
+	if err = o.setFlags(l); err != nil {
+		return nil, err
 	}
 	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	browser, err := newBrowser(b, pagePoolSize, opts...)
+	if err == nil && user != nil {
+		err = browser.addProxyAuth(server, user)
+	}
+	if err != nil {
+		_ = b.Close()
+		l.Cleanup()
+		return nil, err
+	}
+	browser.launcher = l
+	return browser, nil
+}
+
+// NewBrowserFromControlURL returns new browser with given pool size, on top of an already running browser
+// that serves DevTools protocol at the control URL, such as "ws://127.0.0.1:9222/devtools/browser/<id>" or
+// "http://127.0.0.1:9222". No browser is launched locally.
+// Note that CleanUp closes the pages of the pool and the connection, but leaves the remote browser running.
+// It returns an error with WithHostRules or WithFakeMedia, as their flags cannot be applied to the running browser.
+func NewBrowserFromControlURL(controlURL string, pagePoolSize int, opts ...BrowserOption) (*Browser, error) {
+	o := &browserOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if name := o.launchOption(); len(name) > 0 {
+		return nil, fmt.Errorf("%s cannot be applied to a running browser", name)
+	}
+	u, err := launcher.ResolveURL(controlURL)
+	if err != nil {
+		return nil, err
+	}
+	ws := &cdp.WebSocket{}
+	if err = ws.Connect(context.Background(), u, nil); err != nil {
+		return nil, err
+	}
+	b := rod.New().Client(cdp.New().Start(ws))
+	if err = b.Connect(); err != nil {
+		_ = ws.Close()
+		return nil, err
+	}
+	browser, err := newBrowser(b, pagePoolSize, opts...)
+	if err != nil {
+		_ = ws.Close()
+		return nil, err
+	}
+	browser.disconnect = ws.Close
+	return browser, nil
+}
+
+// newBrowser builds the page pool and the task queue on top of the connected browser.
+func newBrowser(b *rod.Browser, pagePoolSize int, opts ...BrowserOption) (*Browser, error) {
+	o := &browserOptions{taskQueueSize: defaultTaskQueueSize}
+	for _, opt := range opts {
+		opt(o)
+	}
 	if pagePoolSize <= 0 {
 		pagePoolSize = 1
 	}
 
+	servers, users, err := splitProxies(o.proxies)
+	if err != nil {
+		return nil, err
+	}
+
+	var robots *robotsPolicy
+	if len(o.robots) > 0 {
+		robots = newRobotsPolicy(o.robots)
+	}
+
 	pool := make(PagePool, pagePoolSize)
+	discard := func() {
+		close(pool)
+		for p := range pool {
+			_ = closeTab(p.Page, p.isolated)
+		}
+	}
 
 	wg := &sync.WaitGroup{}
+	auth := newProxyAuth()
+	pooled := make(map[proto.TargetTargetID]bool, pagePoolSize)
 	for i := 0; i < pagePoolSize; i++ {
-		page := newPage(b.MustPage(), wg.Done)
-		page.MustSetViewport(2160, 1440, 0, false)
-		pool <- page
+		proxy := ""
+		if len(servers) > 0 {
+			proxy = servers[i%len(servers)]
+		}
+		page, err := openTab(b, "", o.isolated || len(proxy) > 0, proxy)
+		if err != nil {
+			discard()
+			return nil, err
+		}
+		p := newPage(page, wg.Done)
+		p.human = o.human
+		p.limiter = o.limiter
+		p.robots = robots
+		p.collector = o.collector
+		p.tracer = o.tracer
+		p.logger = o.logger
+		p.artifactDir = o.artifactDir
+		p.auth = auth
+		p.isolated = o.isolated || len(proxy) > 0
+		p.proxy = proxy
+		pooled[page.TargetID] = true
+		pool <- p
+		if o.stealth {
+			if err = p.SetStealth(); err != nil {
+				discard()
+				return nil, err
+			}
+		}
 	}
 
 	wg.Add(pagePoolSize)
 
-	return &Browser{b, wg, pool, l}, nil
+	browser := &Browser{Browser: b, wg: wg, pagePool: pool, mu: &sync.Mutex{}, closed: make(chan struct{})}
+	browser.pooled = pooled
+	browser.limiter = o.limiter
+	browser.robots = robots
+	browser.collector = o.collector
+	browser.tracer = o.tracer
+	browser.logger = o.logger
+	browser.artifactDir = o.artifactDir
+	browser.proxyAuth = auth
+	for i, user := range users {
+		if user == nil {
+			continue
+		}
+		if err = browser.addProxyAuth(servers[i], user); err != nil {
+			discard()
+			return nil, err
+		}
+	}
+	browser.tasks = newTaskQueue(o.taskQueueSize, o.taskTimeout)
+	browser.reset = o.reset
+	browser.healthTimeout = o.healthTimeout
+	if o.leakThreshold > 0 {
+		browser.leakThreshold = o.leakThreshold
+		go browser.watchLeaks(o.leakThreshold, o.onLeak)
+	}
+	go browser.watchDisconnect(b.Event())
+	return browser, nil
 }
@@ -2,6 +2,8 @@ package chromium
 
 import (
 	"errors"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/sync/errgroup"
 	"sync"
@@ -97,3 +99,64 @@ func Test_GetPage_Returns_When_Page_Is_Back_To_Pool(t *testing.T) {
 	assert.NoError(t, g.Wait())
 	assert.LessOrEqual(t, max, cap(b.pagePool))
 }
+
+func Test_OnDisconnect_Fires_When_Browser_Is_Killed(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1)
+	assert.NoError(t, err)
+	t.Cleanup(b.launcher.Cleanup)
+	errChan := make(chan error, 1)
+	b.OnDisconnect(func(err error) { errChan <- err })
+	b.launcher.Kill()
+	select {
+	case err := <-errChan:
+		assert.ErrorIs(t, err, Disconnected)
+	case <-time.After(time.Second * 5):
+		t.Fatal("expected disconnect handler to be fired")
+	}
+}
+
+func Test_OnDisconnect_Is_Not_Fired_On_CleanUp(t *testing.T) {
+	b := &Browser{mu: &sync.Mutex{}}
+	b.closing = true
+	fired := false
+	b.OnDisconnect(func(err error) { fired = true })
+	events := make(chan *rod.Message)
+	close(events)
+	b.watchDisconnect(events)
+	assert.False(t, fired)
+}
+
+func Test_OnDisconnect_Fires_Immediately_When_Already_Disconnected(t *testing.T) {
+	b := &Browser{mu: &sync.Mutex{}}
+	events := make(chan *rod.Message)
+	close(events)
+	b.watchDisconnect(events)
+	var got error
+	b.OnDisconnect(func(err error) { got = err })
+	assert.ErrorIs(t, got, Disconnected)
+}
+
+func Test_NewBrowserFromControlURL_Returns_Err_When_Unreachable(t *testing.T) {
+	b, err := NewBrowserFromControlURL("ws://127.0.0.1:1/devtools/browser/none", 1)
+	assert.Nil(t, b)
+	assert.Error(t, err)
+}
+
+func Test_NewBrowserFromControlURL_Serves_Pages_And_Leaves_Remote_Running(t *testing.T) {
+	l := launcher.New().Leakless(true)
+	t.Cleanup(l.Cleanup)
+	controlURL := l.MustLaunch()
+
+	b, err := NewBrowserFromControlURL(controlURL, 2)
+	assert.NoError(t, err)
+	p := b.GetPage()
+	assert.NotNil(t, p)
+	b.PutPage(p)
+	b.CleanUp()
+
+	remote := rod.New().ControlURL(controlURL).MustConnect()
+	defer remote.MustClose()
+	_, err = remote.Pages()
+	assert.NoError(t, err, "expected remote browser to keep running")
+}
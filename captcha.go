@@ -0,0 +1,113 @@
+package chromium
+
+import (
+	"context"
+	"fmt"
+)
+
+// CaptchaKind is a kind of CAPTCHA widget, which tells a solver how to solve it and the page where to put the token.
+type CaptchaKind string
+
+const (
+	CaptchaReCAPTCHA CaptchaKind = "recaptcha" // Google reCAPTCHA v2 or v3.
+	CaptchaHCaptcha  CaptchaKind = "hcaptcha"  // hCaptcha.
+	CaptchaTurnstile CaptchaKind = "turnstile" // Cloudflare Turnstile.
+)
+
+// Challenge is a CAPTCHA that a page shows, along with what a solving service asks for to solve it.
+type Challenge struct {
+	Kind    CaptchaKind `json:"kind"`
+	SiteKey string      `json:"siteKey"`
+	URL     string      `json:"url"`              // URL of the page that shows the widget.
+	Action  string      `json:"action,omitempty"` // action of the widget, if any, such as of reCAPTCHA v3.
+}
+
+// Solution is a solved CAPTCHA, whose token is what the widget hands to the page once a user passes it.
+type Solution struct {
+	Token string `json:"token"`
+}
+
+// CaptchaSolver finds a CAPTCHA of a page, then solves it, such as by a solving service.
+// Detect may use DetectCaptcha, which tells the widgets that the package knows.
+type CaptchaSolver interface {
+	Detect(p *Page) (Challenge, bool)
+	Solve(ctx context.Context, c Challenge) (Solution, error)
+}
+
+// detectCaptchaJS returns the challenge of the first widget of known kinds on the document, or null if none.
+const detectCaptchaJS = `() => {
+	const param = (selector, name) => {
+		const frame = document.querySelector(selector);
+		return frame ? new URL(frame.src, location.href).searchParams.get(name) : null;
+	};
+	const widget = (selector) => document.querySelector(selector);
+	const found = (kind, el, frameKey) => {
+		const siteKey = (el && el.getAttribute('data-sitekey')) || frameKey;
+		return siteKey ? {kind, siteKey, url: location.href, action: (el && el.getAttribute('data-action')) || ''} : null;
+	};
+	return found('turnstile', widget('.cf-turnstile[data-sitekey]'), null) ||
+		found('hcaptcha', widget('.h-captcha[data-sitekey]'), param('iframe[src*="hcaptcha.com"]', 'sitekey')) ||
+		found('recaptcha', widget('.g-recaptcha[data-sitekey]'), param('iframe[src*="/recaptcha/"]', 'k'));
+}`
+
+// DetectCaptcha finds the first widget of reCAPTCHA, hCaptcha or Turnstile on the page by its site key.
+func DetectCaptcha(p *Page) (Challenge, bool) {
+	ctx, cancel := p.timeoutContext()
+	defer cancel()
+	var c *Challenge
+	obj, err := p.Context(ctx).Eval(detectCaptchaJS)
+	if err != nil || decodeJSON(obj, &c) != nil || c == nil {
+		return Challenge{}, false
+	}
+	return *c, true
+}
+
+// injectCaptchaJS puts the token where the widget of the kind hands it to the page, then calls back the page if the
+// widget has a callback. It returns the number of fields that the token is put into.
+const injectCaptchaJS = `(kind, token) => {
+	const names = {
+		recaptcha: ['g-recaptcha-response'],
+		hcaptcha: ['h-captcha-response', 'g-recaptcha-response'],
+		turnstile: ['cf-turnstile-response'],
+	}[kind] || [];
+	let count = 0;
+	for (const name of names) {
+		for (const field of document.querySelectorAll('[name="' + name + '"]')) {
+			field.value = token;
+			field.innerHTML = token;
+			field.dispatchEvent(new Event('change', {bubbles: true}));
+			count++;
+		}
+	}
+	const widget = document.querySelector({recaptcha: '.g-recaptcha', hcaptcha: '.h-captcha', turnstile: '.cf-turnstile'}[kind]);
+	const callback = widget && widget.getAttribute('data-callback');
+	if (callback && typeof window[callback] === 'function') {
+		window[callback](token);
+		count++;
+	}
+	return count;
+}`
+
+// SolveCaptcha detects a CAPTCHA of this page by the solver, solves it, then puts the token of the solution
+// where the widget hands it to the page, calling back the page if the widget has a callback.
+// It reports false without solving if the solver detects none. Submitting the form, if needed, is up to the caller.
+// It returns an error wrapping CaptchaFailed if the solver fails, or the page has no field to put the token into.
+func (p *Page) SolveCaptcha(ctx context.Context, solver CaptchaSolver) (bool, error) {
+	c, ok := solver.Detect(p)
+	if !ok {
+		return false, nil
+	}
+	solution, err := solver.Solve(ctx, c)
+	if err != nil {
+		return true, &Error{Op: opCaptcha, URL: c.URL, Err: classify(CaptchaFailed, err)}
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	obj, err := p.Context(ctx).Eval(injectCaptchaJS, string(c.Kind), solution.Token)
+	if err != nil {
+		return true, &Error{Op: opCaptcha, URL: c.URL, Err: classify(CaptchaFailed, err)}
+	} else if obj.Value.Int() == 0 {
+		return true, &Error{Op: opCaptcha, URL: c.URL, Err: fmt.Errorf("%w: no field of %s", CaptchaFailed, c.Kind)}
+	}
+	return true, nil
+}
@@ -0,0 +1,58 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// fakeSolver solves any challenge that DetectCaptcha finds by the token, or fails by the error if any.
+type fakeSolver struct {
+	token  string
+	err    error
+	solved []Challenge
+}
+
+func (s *fakeSolver) Detect(p *Page) (Challenge, bool) {
+	return DetectCaptcha(p)
+}
+
+func (s *fakeSolver) Solve(_ context.Context, c Challenge) (Solution, error) {
+	s.solved = append(s.solved, c)
+	return Solution{Token: s.token}, s.err
+}
+
+func Test_SolveCaptcha_Injects_Token_And_Calls_Back(t *testing.T) {
+	_, p, s := setup(t, []byte(`<html><body><form>
+<div class="g-recaptcha" data-sitekey="site-key" data-callback="onSolved"></div>
+<textarea name="g-recaptcha-response"></textarea>
+</form><script>function onSolved(token) { window.solved = token }</script></body></html>`))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	solver := &fakeSolver{token: "token"}
+	ok, err := p.SolveCaptcha(context.Background(), solver)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	if assert.Len(t, solver.solved, 1) {
+		assert.Equal(t, CaptchaReCAPTCHA, solver.solved[0].Kind)
+		assert.Equal(t, "site-key", solver.solved[0].SiteKey)
+		assert.Equal(t, s.URL+"/", solver.solved[0].URL)
+	}
+	assert.Equal(t, "token", p.MustElement(`[name="g-recaptcha-response"]`).MustProperty("value").Str())
+	assert.Equal(t, "token", p.MustEval(`() => window.solved`).Str())
+
+	solver.err = errors.New("no balance")
+	_, err = p.SolveCaptcha(context.Background(), solver)
+	assert.ErrorIs(t, err, CaptchaFailed)
+}
+
+func Test_SolveCaptcha_Reports_False_Without_Captcha(t *testing.T) {
+	_, p, s := setup(t)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	solver := &fakeSolver{token: "token"}
+	ok, err := p.SolveCaptcha(context.Background(), solver)
+	assert.False(t, ok)
+	assert.NoError(t, err)
+	assert.Empty(t, solver.solved)
+}
@@ -0,0 +1,74 @@
+package chromium
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// detectChallengeJS returns the name of the anti-bot interstitial that the document shows by its DOM signatures,
+// or empty string if none, along with whether the document has completely loaded.
+const detectChallengeJS = `() => {
+	const any = (...selectors) => selectors.some(s => document.querySelector(s) !== null);
+	const title = document.title || '';
+	let name = '';
+	if (any('#challenge-form', '#challenge-running', '#challenge-stage', '#cf-challenge-running', '#cf-please-wait',
+			'iframe[src*="challenges.cloudflare.com"]', 'script[src*="/cdn-cgi/challenge-platform/"]') ||
+		title === 'Just a moment...' || title.startsWith('Attention Required! | Cloudflare')) {
+		name = 'cloudflare';
+	} else if (any('#px-captcha', '[id^="px-captcha"]', 'iframe[src*="px-cloud.net"]', 'iframe[src*="perimeterx.net"]') ||
+		title === 'Access to this page has been denied') {
+		name = 'perimeterx';
+	} else if (any('iframe[src*="captcha-delivery.com"]', 'iframe[src*="geo.captcha-delivery.com"]')) {
+		name = 'datadome';
+	}
+	return {name, ready: document.readyState === 'complete'};
+}`
+
+// challengeState is the result of detectChallengeJS.
+type challengeState struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+}
+
+// DetectChallenge returns the name of the anti-bot interstitial that this page shows, which is one of
+// "cloudflare", "perimeterx" and "datadome", or empty string if the page shows none of them.
+func (p *Page) DetectChallenge() (string, error) {
+	ctx, cancel := p.timeoutContext()
+	defer cancel()
+	var state challengeState
+	obj, err := p.Context(ctx).Eval(detectChallengeJS)
+	if err == nil {
+		err = decodeJSON(obj, &state)
+	}
+	return state.Name, replaceTimeoutError(replaceAbortedError(err))
+}
+
+// WaitChallengeResolved waits until this page shows none of the anti-bot interstitials that DetectChallenge tells,
+// and the document has completely loaded, polling by the poll interval of this page.
+// Interstitials such as the one of Cloudflare reload the page once passed, during which errors of evaluation are ignored.
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping ChallengeNotResolved, along with the name of the interstitial, if it stays in time,
+// or TaskTimeout if the page shows none but has not completely loaded in time.
+func (p *Page) WaitChallengeResolved(timeout time.Duration) error {
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	last := ""
+	_, err := Await(ctx, func() (bool, bool, error) {
+		var state challengeState
+		obj, err := page.Eval(detectChallengeJS)
+		if err != nil || decodeJSON(obj, &state) != nil {
+			// the document may be replaced meanwhile, which is told by the next poll.
+			return false, false, ctx.Err()
+		}
+		last = state.Name
+		return true, len(state.Name) == 0 && state.Ready, nil
+	}, p.pollInterval(), 0)
+	if errors.Is(err, TaskTimeout) && len(last) > 0 {
+		return &Error{Op: opChallenge, Err: fmt.Errorf("%w: %s", ChallengeNotResolved, last)}
+	} else if err != nil {
+		return &Error{Op: opChallenge, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_WaitChallengeResolved_Waits_For_Interstitial_To_Clear(t *testing.T) {
+	challenge := []byte(`<html><head><title>Just a moment...</title></head>
+<body><div id="challenge-running"></div>
+<script>setTimeout(() => { document.title = 'Home'; document.querySelector('#challenge-running').remove() }, 300)</script>
+</body></html>`)
+	_, p, s := setup(t, challenge)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	name, err := p.DetectChallenge()
+	assert.NoError(t, err)
+	assert.Equal(t, "cloudflare", name)
+
+	assert.NoError(t, p.WaitChallengeResolved(time.Second*5))
+	name, err = p.DetectChallenge()
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func Test_WaitChallengeResolved_Returns_ChallengeNotResolved_On_Timeout(t *testing.T) {
+	_, p, _ := setup(t)
+	s := testserver.WithRotatingResponses(t, []byte(`<html><body><div id="px-captcha"></div></body></html>`))
+	t.Cleanup(s.Close)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	err := p.WaitChallengeResolved(time.Millisecond * 300)
+	assert.ErrorIs(t, err, ChallengeNotResolved)
+	assert.Contains(t, err.Error(), "perimeterx")
+}
@@ -0,0 +1,50 @@
+package chromium
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/go-rod/rod/lib/proto"
+	"golang.org/x/net/html/charset"
+)
+
+// DecodeContent decodes raw content into a UTF-8 string, detecting its charset by the byte order mark,
+// the charset parameter of given Content-Type, or meta charset of HTML, in that order.
+// Legacy charsets such as EUC-KR and Shift_JIS are supported.
+func DecodeContent(body []byte, contentType string) (string, error) {
+	enc, name, _ := charset.DetermineEncoding(body, contentType)
+	decoded, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode content as %+v: %w", name, err)
+	}
+	return string(decoded), nil
+}
+
+// decodeBody decodes a body of the DevTools protocol into a UTF-8 string. The browser hands text that it has decoded
+// already as is, whereas it hands raw bytes in base64, which are decoded by DecodeContent with the content type.
+func decodeBody(body string, base64Encoded bool, contentType string) (string, error) {
+	if !base64Encoded {
+		return body, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return "", err
+	}
+	return DecodeContent(raw, contentType)
+}
+
+// DocumentSource returns the source HTML of the current document as served, before any script modified it.
+// The source is the body of the response that the browser has kept for the document, rather than the one of another
+// request, thus it is the same for results of POST requests and pages that are not to be cached.
+// The source is decoded by DecodeContent, such that legacy pages without UTF-8 are returned without mojibake.
+func (p *Page) DocumentSource() (string, error) {
+	tree, err := proto.PageGetFrameTree{}.Call(p)
+	if err != nil {
+		return "", replaceAbortedError(err)
+	}
+	frame := tree.FrameTree.Frame
+	res, err := proto.PageGetResourceContent{FrameID: frame.ID, URL: frame.URL}.Call(p)
+	if err != nil {
+		return "", replaceAbortedError(err)
+	}
+	return decodeBody(res.Content, res.Base64Encoded, frame.MIMEType)
+}
@@ -0,0 +1,85 @@
+package chromium
+
+import (
+	"encoding/base64"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"net/http"
+	"testing"
+)
+
+const koreanText, japaneseText = "안녕하세요", "こんにちは"
+
+func Test_DecodeContent_Detects_Charset_From_Content_Type(t *testing.T) {
+	body, err := korean.EUCKR.NewEncoder().Bytes([]byte(koreanText))
+	assert.NoError(t, err)
+	decoded, err := DecodeContent(body, "text/plain; charset=euc-kr")
+	assert.NoError(t, err)
+	assert.Equal(t, koreanText, decoded)
+}
+
+func Test_DecodeContent_Detects_Charset_From_Meta(t *testing.T) {
+	body, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(`<html><head><meta charset="shift_jis"></head><body>` + japaneseText + `</body></html>`))
+	assert.NoError(t, err)
+	decoded, err := DecodeContent(body, "text/html")
+	assert.NoError(t, err)
+	assert.Contains(t, decoded, japaneseText)
+}
+
+func Test_DecodeContent_Keeps_UTF8_As_Is(t *testing.T) {
+	decoded, err := DecodeContent([]byte(koreanText), "text/html; charset=utf-8")
+	assert.NoError(t, err)
+	assert.Equal(t, koreanText, decoded)
+}
+
+func Test_DocumentSource_Decodes_Legacy_Charset(t *testing.T) {
+	body, err := korean.EUCKR.NewEncoder().Bytes([]byte(`<html><head><meta charset="euc-kr"></head><body><p>` + koreanText + `</p></body></html>`))
+	assert.NoError(t, err)
+	_, p, _ := setup(t)
+	s := testserver.WithRotatingResponses(t, body)
+	t.Cleanup(s.Close)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	source, err := p.DocumentSource()
+	assert.NoError(t, err)
+	assert.Contains(t, source, koreanText)
+}
+
+func Test_DocumentSource_Returns_Body_As_Served_Without_Another_Request(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval("() => document.body.innerHTML = ''")
+
+	source, err := p.DocumentSource()
+	assert.NoError(t, err)
+	assert.Equal(t, string(testfile.ItemsHTML), source)
+	requestCountMustBeAsExpected(t, s, 1)
+}
+
+func Test_CapturedResponse_Text_Decodes_Body_As_Served(t *testing.T) {
+	body, err := korean.EUCKR.NewEncoder().Bytes([]byte(koreanText))
+	assert.NoError(t, err)
+	res := &CapturedResponse{Header: http.Header{"Content-Type": {"text/plain; charset=euc-kr"}}, Body: body, raw: true}
+	text, err := res.Text()
+	assert.NoError(t, err)
+	assert.Equal(t, koreanText, text)
+
+	res = &CapturedResponse{Header: http.Header{"Content-Type": {"text/plain; charset=euc-kr"}}, Body: []byte(koreanText)}
+	text, err = res.Text()
+	assert.NoError(t, err)
+	assert.Equal(t, koreanText, text)
+}
+
+func Test_decodeBody_Decodes_Base64_By_Content_Type(t *testing.T) {
+	body, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(japaneseText))
+	assert.NoError(t, err)
+	decoded, err := decodeBody(base64.StdEncoding.EncodeToString(body), true, "text/html; charset=shift_jis")
+	assert.NoError(t, err)
+	assert.Equal(t, japaneseText, decoded)
+	decoded, err = decodeBody(japaneseText, false, "text/html; charset=shift_jis")
+	assert.NoError(t, err)
+	assert.Equal(t, japaneseText, decoded)
+}
@@ -0,0 +1,90 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"strings"
+)
+
+// ClearOptions decides which browsing data ClearBrowsingData wipes.
+type ClearOptions struct {
+	// Cache clears the HTTP cache, which is shared by all browser contexts of the browser.
+	Cache bool
+	// Cookies deletes all cookies of the browser context of the page, regardless of their sites.
+	Cookies bool
+	// Storage clears localStorage, Cache Storage, file systems and WebSQL of the origins,
+	// along with sessionStorage of the page if the origins default to the one of the page.
+	Storage bool
+	// IndexedDB deletes the IndexedDB databases of the origins.
+	IndexedDB bool
+	// ServiceWorkers unregisters the service workers of the origins.
+	ServiceWorkers bool
+	// Origins are the origins whose data is cleared, such as "https://example.com", which defaults to the origin of
+	// the current URL of the page.
+	Origins []string
+}
+
+// ClearAll wipes all browsing data that ClearOptions covers, of the current origin of the page.
+var ClearAll = ClearOptions{Cache: true, Cookies: true, Storage: true, IndexedDB: true, ServiceWorkers: true}
+
+// storageTypes returns the storage types to clear for each origin, as of Storage.clearDataForOrigin.
+func (o ClearOptions) storageTypes() string {
+	types := make([]string, 0, 7)
+	if o.Storage {
+		types = append(types, string(proto.StorageStorageTypeLocalStorage), string(proto.StorageStorageTypeCacheStorage),
+			string(proto.StorageStorageTypeFileSystems), string(proto.StorageStorageTypeWebsql))
+	}
+	if o.IndexedDB {
+		types = append(types, string(proto.StorageStorageTypeIndexeddb))
+	}
+	if o.ServiceWorkers {
+		types = append(types, string(proto.StorageStorageTypeServiceWorkers))
+	}
+	return strings.Join(types, ",")
+}
+
+// ClearBrowsingData wipes the browsing data of this page by the options in one call, such that a job leaves nothing
+// behind for the next one where isolated pages such as of WithIsolatedPages are not available.
+// The page stays where it is, thus the page may need a reload or a navigation to forget what it holds in memory.
+// Note that cookies and storages are shared by pages of the same browser context, which are all the pages that are
+// not isolated.
+func (p *Page) ClearBrowsingData(opts ClearOptions) error {
+	if opts.Cache {
+		if err := (proto.NetworkClearBrowserCache{}).Call(p); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	if opts.Cookies {
+		req := proto.StorageClearCookies{BrowserContextID: p.Browser().BrowserContextID}
+		if err := req.Call(p.Browser()); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	types := opts.storageTypes()
+	if len(types) == 0 {
+		return nil
+	}
+	origins := opts.Origins
+	if len(origins) == 0 {
+		info, err := p.Info()
+		if err != nil {
+			return replaceAbortedError(err)
+		}
+		origins = []string{originOf(info.URL)}
+	}
+	for _, origin := range origins {
+		origin = originOf(origin)
+		if !strings.Contains(origin, "://") {
+			continue // opaque origins such as about:blank have no data to clear.
+		}
+		if err := (proto.StorageClearDataForOrigin{Origin: origin, StorageTypes: types}).Call(p); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	if opts.Storage && len(opts.Origins) == 0 {
+		// sessionStorage is not covered by StorageClearDataForOrigin, as it belongs to the tab rather than the origin.
+		if _, err := p.Eval(clearStorageJS); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// seedBrowsingDataJS leaves a cookie, items of both storages and a database of IndexedDB on the current origin.
+const seedBrowsingDataJS = `() => new Promise(resolve => {
+	document.cookie = "id=1";
+	localStorage.setItem("flag", "on");
+	sessionStorage.setItem("token", "secret");
+	indexedDB.open("jobs").onsuccess = (e) => { e.target.result.close(); resolve(); };
+})`
+
+func Test_ClearOptions_storageTypes(t *testing.T) {
+	assert.Equal(t, "", ClearOptions{Cache: true, Cookies: true}.storageTypes())
+	assert.Equal(t, "indexeddb,service_workers", ClearOptions{IndexedDB: true, ServiceWorkers: true}.storageTypes())
+	assert.Equal(t, "local_storage,cache_storage,file_systems,websql,indexeddb,service_workers", ClearAll.storageTypes())
+}
+
+func Test_ClearBrowsingData_Wipes_Cookies(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	p.MustEval(seedBrowsingDataJS)
+	assert.NoError(t, p.ClearBrowsingData(ClearOptions{Cookies: true}))
+	cookies, err := p.Cookies(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, cookies)
+}
+
+func Test_ClearBrowsingData_Wipes_Storages(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	p.MustEval(seedBrowsingDataJS)
+	assert.NoError(t, p.ClearBrowsingData(ClearOptions{Storage: true}))
+	local, err := p.LocalStorage()
+	assert.NoError(t, err)
+	assert.Empty(t, local)
+	session, err := p.SessionStorage()
+	assert.NoError(t, err)
+	assert.Empty(t, session)
+}
+
+func Test_ClearBrowsingData_Wipes_IndexedDB(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	p.MustEval(seedBrowsingDataJS)
+	assert.NoError(t, p.ClearBrowsingData(ClearOptions{IndexedDB: true}))
+	names := p.MustEval(`() => indexedDB.databases().then(dbs => dbs.map(db => db.name))`)
+	assert.Empty(t, names.Arr())
+}
+
+func Test_ClearBrowsingData_Ignores_Page_Without_Origin(t *testing.T) {
+	_, p, _ := setup(t)
+	assert.NoError(t, p.Navigate(blankURL))
+	assert.NoError(t, p.ClearBrowsingData(ClearAll))
+}
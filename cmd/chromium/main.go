@@ -0,0 +1,149 @@
+// Command chromium executes a script of browsing commands with a headless browser.
+//
+// A script consists of a command per line, where arguments containing spaces must be double-quoted:
+//
+//	navigate <url>                  navigates to the url and waits for the page to load.
+//	wait <selector>                 waits for an element to be visible.
+//	waitjs <object> [timeout]       waits for a JavaScript object, such as "window.app.ready".
+//	click <selector>                clicks a visible element.
+//	input <selector> <text>         replaces text of an input element.
+//	screenshot <file>               saves a screenshot of the viewport as PNG.
+//	pdf <file>                      saves the page as PDF.
+//	extract <selector> [attribute]  prints text, or the attribute, of each matching element to stdout.
+//	sleep <duration>                pauses for the duration, such as 500ms.
+//
+// Selectors may be of any kind that chromium.ParseSelector accepts, such as "xpath=//a" and "text=Sign in".
+// The script is read from the file given by -script, or stdin if omitted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/state303/chromium"
+	"io"
+	"os"
+	"time"
+)
+
+func main() {
+	scriptPath := flag.String("script", "", "path of the script to run; stdin if omitted")
+	proxy := flag.String("proxy", "", "proxy of the browser, such as 127.0.0.1:8080")
+	timeout := flag.Duration("timeout", time.Second*30, "default timeout of each command")
+	flag.Parse()
+
+	if err := run(*scriptPath, *proxy, *timeout, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(scriptPath, proxy string, timeout time.Duration, out io.Writer) error {
+	in := os.Stdin
+	if len(scriptPath) > 0 {
+		f, err := os.Open(scriptPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	commands, err := parseScript(in)
+	if err != nil {
+		return err
+	}
+
+	b, err := chromium.NewBrowserWithProxy(1, proxy)
+	if err != nil {
+		return err
+	}
+	p := b.GetPage()
+	defer func() { b.PutPage(p); b.CleanUp() }()
+	p.SetDefaultTimeout(timeout)
+
+	for _, cmd := range commands {
+		if err = execute(p, cmd, out); err != nil {
+			return fmt.Errorf("line %d, %+v: %w", cmd.line, cmd.name, err)
+		}
+	}
+	return nil
+}
+
+// execute runs a single command against the page.
+// Commands that have a counterpart of chromium.Step are executed by chromium.RunStep, as steps of scripts are.
+func execute(p *chromium.Page, cmd command, out io.Writer) error {
+	s, ok, err := toStep(cmd)
+	if err != nil {
+		return err
+	} else if ok {
+		values, err := chromium.RunStep(p, s)
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			if _, err = fmt.Fprintln(out, value); err != nil {
+				return err
+			}
+		}
+		if s.Action == chromium.ActionNavigate {
+			return p.WaitLoad()
+		}
+		return nil
+	}
+	switch cmd.name {
+	case "screenshot":
+		data, err := p.Screenshot(chromium.ScreenshotOptions{})
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(cmd.args[0], data, 0644)
+	case "pdf":
+		r, err := p.PDF(chromium.PDFOptions{})
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(cmd.args[0], data, 0644)
+	case "sleep":
+		d, err := time.ParseDuration(cmd.args[0])
+		if err != nil {
+			return err
+		}
+		time.Sleep(d)
+		return nil
+	}
+	return fmt.Errorf("unknown command %+v", cmd.name)
+}
+
+// toStep converts the command into a step of chromium.Script, reporting false for commands that scripts do not have.
+func toStep(cmd command) (chromium.Step, bool, error) {
+	switch cmd.name {
+	case "navigate":
+		return chromium.Step{Action: chromium.ActionNavigate, URL: cmd.args[0]}, true, nil
+	case "wait":
+		return chromium.Step{Action: chromium.ActionWait, Selector: cmd.args[0]}, true, nil
+	case "waitjs":
+		step := chromium.Step{Action: chromium.ActionWaitJS, Text: cmd.args[0]}
+		if len(cmd.args) > 1 {
+			d, err := time.ParseDuration(cmd.args[1])
+			if err != nil {
+				return step, false, err
+			}
+			step.Timeout = chromium.Duration(d)
+		}
+		return step, true, nil
+	case "click":
+		return chromium.Step{Action: chromium.ActionClick, Selector: cmd.args[0]}, true, nil
+	case "input":
+		return chromium.Step{Action: chromium.ActionInput, Selector: cmd.args[0], Text: cmd.args[1]}, true, nil
+	case "extract":
+		step := chromium.Step{Action: chromium.ActionExtract, Selector: cmd.args[0]}
+		if len(cmd.args) > 1 {
+			step.Attribute = cmd.args[1]
+		}
+		return step, true, nil
+	}
+	return chromium.Step{}, false, nil
+}
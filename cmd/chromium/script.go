@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// command is a single line of a script, that consists of its name and arguments.
+type command struct {
+	line int
+	name string
+	args []string
+}
+
+// arity holds the minimum and maximum number of arguments of each command.
+var arity = map[string][2]int{
+	"navigate":   {1, 1},
+	"wait":       {1, 1},
+	"waitjs":     {1, 2},
+	"click":      {1, 1},
+	"input":      {2, 2},
+	"screenshot": {1, 1},
+	"pdf":        {1, 1},
+	"extract":    {1, 2},
+	"sleep":      {1, 1},
+}
+
+// parseScript reads commands from the reader, one for each line.
+// Blank lines and lines beginning with # are ignored. Arguments containing spaces must be double-quoted.
+func parseScript(r io.Reader) ([]command, error) {
+	commands := make([]command, 0)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+		tokens, err := tokenize(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		cmd := command{line: line, name: strings.ToLower(tokens[0]), args: tokens[1:]}
+		bounds, found := arity[cmd.name]
+		if !found {
+			return nil, fmt.Errorf("line %d: unknown command %+v", line, tokens[0])
+		} else if len(cmd.args) < bounds[0] || len(cmd.args) > bounds[1] {
+			return nil, fmt.Errorf("line %d: %+v expects %d to %d arguments, got %d", line, cmd.name, bounds[0], bounds[1], len(cmd.args))
+		}
+		commands = append(commands, cmd)
+	}
+	return commands, scanner.Err()
+}
+
+// tokenize splits the text by spaces, keeping double-quoted tokens as a whole.
+func tokenize(text string) ([]string, error) {
+	tokens := make([]string, 0)
+	for text = strings.TrimLeftFunc(text, unicode.IsSpace); len(text) > 0; text = strings.TrimLeftFunc(text, unicode.IsSpace) {
+		if text[0] != '"' {
+			end := strings.IndexFunc(text, unicode.IsSpace)
+			if end < 0 {
+				end = len(text)
+			}
+			tokens = append(tokens, text[:end])
+			text = text[end:]
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(text)
+		if err != nil {
+			return nil, fmt.Errorf("unterminated quote: %+v", text)
+		}
+		token, _ := strconv.Unquote(quoted)
+		tokens = append(tokens, token)
+		text = text[len(quoted):]
+	}
+	return tokens, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/state303/chromium"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseScript_Parses_Commands_And_Skips_Comments(t *testing.T) {
+	script := `
+# comment
+navigate https://example.com
+input "#search > input" "hello world"
+EXTRACT li href
+`
+	commands, err := parseScript(strings.NewReader(script))
+	assert.NoError(t, err)
+	if assert.Len(t, commands, 3) {
+		assert.Equal(t, command{line: 3, name: "navigate", args: []string{"https://example.com"}}, commands[0])
+		assert.Equal(t, []string{"#search > input", "hello world"}, commands[1].args)
+		assert.Equal(t, "extract", commands[2].name)
+	}
+}
+
+func Test_parseScript_Returns_Err_On_Unknown_Command(t *testing.T) {
+	_, err := parseScript(strings.NewReader("fly away"))
+	assert.ErrorContains(t, err, "line 1")
+	assert.ErrorContains(t, err, "unknown command")
+}
+
+func Test_parseScript_Returns_Err_On_Wrong_Number_Of_Arguments(t *testing.T) {
+	_, err := parseScript(strings.NewReader("navigate\n"))
+	assert.ErrorContains(t, err, "arguments")
+	_, err = parseScript(strings.NewReader("input a b c"))
+	assert.ErrorContains(t, err, "arguments")
+}
+
+func Test_tokenize_Returns_Err_On_Unterminated_Quote(t *testing.T) {
+	_, err := tokenize(`input "#item0 test`)
+	assert.ErrorContains(t, err, "quote")
+}
+
+func Test_tokenize_Supports_Escaped_Quotes(t *testing.T) {
+	tokens, err := tokenize(`input a "say \"hi\""`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"input", "a", `say "hi"`}, tokens)
+}
+
+func Test_toStep_Converts_Commands_Of_Scripts(t *testing.T) {
+	s, ok, err := toStep(command{name: "waitjs", args: []string{"window.app", "2s"}})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, chromium.Step{Action: chromium.ActionWaitJS, Text: "window.app", Timeout: chromium.Duration(2 * time.Second)}, s)
+
+	s, ok, err = toStep(command{name: "extract", args: []string{"xpath=//a", "href"}})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, chromium.Step{Action: chromium.ActionExtract, Selector: "xpath=//a", Attribute: "href"}, s)
+
+	_, ok, err = toStep(command{name: "screenshot", args: []string{"out.png"}})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = toStep(command{name: "waitjs", args: []string{"window.app", "soon"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package chromium
+
+import (
+	"context"
+	"github.com/go-rod/rod/lib/proto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConsoleMessage is a message that a page has written to its console, such as via console.log.
+type ConsoleMessage struct {
+	Type  string       // type of the call, such as log, warning and error.
+	Text  string       // arguments of the call, joined by spaces.
+	Time  time.Time    // time of the call.
+	Stack []StackFrame // stack of the call, from the innermost frame.
+}
+
+// JSError is an exception that has not been caught by scripts of a page.
+type JSError struct {
+	Message string
+	URL     string
+	Line    int // zero-based line of the exception.
+	Column  int // zero-based column of the exception.
+	Time    time.Time
+	Stack   []StackFrame // stack of the exception, from the innermost frame.
+}
+
+// StackFrame is a frame of a JavaScript stack.
+type StackFrame struct {
+	Function string
+	URL      string
+	Line     int // zero-based line of the frame.
+	Column   int // zero-based column of the frame.
+}
+
+// consoleRecorder keeps console messages and exceptions of a page, up to defaultConsoleLimit of each.
+type consoleRecorder struct {
+	mu       *sync.Mutex
+	messages []ConsoleMessage
+	errors   []JSError
+	stop     func()
+}
+
+// CaptureConsole begins to capture console messages and uncaught exceptions of this page,
+// which are available via ConsoleLogs and JSErrors until the page is closed or the returned stop is called.
+// Only the latest 1000 messages and exceptions are kept each.
+// If the capture is already in progress, it returns the stop of the capture instead.
+func (p *Page) CaptureConsole() (stop func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.console != nil {
+		return p.console.stop
+	}
+	rec := &consoleRecorder{mu: &sync.Mutex{}}
+	ctx, cancel := context.WithCancel(p.GetContext())
+	wait := p.Context(ctx).EachEvent(rec.consoleAPICalled, rec.exceptionThrown)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait()
+	}()
+	once := &sync.Once{}
+	rec.stop = func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+	p.console = rec
+	return rec.stop
+}
+
+// detachConsole stops the capture of this page if any, such that CaptureConsole begins a new one,
+// e.g. on the tab that replaces the current one.
+func (p *Page) detachConsole() {
+	p.mu.Lock()
+	rec := p.console
+	p.console = nil
+	p.mu.Unlock()
+	if rec != nil {
+		rec.stop()
+	}
+}
+
+// ConsoleLogs returns a copy of console messages captured by CaptureConsole, from the oldest to the latest.
+func (p *Page) ConsoleLogs() []ConsoleMessage {
+	rec := p.consoleRecorder()
+	if rec == nil {
+		return make([]ConsoleMessage, 0)
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append(make([]ConsoleMessage, 0, len(rec.messages)), rec.messages...)
+}
+
+// JSErrors returns a copy of uncaught exceptions captured by CaptureConsole, from the oldest to the latest.
+func (p *Page) JSErrors() []JSError {
+	rec := p.consoleRecorder()
+	if rec == nil {
+		return make([]JSError, 0)
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append(make([]JSError, 0, len(rec.errors)), rec.errors...)
+}
+
+func (p *Page) consoleRecorder() *consoleRecorder {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.console
+}
+
+func (r *consoleRecorder) consoleAPICalled(e *proto.RuntimeConsoleAPICalled) {
+	texts := make([]string, 0, len(e.Args))
+	for _, arg := range e.Args {
+		texts = append(texts, remoteObjectText(arg))
+	}
+	message := ConsoleMessage{Type: string(e.Type), Text: strings.Join(texts, " "), Time: timestampTime(e.Timestamp), Stack: stackFrames(e.StackTrace)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, message)
+	if len(r.messages) > defaultConsoleLimit {
+		r.messages = r.messages[len(r.messages)-defaultConsoleLimit:]
+	}
+}
+
+func (r *consoleRecorder) exceptionThrown(e *proto.RuntimeExceptionThrown) {
+	details := e.ExceptionDetails
+	jsErr := JSError{
+		Message: details.Text,
+		URL:     details.URL,
+		Line:    details.LineNumber,
+		Column:  details.ColumnNumber,
+		Time:    timestampTime(e.Timestamp),
+		Stack:   stackFrames(details.StackTrace),
+	}
+	if details.Exception != nil {
+		// the description of an error begins with its message, followed by its stack.
+		jsErr.Message, _, _ = strings.Cut(remoteObjectText(details.Exception), "\n")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, jsErr)
+	if len(r.errors) > defaultConsoleLimit {
+		r.errors = r.errors[len(r.errors)-defaultConsoleLimit:]
+	}
+}
+
+// remoteObjectText returns the text of the object as the console of browsers would print.
+func remoteObjectText(obj *proto.RuntimeRemoteObject) string {
+	switch {
+	case obj.Type == proto.RuntimeRemoteObjectTypeString:
+		return obj.Value.Str()
+	case obj.Type == proto.RuntimeRemoteObjectTypeUndefined:
+		return "undefined"
+	case len(obj.UnserializableValue) > 0:
+		return string(obj.UnserializableValue)
+	case len(obj.Description) > 0:
+		return obj.Description
+	default:
+		return obj.Value.JSON("", "")
+	}
+}
+
+// timestampTime converts milliseconds since epoch to time.
+func timestampTime(t proto.RuntimeTimestamp) time.Time {
+	return time.UnixMicro(int64(float64(t) * 1000))
+}
+
+func stackFrames(trace *proto.RuntimeStackTrace) []StackFrame {
+	if trace == nil {
+		return nil
+	}
+	frames := make([]StackFrame, 0, len(trace.CallFrames))
+	for _, f := range trace.CallFrames {
+		frames = append(frames, StackFrame{Function: f.FunctionName, URL: f.URL, Line: f.LineNumber, Column: f.ColumnNumber})
+	}
+	return frames
+}
@@ -0,0 +1,82 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysmood/gson"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_remoteObjectText_Formats_Like_Console(t *testing.T) {
+	assert.Equal(t, "text", remoteObjectText(&proto.RuntimeRemoteObject{Type: proto.RuntimeRemoteObjectTypeString, Value: gson.New("text")}))
+	assert.Equal(t, "1", remoteObjectText(&proto.RuntimeRemoteObject{Type: proto.RuntimeRemoteObjectTypeNumber, Value: gson.New(1), Description: "1"}))
+	assert.Equal(t, "undefined", remoteObjectText(&proto.RuntimeRemoteObject{Type: proto.RuntimeRemoteObjectTypeUndefined}))
+	assert.Equal(t, "NaN", remoteObjectText(&proto.RuntimeRemoteObject{Type: proto.RuntimeRemoteObjectTypeNumber, UnserializableValue: "NaN"}))
+	assert.Equal(t, "Object", remoteObjectText(&proto.RuntimeRemoteObject{Type: proto.RuntimeRemoteObjectTypeObject, Description: "Object"}))
+}
+
+func Test_consoleRecorder_Keeps_Latest_Messages(t *testing.T) {
+	rec := &consoleRecorder{mu: &sync.Mutex{}}
+	for i := 0; i < defaultConsoleLimit+1; i++ {
+		rec.consoleAPICalled(&proto.RuntimeConsoleAPICalled{Type: proto.RuntimeConsoleAPICalledTypeLog, Timestamp: proto.RuntimeTimestamp(i)})
+	}
+	assert.Len(t, rec.messages, defaultConsoleLimit)
+	assert.Equal(t, time.UnixMilli(1), rec.messages[0].Time)
+}
+
+func Test_consoleRecorder_Takes_First_Line_Of_Exception(t *testing.T) {
+	rec := &consoleRecorder{mu: &sync.Mutex{}}
+	rec.exceptionThrown(&proto.RuntimeExceptionThrown{ExceptionDetails: &proto.RuntimeExceptionDetails{
+		Text:       "Uncaught",
+		Exception:  &proto.RuntimeRemoteObject{Type: proto.RuntimeRemoteObjectTypeObject, Description: "Error: boom\n    at <anonymous>:1:7"},
+		StackTrace: &proto.RuntimeStackTrace{CallFrames: []*proto.RuntimeCallFrame{{FunctionName: "f", LineNumber: 1}}},
+	}})
+	if assert.Len(t, rec.errors, 1) {
+		assert.Equal(t, "Error: boom", rec.errors[0].Message)
+		assert.Equal(t, []StackFrame{{Function: "f", Line: 1}}, rec.errors[0].Stack)
+	}
+}
+
+func Test_ConsoleLogs_Returns_Empty_Without_Capture(t *testing.T) {
+	p := newPage(nil, func() {})
+	assert.Empty(t, p.ConsoleLogs())
+	assert.Empty(t, p.JSErrors())
+}
+
+func Test_CaptureConsole_Captures_Logs_And_Errors(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	stop := p.CaptureConsole()
+	t.Cleanup(stop)
+	p.MustEval(`() => { console.warn("hello", 1); setTimeout(() => { throw new Error("boom") }) }`)
+
+	assert.Eventually(t, func() bool { return len(p.JSErrors()) == 1 }, time.Second*5, time.Millisecond*10)
+	logs := p.ConsoleLogs()
+	if assert.Len(t, logs, 1) {
+		assert.Equal(t, "warning", logs[0].Type)
+		assert.Equal(t, "hello 1", logs[0].Text)
+	}
+	assert.Contains(t, p.JSErrors()[0].Message, "boom")
+}
+
+func Test_detachConsole_Stops_Capture(t *testing.T) {
+	p := newPage(nil, func() {})
+	stopped := false
+	p.console = &consoleRecorder{mu: &sync.Mutex{}, stop: func() { stopped = true }}
+	p.detachConsole()
+	assert.True(t, stopped)
+	assert.Nil(t, p.console)
+	p.detachConsole()
+}
+
+func Test_CaptureConsole_Captures_Recycled_Page(t *testing.T) {
+	p, s := recycledPage(t, func(p *Page) { p.CaptureConsole() })
+	p.MustNavigate(s.URL).MustWaitLoad()
+	stop := p.CaptureConsole()
+	t.Cleanup(stop)
+	p.MustEval(`() => console.log("recycled")`)
+	assert.Eventually(t, func() bool { return len(p.ConsoleLogs()) == 1 }, time.Second*5, time.Millisecond*10)
+}
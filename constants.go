@@ -1,7 +1,39 @@
 package chromium
 
+import "time"
+
 /* constants */
 
 const (
-	abortedError = "net::ERR_ABORTED"
+	abortedError       = "net::ERR_ABORTED"
+	networkErrorPrefix = "net::ERR_"
+	blankURL           = "about:blank"
+
+	defaultTaskQueueSize = 128
+	defaultPollInterval  = 100 * time.Millisecond
+	defaultDialogLimit   = 100
+	defaultConsoleLimit  = 1000
+	defaultHistoryLimit  = 100
+
+	// operations of Error other than actions of Step.
+	opFind          = "find"
+	opWaitText      = "waitText"
+	opWaitURL       = "waitURL"
+	opScreenshot    = "screenshot"
+	opKeys          = "keys"
+	opChallenge     = "challenge"
+	opCaptcha       = "captcha"
+	opWaitResponse  = "waitResponse"
+	opTable         = "table"
+	opUnmarshal     = "unmarshal"
+	opPaginate      = "paginate"
+	opWaitJSTrue    = "waitJSTrue"
+	opWaitGone      = "waitGone"
+	opWaitDOMStable = "waitDOMStable"
+	opBox           = "box"
+	opScroll        = "scroll"
+	opLazyLoad      = "lazyLoad"
+
+	defaultViewportWidth  = 2160
+	defaultViewportHeight = 1440
 )
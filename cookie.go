@@ -0,0 +1,190 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SetCookies sets the cookies to the browser context of this page.
+// Cookies without domain are scoped to the current URL of this page.
+// Note that it shadows SetCookies of rod.Page, which is still available via the embedded page.
+func (p *Page) SetCookies(cookies []*http.Cookie) error {
+	if len(cookies) == 0 {
+		return nil
+	}
+	var url string
+	params := make([]*proto.NetworkCookieParam, 0, len(cookies))
+	for _, c := range cookies {
+		param := toCookieParam(c, time.Now())
+		if len(param.Domain) == 0 {
+			if len(url) == 0 {
+				info, err := p.Info()
+				if err != nil {
+					return replaceAbortedError(err)
+				}
+				url = info.URL
+			}
+			param.URL = url
+		}
+		params = append(params, param)
+	}
+	return replaceAbortedError(proto.NetworkSetCookies{Cookies: params}.Call(p))
+}
+
+// GetCookies returns the cookies that are visible to the current URL of this page.
+func (p *Page) GetCookies() ([]*http.Cookie, error) {
+	cookies, err := p.Cookies(nil)
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	res := make([]*http.Cookie, 0, len(cookies))
+	for _, c := range cookies {
+		res = append(res, fromCookie(c))
+	}
+	return res, nil
+}
+
+// ClearCookies deletes the cookies that are visible to the current URL of this page.
+// Cookies of other sites in the same browser context are left as they are.
+func (p *Page) ClearCookies() error {
+	cookies, err := p.Cookies(nil)
+	if err != nil {
+		return replaceAbortedError(err)
+	}
+	for _, c := range cookies {
+		req := proto.NetworkDeleteCookies{Name: c.Name, Domain: c.Domain, Path: c.Path}
+		if err = req.Call(p); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	return nil
+}
+
+// SyncCookiesTo copies the cookies of the browser context of this page into the jar, such that a client of net/http
+// shares the session of the browser. Only the cookies visible to the URLs are copied if any URL is given.
+// Note that the jar keeps names and values along with scopes of cookies, but not all of their attributes.
+func (p *Page) SyncCookiesTo(jar http.CookieJar, urls ...*url.URL) error {
+	if len(urls) == 0 {
+		cookies, err := proto.NetworkGetAllCookies{}.Call(p)
+		if err != nil {
+			return replaceAbortedError(err)
+		}
+		for _, c := range cookies.Cookies {
+			jar.SetCookies(cookieURL(c), []*http.Cookie{jarCookie(c)})
+		}
+		return nil
+	}
+	for _, u := range urls {
+		cookies, err := proto.NetworkGetCookies{Urls: []string{u.String()}}.Call(p)
+		if err != nil {
+			return replaceAbortedError(err)
+		}
+		res := make([]*http.Cookie, 0, len(cookies.Cookies))
+		for _, c := range cookies.Cookies {
+			res = append(res, jarCookie(c))
+		}
+		jar.SetCookies(u, res)
+	}
+	return nil
+}
+
+// LoadCookiesFrom sets the cookies of the jar for the URLs to the browser context of this page, such that the browser
+// shares the session of a client of net/http. The current URL of this page is used if no URL is given.
+// As the jar only tells names and values, cookies are scoped to the URL they are loaded for.
+func (p *Page) LoadCookiesFrom(jar http.CookieJar, urls ...*url.URL) error {
+	if len(urls) == 0 {
+		info, err := p.Info()
+		if err != nil {
+			return replaceAbortedError(err)
+		}
+		u, err := url.Parse(info.URL)
+		if err != nil {
+			return err
+		}
+		urls = []*url.URL{u}
+	}
+	params := make([]*proto.NetworkCookieParam, 0)
+	for _, u := range urls {
+		for _, c := range jar.Cookies(u) {
+			params = append(params, &proto.NetworkCookieParam{Name: c.Name, Value: c.Value, URL: u.String()})
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return replaceAbortedError(proto.NetworkSetCookies{Cookies: params}.Call(p))
+}
+
+// cookieURL returns the URL that the cookie is scoped to, which a jar accepts the cookie for.
+func cookieURL(c *proto.NetworkCookie) *url.URL {
+	scheme := "http"
+	if c.Secure {
+		scheme = "https"
+	}
+	return &url.URL{Scheme: scheme, Host: strings.TrimPrefix(c.Domain, "."), Path: c.Path}
+}
+
+// jarCookie converts the cookie of DevTools protocol into the one for a jar, where a cookie whose domain has no
+// leading dot is a host-only cookie, thus has no domain attribute.
+func jarCookie(c *proto.NetworkCookie) *http.Cookie {
+	cookie := fromCookie(c)
+	if !strings.HasPrefix(c.Domain, ".") {
+		cookie.Domain = ""
+	}
+	return cookie
+}
+
+// toCookieParam converts the cookie into the parameter of DevTools protocol, where MaxAge counts from now.
+func toCookieParam(c *http.Cookie, now time.Time) *proto.NetworkCookieParam {
+	param := &proto.NetworkCookieParam{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		Secure:   c.Secure,
+		HTTPOnly: c.HttpOnly,
+	}
+	switch c.SameSite {
+	case http.SameSiteStrictMode:
+		param.SameSite = proto.NetworkCookieSameSiteStrict
+	case http.SameSiteLaxMode:
+		param.SameSite = proto.NetworkCookieSameSiteLax
+	case http.SameSiteNoneMode:
+		param.SameSite = proto.NetworkCookieSameSiteNone
+	}
+	if c.MaxAge > 0 {
+		param.Expires = proto.TimeSinceEpoch(now.Add(time.Duration(c.MaxAge) * time.Second).Unix())
+	} else if c.MaxAge < 0 {
+		param.Expires = proto.TimeSinceEpoch(now.Add(-time.Hour).Unix())
+	} else if !c.Expires.IsZero() {
+		param.Expires = proto.TimeSinceEpoch(c.Expires.Unix())
+	}
+	return param
+}
+
+// fromCookie converts the cookie of DevTools protocol into http.Cookie. Session cookies have no expiry.
+func fromCookie(c *proto.NetworkCookie) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		Secure:   c.Secure,
+		HttpOnly: c.HTTPOnly,
+	}
+	switch c.SameSite {
+	case proto.NetworkCookieSameSiteStrict:
+		cookie.SameSite = http.SameSiteStrictMode
+	case proto.NetworkCookieSameSiteLax:
+		cookie.SameSite = http.SameSiteLaxMode
+	case proto.NetworkCookieSameSiteNone:
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	if !c.Session && c.Expires > 0 {
+		cookie.Expires = time.Unix(int64(c.Expires), 0)
+	}
+	return cookie
+}
@@ -0,0 +1,103 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func Test_toCookieParam_Converts_Attributes(t *testing.T) {
+	now := time.Unix(1000, 0)
+	param := toCookieParam(&http.Cookie{
+		Name: "a", Value: "b", Domain: "example.com", Path: "/", Secure: true, HttpOnly: true,
+		SameSite: http.SameSiteLaxMode, MaxAge: 60,
+	}, now)
+	assert.Equal(t, "a", param.Name)
+	assert.Equal(t, "b", param.Value)
+	assert.Equal(t, "example.com", param.Domain)
+	assert.True(t, param.Secure)
+	assert.True(t, param.HTTPOnly)
+	assert.Equal(t, proto.NetworkCookieSameSiteLax, param.SameSite)
+	assert.Equal(t, proto.TimeSinceEpoch(1060), param.Expires)
+}
+
+func Test_toCookieParam_Uses_Expires_When_No_MaxAge(t *testing.T) {
+	param := toCookieParam(&http.Cookie{Name: "a", Expires: time.Unix(2000, 0)}, time.Unix(1000, 0))
+	assert.Equal(t, proto.TimeSinceEpoch(2000), param.Expires)
+	assert.Zero(t, toCookieParam(&http.Cookie{Name: "a"}, time.Now()).Expires)
+}
+
+func Test_fromCookie_Converts_Attributes(t *testing.T) {
+	cookie := fromCookie(&proto.NetworkCookie{
+		Name: "a", Value: "b", Domain: "example.com", Path: "/", Expires: 2000,
+		SameSite: proto.NetworkCookieSameSiteStrict, HTTPOnly: true,
+	})
+	assert.Equal(t, "a", cookie.Name)
+	assert.Equal(t, time.Unix(2000, 0), cookie.Expires)
+	assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, fromCookie(&proto.NetworkCookie{Name: "a", Session: true, Expires: -1}).Expires.IsZero())
+}
+
+func Test_Cookies_Round_Trip_Through_Page(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.NoError(t, p.SetCookies([]*http.Cookie{{Name: "session", Value: "test", Path: "/"}}))
+
+	cookies, err := p.GetCookies()
+	assert.NoError(t, err)
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "session", cookies[0].Name)
+		assert.Equal(t, "test", cookies[0].Value)
+	}
+	assert.Contains(t, p.MustEval(`() => document.cookie`).String(), "session=test")
+
+	assert.NoError(t, p.ClearCookies())
+	cookies, err = p.GetCookies()
+	assert.NoError(t, err)
+	assert.Empty(t, cookies)
+}
+
+func Test_cookieURL_Scopes_Cookie(t *testing.T) {
+	u := cookieURL(&proto.NetworkCookie{Domain: ".example.com", Path: "/app", Secure: true})
+	assert.Equal(t, "https://example.com/app", u.String())
+	u = cookieURL(&proto.NetworkCookie{Domain: "example.com", Path: "/"})
+	assert.Equal(t, "http://example.com/", u.String())
+}
+
+func Test_jarCookie_Drops_Domain_Of_Host_Only_Cookie(t *testing.T) {
+	assert.Equal(t, "", jarCookie(&proto.NetworkCookie{Name: "a", Domain: "example.com"}).Domain)
+	assert.Equal(t, ".example.com", jarCookie(&proto.NetworkCookie{Name: "a", Domain: ".example.com"}).Domain)
+}
+
+func Test_Cookies_Sync_With_CookieJar(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	u, err := url.Parse(s.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, p.SetCookies([]*http.Cookie{{Name: "session", Value: "test", Path: "/"}}))
+
+	jar, err := cookiejar.New(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.SyncCookiesTo(jar))
+	cookies := jar.Cookies(u)
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "session", cookies[0].Name)
+		assert.Equal(t, "test", cookies[0].Value)
+	}
+
+	jar, err = cookiejar.New(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, p.SyncCookiesTo(jar, u))
+	assert.Len(t, jar.Cookies(u), 1)
+
+	assert.NoError(t, p.ClearCookies())
+	jar.SetCookies(u, []*http.Cookie{{Name: "token", Value: "fetched", Path: "/"}})
+	assert.NoError(t, p.LoadCookiesFrom(jar))
+	assert.Contains(t, p.MustEval(`() => document.cookie`).String(), "token=fetched")
+}
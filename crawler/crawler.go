@@ -0,0 +1,254 @@
+// Package crawler crawls a website breadth-first by pages of package chromium, following links within the origin
+// of its seed, such that every page of a site can be visited by a single call.
+package crawler
+
+import (
+	"context"
+	"github.com/state303/chromium"
+	"golang.org/x/sync/errgroup"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// navigateBackoff is the backoff of TryNavigate, which never retries as every loaded page is accepted.
+const navigateBackoff = time.Second
+
+// loaded accepts any page that has been navigated to, leaving failures of navigation to TryNavigate.
+var loaded chromium.Predicate[*chromium.Page] = func(*chromium.Page) bool { return true }
+
+// Crawler visits pages of a website breadth-first from a seed by pages of the pool of its browser.
+type Crawler struct {
+	Browser *chromium.Browser
+	// MaxDepth is the number of links to follow from the seed, such that 1 visits the seed and the pages it links to.
+	// Zero or negative follows links without limit.
+	MaxDepth int
+	// Concurrency is the number of pages visited at once, which is bounded by the page pool anyway.
+	// Zero or negative visits a page at a time.
+	Concurrency int
+	// Filter decides whether to visit a URL of the origin, such as to skip logout links. Nil visits every URL.
+	Filter chromium.Predicate[string]
+	// HostDelay is the minimum interval between navigations to the same host. Zero navigates without delay.
+	HostDelay time.Duration
+	// OnError receives the URL of a page that fails to load or to tell its links, which is skipped then.
+	// It may be called concurrently. Nil ignores such failures.
+	OnError func(url string, err error)
+	// OnProgress receives the number of URLs visited or skipped, and the number of URLs found so far, once a URL is done.
+	// It may be called concurrently.
+	OnProgress func(done, found int)
+	// Client fetches sitemaps for FromSitemap, which defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Crawl visits the seed, then the pages it links to within the origin of the seed, level by level up to MaxDepth.
+// Each URL is visited once regardless of its fragment, and the origin is the one of the seed once redirected.
+// The visit function is called with each page once it is loaded, before its links are read, thus it may interact
+// with the page to reveal more links. An error from visit stops the crawl and is returned as is, while a page that fails
+// to load is reported to OnError and skipped. It returns the error of ctx once ctx is done.
+func (c *Crawler) Crawl(ctx context.Context, seedURL string, visit func(*chromium.Page) error) error {
+	seed, err := url.Parse(seedURL)
+	if err != nil {
+		return err
+	}
+	seed.Fragment, seed.RawFragment = "", ""
+	s := c.newCrawl(visit)
+	s.origin = originOf(seed)
+	s.found(seed.String())
+	level := []string{seed.String()}
+	for depth := 0; len(level) > 0; depth++ {
+		follow := c.MaxDepth <= 0 || depth < c.MaxDepth
+		found, err := s.visitLevel(ctx, level, follow)
+		if err != nil {
+			return err
+		} else if depth == 0 {
+			s.redirect(found[0])
+		}
+		level = s.next(found)
+	}
+	return nil
+}
+
+// crawl is the state of a single call of Crawl or FromSitemap.
+type crawl struct {
+	*Crawler
+	visit   func(*chromium.Page) error
+	origin  string
+	limiter *hostLimiter
+
+	mu   *sync.Mutex
+	seen map[string]bool
+	done int
+}
+
+func (c *Crawler) newCrawl(visit func(*chromium.Page) error) *crawl {
+	return &crawl{Crawler: c, visit: visit, limiter: newHostLimiter(c.HostDelay), mu: &sync.Mutex{}, seen: make(map[string]bool)}
+}
+
+// found marks the URL as seen, reporting false if it has been seen already.
+func (s *crawl) found(link string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[link] {
+		return false
+	}
+	s.seen[link] = true
+	return true
+}
+
+// progress counts a URL as done, then reports the progress to OnProgress, if any.
+func (s *crawl) progress() {
+	s.mu.Lock()
+	s.done++
+	done, found := s.done, len(s.seen)
+	s.mu.Unlock()
+	if s.OnProgress != nil {
+		s.OnProgress(done, found)
+	}
+}
+
+// page is the outcome of a visit to a URL.
+type page struct {
+	final string   // URL of the page once redirected, or empty if it failed to load.
+	links []string // links of the page to be followed.
+}
+
+// visitLevel visits the URLs of a level concurrently, then returns their outcomes in the order of the URLs.
+func (s *crawl) visitLevel(ctx context.Context, urls []string, follow bool) ([]page, error) {
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	pages := make([]page, len(urls))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for i := range urls {
+		i := i
+		g.Go(func() (err error) {
+			pages[i], err = s.visitURL(ctx, urls[i], follow)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// visitURL navigates a page of the pool to the URL, visits it, then reads its links if they are to be followed.
+// The host of the URL is waited for before a page is taken, such that the wait does not hold a page of the pool.
+func (s *crawl) visitURL(ctx context.Context, link string, follow bool) (page, error) {
+	if err := s.limiter.wait(ctx, link); err != nil {
+		return page{}, err
+	}
+	p, err := s.Browser.GetPageContext(ctx)
+	if err != nil {
+		return page{}, err
+	}
+	defer s.Browser.PutPage(p)
+	defer s.progress()
+	if err = p.TryNavigateContext(ctx, link, loaded, navigateBackoff); err != nil {
+		if ctx.Err() != nil {
+			return page{}, ctx.Err()
+		}
+		s.report(link, err)
+		return page{}, nil
+	}
+	if err = s.visit(p); err != nil {
+		return page{}, err
+	}
+	info, err := p.Info()
+	if err != nil {
+		s.report(link, err)
+		return page{}, nil
+	}
+	res := page{final: info.URL}
+	if follow {
+		if res.links, err = p.Links(); err != nil {
+			s.report(link, err)
+		}
+	}
+	return res, nil
+}
+
+// report passes the failure of the URL to OnError, if any.
+func (s *crawl) report(link string, err error) {
+	if s.OnError != nil {
+		s.OnError(link, err)
+	}
+}
+
+// redirect moves the origin to the one of the seed once redirected, such as from http to https.
+func (s *crawl) redirect(seed page) {
+	u, err := url.Parse(seed.final)
+	if err != nil || len(seed.final) == 0 {
+		return
+	}
+	u.Fragment, u.RawFragment = "", ""
+	s.origin = originOf(u)
+	s.found(u.String())
+}
+
+// next returns the URLs of the next level from the outcomes of the current level, in the order they are found.
+func (s *crawl) next(pages []page) []string {
+	urls := make([]string, 0)
+	for _, p := range pages {
+		for _, link := range p.links {
+			if s.follows(link) && s.found(link) {
+				urls = append(urls, link)
+			}
+		}
+	}
+	return urls
+}
+
+// follows tells whether the link is of the origin, and passes the filter.
+func (s *crawl) follows(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil || originOf(u) != s.origin {
+		return false
+	}
+	return s.Filter == nil || s.Filter(link)
+}
+
+// originOf returns the scheme and the host of the URL, which identifies its origin.
+func originOf(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// hostLimiter spaces navigations to the same host by its delay.
+type hostLimiter struct {
+	delay time.Duration
+	mu    *sync.Mutex
+	next  map[string]time.Time // the earliest time of the next navigation by host.
+}
+
+func newHostLimiter(delay time.Duration) *hostLimiter {
+	return &hostLimiter{delay: delay, mu: &sync.Mutex{}, next: make(map[string]time.Time)}
+}
+
+// wait reserves the next slot of the host of the link, then waits for it, or returns the error of ctx once ctx is done.
+func (l *hostLimiter) wait(ctx context.Context, link string) error {
+	if l.delay <= 0 {
+		return nil
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	at, now := l.next[u.Host], time.Now()
+	if at.Before(now) {
+		at = now
+	}
+	l.next[u.Host] = at.Add(l.delay)
+	l.mu.Unlock()
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"github.com/state303/chromium"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// links of each page of the site, where /missing is not found and /logout is to be filtered out.
+var site = map[string]string{
+	"/":  `<a href="/a">a</a><a href="/b#part">b</a><a href="https://example.com/">external</a><a href="/logout">out</a>`,
+	"/a": `<a href="/c">c</a><a href="/a#self">self</a><a href="/missing">missing</a>`,
+	"/b": `<a href="/">home</a>`,
+	"/c": `<a href="/d">d</a>`,
+	"/d": ``,
+}
+
+// setup returns a new browser, and a server of the site.
+func setup(t *testing.T) (*chromium.Browser, *httptest.Server) {
+	t.Parallel()
+	b, err := chromium.NewBrowser(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.CleanUp)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := site[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("<html><body>" + body + "</body></html>"))
+	}))
+	t.Cleanup(s.Close)
+	return b, s
+}
+
+func Test_Crawl_Visits_Origin_Breadth_First(t *testing.T) {
+	b, s := setup(t)
+	mu, visited := &sync.Mutex{}, make([]string, 0)
+	c := &Crawler{Browser: b, MaxDepth: 2, Concurrency: 2, Filter: func(link string) bool { return !strings.HasSuffix(link, "/logout") }}
+	err := c.Crawl(context.Background(), s.URL+"/", func(p *chromium.Page) error {
+		mu.Lock()
+		defer mu.Unlock()
+		visited = append(visited, strings.TrimPrefix(p.MustInfo().URL, s.URL))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/", "/a", "/b", "/c", "/missing"}, visited)
+	assert.Equal(t, "/", visited[0])
+}
+
+func Test_Crawl_Stops_On_Visit_Error(t *testing.T) {
+	b, s := setup(t)
+	stop, visits := errors.New("stop"), 0
+	err := (&Crawler{Browser: b}).Crawl(context.Background(), s.URL, func(p *chromium.Page) error {
+		visits++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, visits)
+}
+
+func Test_hostLimiter_Spaces_Same_Host(t *testing.T) {
+	l := newHostLimiter(50 * time.Millisecond)
+	begin := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, l.wait(context.Background(), "https://example.com/"))
+	}
+	assert.GreaterOrEqual(t, time.Since(begin), 100*time.Millisecond)
+
+	begin = time.Now()
+	assert.NoError(t, l.wait(context.Background(), "https://other.example.com/"))
+	assert.Less(t, time.Since(begin), 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, l.wait(ctx, "https://example.com/"))
+}
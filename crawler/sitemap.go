@@ -0,0 +1,121 @@
+package crawler
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/state303/chromium"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxSitemapSize caps the size of a sitemap once decompressed, which is 50MB by the protocol.
+	maxSitemapSize = 50 << 20
+	// maxSitemapDepth caps the nesting of sitemap indexes, which the protocol does not allow at all.
+	maxSitemapDepth = 3
+)
+
+// sitemap is either of a set of URLs or an index of sitemaps, as of the sitemaps protocol.
+type sitemap struct {
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// FromSitemap visits the URLs of the sitemap, following sitemap indexes to their sitemaps, which may be gzipped.
+// URLs are visited by Concurrency pages of the pool at once, in the order of the sitemaps, without following their links.
+// Each URL is visited once, as long as it passes Filter, regardless of its origin.
+// The visit function is called with each page once it is loaded, and an error from it stops the visits and is
+// returned as is, while a page or a nested sitemap that fails to load is reported to OnError and skipped.
+// It returns an error if the sitemap itself cannot be fetched or parsed, or the error of ctx once ctx is done.
+func (c *Crawler) FromSitemap(ctx context.Context, sitemapURL string, visit func(*chromium.Page) error) error {
+	s := c.newCrawl(visit)
+	urls, err := s.sitemapURLs(ctx, sitemapURL, 0)
+	if err != nil {
+		return err
+	}
+	_, err = s.visitLevel(ctx, urls, false)
+	return err
+}
+
+// sitemapURLs returns the URLs of the sitemap which are not seen yet and pass the filter, following indexes.
+func (s *crawl) sitemapURLs(ctx context.Context, sitemapURL string, depth int) ([]string, error) {
+	m, err := s.fetchSitemap(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]string, 0, len(m.URLs))
+	for _, u := range m.URLs {
+		link := strings.TrimSpace(u.Loc)
+		if len(link) > 0 && (s.Filter == nil || s.Filter(link)) && s.found(link) {
+			urls = append(urls, link)
+		}
+	}
+	for _, nested := range m.Sitemaps {
+		link := strings.TrimSpace(nested.Loc)
+		if len(link) == 0 {
+			continue
+		}
+		if depth >= maxSitemapDepth {
+			s.report(link, fmt.Errorf("sitemap nested deeper than %d", maxSitemapDepth))
+			continue
+		}
+		more, err := s.sitemapURLs(ctx, link, depth+1)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.report(link, err)
+			continue
+		}
+		urls = append(urls, more...)
+	}
+	return urls, nil
+}
+
+// fetchSitemap fetches and parses the sitemap, decompressing it if gzipped.
+func (s *crawl) fetchSitemap(ctx context.Context, sitemapURL string) (*sitemap, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sitemapURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sitemap %s: %s", sitemapURL, res.Status)
+	}
+	body, err := decompress(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("sitemap %s: %w", sitemapURL, err)
+	}
+	m := &sitemap{}
+	if err = xml.NewDecoder(io.LimitReader(body, maxSitemapSize)).Decode(m); err != nil {
+		return nil, fmt.Errorf("sitemap %s: %w", sitemapURL, err)
+	}
+	return m, nil
+}
+
+// decompress returns the reader as is, or a reader of gzip if it begins with the magic number of gzip,
+// regardless of its name or content type, as servers tell either inconsistently.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return br, nil
+	}
+	return gzip.NewReader(br)
+}
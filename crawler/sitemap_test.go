@@ -0,0 +1,97 @@
+package crawler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"github.com/state303/chromium"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newSitemapServer returns a server of a sitemap index at /sitemap.xml, which refers to a gzipped sitemap,
+// a plain one, and a missing one, along with the pages of the site.
+func newSitemapServer(t *testing.T) *httptest.Server {
+	var s *httptest.Server
+	urlset := func(paths ...string) string {
+		b := &strings.Builder{}
+		b.WriteString(`<?xml version="1.0" encoding="UTF-8"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+		for _, path := range paths {
+			b.WriteString("<url><loc> " + s.URL + path + " </loc></url>")
+		}
+		b.WriteString("</urlset>")
+		return b.String()
+	}
+	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			_, _ = w.Write([]byte(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+				`<sitemap><loc>` + s.URL + `/pages.xml.gz</loc></sitemap>` +
+				`<sitemap><loc>` + s.URL + `/more.xml</loc></sitemap>` +
+				`<sitemap><loc>` + s.URL + `/missing.xml</loc></sitemap></sitemapindex>`))
+		case "/pages.xml.gz":
+			buf := &bytes.Buffer{}
+			zw := gzip.NewWriter(buf)
+			_, _ = zw.Write([]byte(urlset("/", "/a")))
+			_ = zw.Close()
+			_, _ = w.Write(buf.Bytes())
+		case "/more.xml":
+			_, _ = w.Write([]byte(urlset("/a", "/b", "/logout")))
+		case "/", "/a", "/b":
+			_, _ = w.Write([]byte(`<html><body>` + r.URL.Path + `</body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func Test_sitemapURLs_Follows_Indexes(t *testing.T) {
+	t.Parallel()
+	s := newSitemapServer(t)
+	failed := make([]string, 0)
+	c := &Crawler{
+		Filter:  func(link string) bool { return !strings.HasSuffix(link, "/logout") },
+		OnError: func(url string, err error) { failed = append(failed, url) },
+	}
+	urls, err := c.newCrawl(nil).sitemapURLs(context.Background(), s.URL+"/sitemap.xml", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{s.URL + "/", s.URL + "/a", s.URL + "/b"}, urls)
+	assert.Equal(t, []string{s.URL + "/missing.xml"}, failed)
+
+	_, err = c.newCrawl(nil).sitemapURLs(context.Background(), s.URL+"/missing.xml", 0)
+	assert.Error(t, err)
+}
+
+func Test_FromSitemap_Visits_URLs(t *testing.T) {
+	b, _ := setup(t)
+	s := newSitemapServer(t)
+	mu, visited, progress := &sync.Mutex{}, make([]string, 0), make([][2]int, 0)
+	c := &Crawler{
+		Browser:     b,
+		Concurrency: 2,
+		OnProgress: func(done, found int) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, [2]int{done, found})
+		},
+	}
+	err := c.FromSitemap(context.Background(), s.URL+"/sitemap.xml", func(p *chromium.Page) error {
+		mu.Lock()
+		defer mu.Unlock()
+		visited = append(visited, p.MustElement("body").MustText())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/", "/a", "/b", "404 page not found"}, visited)
+	if assert.Len(t, progress, 4) {
+		for _, p := range progress {
+			assert.Equal(t, 4, p[1])
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package chromium
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // to decode JPEG screenshots.
+	"image/png"
+	"os"
+)
+
+// DiffOptions configures CompareScreenshots.
+type DiffOptions struct {
+	// Threshold is the maximum difference of each color channel, from 0 to 255, for two pixels to be considered equal.
+	Threshold uint8
+	// MaxDiffRatio is the maximum ratio of differing pixels, from 0 to 1, for two screenshots to match.
+	MaxDiffRatio float64
+	// Ignore is a list of regions that are excluded from the comparison.
+	Ignore []image.Rectangle
+	// DiffImage requests a PNG image that highlights differing pixels in red.
+	DiffImage bool
+}
+
+// DiffResult is an outcome of CompareScreenshots.
+type DiffResult struct {
+	Match       bool
+	DiffPixels  int
+	TotalPixels int     // number of compared pixels, that excludes ignored regions.
+	Ratio       float64 // ratio of DiffPixels to TotalPixels.
+	Image       []byte  // PNG image of the difference, if requested by DiffOptions.
+}
+
+// CompareScreenshots compares two PNG or JPEG encoded screenshots pixel by pixel.
+// It returns an error if any of screenshots cannot be decoded, or they differ in size.
+func CompareScreenshots(a, b []byte, opts DiffOptions) (*DiffResult, error) {
+	imgA, _, err := image.Decode(bytes.NewReader(a))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode first screenshot: %w", err)
+	}
+	imgB, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode second screenshot: %w", err)
+	}
+	bounds := imgA.Bounds()
+	if bounds.Size() != imgB.Bounds().Size() {
+		return nil, fmt.Errorf("screenshots differ in size: %+v, %+v", bounds.Size(), imgB.Bounds().Size())
+	}
+
+	var diff *image.RGBA
+	if opts.DiffImage {
+		diff = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	}
+
+	offset := imgB.Bounds().Min.Sub(bounds.Min)
+	res := &DiffResult{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pt := image.Pt(x-bounds.Min.X, y-bounds.Min.Y)
+			ca := color.RGBAModel.Convert(imgA.At(x, y)).(color.RGBA)
+			if isIgnored(pt, opts.Ignore) {
+				if diff != nil {
+					diff.SetRGBA(pt.X, pt.Y, fade(ca))
+				}
+				continue
+			}
+			res.TotalPixels++
+			cb := color.RGBAModel.Convert(imgB.At(x+offset.X, y+offset.Y)).(color.RGBA)
+			if differs(ca, cb, opts.Threshold) {
+				res.DiffPixels++
+				if diff != nil {
+					diff.SetRGBA(pt.X, pt.Y, color.RGBA{R: 255, A: 255})
+				}
+			} else if diff != nil {
+				diff.SetRGBA(pt.X, pt.Y, fade(ca))
+			}
+		}
+	}
+
+	if res.TotalPixels > 0 {
+		res.Ratio = float64(res.DiffPixels) / float64(res.TotalPixels)
+	}
+	res.Match = res.Ratio <= opts.MaxDiffRatio
+	if diff != nil {
+		buf := &bytes.Buffer{}
+		if err = png.Encode(buf, diff); err != nil {
+			return nil, err
+		}
+		res.Image = buf.Bytes()
+	}
+	return res, nil
+}
+
+// ScreenshotMatchesBaseline compares a screenshot of the current viewport with the PNG image at given path.
+// If there is no baseline yet, the screenshot is saved to the path as a new baseline, and reported as a match.
+func (p *Page) ScreenshotMatchesBaseline(path string) (bool, error) {
+	shot, err := p.Screenshot(ScreenshotOptions{})
+	if err != nil {
+		return false, replaceAbortedError(err)
+	}
+	baseline, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, os.WriteFile(path, shot, 0644)
+	} else if err != nil {
+		return false, err
+	}
+	res, err := CompareScreenshots(baseline, shot, DiffOptions{})
+	if err != nil {
+		return false, err
+	}
+	return res.Match, nil
+}
+
+// isIgnored checks if the point is within any of given regions.
+func isIgnored(pt image.Point, regions []image.Rectangle) bool {
+	for _, r := range regions {
+		if pt.In(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// differs checks if any channel of the colors differs more than the threshold.
+func differs(a, b color.RGBA, threshold uint8) bool {
+	return absDiff(a.R, b.R) > threshold ||
+		absDiff(a.G, b.G) > threshold ||
+		absDiff(a.B, b.B) > threshold ||
+		absDiff(a.A, b.A) > threshold
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// fade returns a pale gray of the color, such that differing pixels stand out in the diff image.
+func fade(c color.RGBA) color.RGBA {
+	gray := color.GrayModel.Convert(c).(color.Gray).Y
+	v := 255 - (255-gray)/4
+	return color.RGBA{R: v, G: v, B: v, A: 255}
+}
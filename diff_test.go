@@ -0,0 +1,114 @@
+package chromium
+
+import (
+	"bytes"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/png"
+	"path/filepath"
+	"testing"
+)
+
+// makePNG returns a PNG encoded image of given size, filled with given color except the pixels of given points.
+func makePNG(t *testing.T, w, h int, fill color.RGBA, marked ...image.Point) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, fill)
+		}
+	}
+	for _, pt := range marked {
+		img.SetRGBA(pt.X, pt.Y, color.RGBA{A: 255})
+	}
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err.Error())
+	}
+	return buf.Bytes()
+}
+
+var white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+func Test_CompareScreenshots_Matches_Identical_Images(t *testing.T) {
+	img := makePNG(t, 10, 10, white)
+	res, err := CompareScreenshots(img, img, DiffOptions{})
+	assert.NoError(t, err)
+	assert.True(t, res.Match)
+	assert.Equal(t, 0, res.DiffPixels)
+	assert.Equal(t, 100, res.TotalPixels)
+}
+
+func Test_CompareScreenshots_Counts_Differing_Pixels(t *testing.T) {
+	a := makePNG(t, 10, 10, white)
+	b := makePNG(t, 10, 10, white, image.Pt(1, 1), image.Pt(5, 5))
+	res, err := CompareScreenshots(a, b, DiffOptions{})
+	assert.NoError(t, err)
+	assert.False(t, res.Match)
+	assert.Equal(t, 2, res.DiffPixels)
+	assert.InDelta(t, 0.02, res.Ratio, 0.0001)
+
+	res, err = CompareScreenshots(a, b, DiffOptions{MaxDiffRatio: 0.05})
+	assert.NoError(t, err)
+	assert.True(t, res.Match)
+}
+
+func Test_CompareScreenshots_Ignores_Differences_Within_Threshold(t *testing.T) {
+	a := makePNG(t, 4, 4, white)
+	b := makePNG(t, 4, 4, color.RGBA{R: 250, G: 250, B: 250, A: 255})
+	res, err := CompareScreenshots(a, b, DiffOptions{Threshold: 5})
+	assert.NoError(t, err)
+	assert.True(t, res.Match)
+	res, err = CompareScreenshots(a, b, DiffOptions{Threshold: 4})
+	assert.NoError(t, err)
+	assert.False(t, res.Match)
+}
+
+func Test_CompareScreenshots_Excludes_Ignored_Regions(t *testing.T) {
+	a := makePNG(t, 10, 10, white)
+	b := makePNG(t, 10, 10, white, image.Pt(1, 1))
+	res, err := CompareScreenshots(a, b, DiffOptions{Ignore: []image.Rectangle{image.Rect(0, 0, 2, 2)}})
+	assert.NoError(t, err)
+	assert.True(t, res.Match)
+	assert.Equal(t, 96, res.TotalPixels)
+}
+
+func Test_CompareScreenshots_Returns_Diff_Image(t *testing.T) {
+	a := makePNG(t, 3, 3, white)
+	b := makePNG(t, 3, 3, white, image.Pt(2, 2))
+	res, err := CompareScreenshots(a, b, DiffOptions{DiffImage: true})
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(res.Image))
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, color.RGBAModel.Convert(img.At(2, 2)))
+	assert.NotEqual(t, color.RGBA{R: 255, A: 255}, color.RGBAModel.Convert(img.At(0, 0)))
+}
+
+func Test_CompareScreenshots_Returns_Err_When_Size_Differs(t *testing.T) {
+	_, err := CompareScreenshots(makePNG(t, 2, 2, white), makePNG(t, 3, 3, white), DiffOptions{})
+	assert.ErrorContains(t, err, "size")
+}
+
+func Test_CompareScreenshots_Returns_Err_When_Image_Is_Invalid(t *testing.T) {
+	_, err := CompareScreenshots([]byte("invalid"), makePNG(t, 2, 2, white), DiffOptions{})
+	assert.Error(t, err)
+}
+
+func Test_ScreenshotMatchesBaseline_Saves_Baseline_Then_Compares(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	path := filepath.Join(t.TempDir(), "baseline.png")
+
+	match, err := p.ScreenshotMatchesBaseline(path)
+	assert.NoError(t, err)
+	assert.True(t, match, "expected first screenshot to be saved as baseline")
+	match, err = p.ScreenshotMatchesBaseline(path)
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	p.MustElement("ul").MustEval("() => this.style.background = 'red'")
+	match, err = p.ScreenshotMatchesBaseline(path)
+	assert.NoError(t, err)
+	assert.False(t, match)
+}
@@ -0,0 +1,27 @@
+package chromium
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"net/url"
+	"strings"
+)
+
+// Document snapshots the current DOM of this page into goquery, including changes made by scripts so far, such that
+// it can be queried offline after the page is put back to the pool. The URL of the document is the current URL of
+// this page, which resolves relative links. Later changes of the page are not reflected to the snapshot.
+func (p *Page) Document() (*goquery.Document, error) {
+	source, err := p.documentHTML()
+	if err != nil {
+		return nil, err
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(source))
+	if err != nil {
+		return nil, err
+	}
+	info, err := p.Info()
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	doc.Url, _ = url.Parse(info.URL)
+	return doc, nil
+}
@@ -0,0 +1,21 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Document_Snapshots_Live_DOM(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => { const el = document.createElement('p'); el.className = 'added'; el.textContent = 'by script'; document.body.append(el); }`)
+
+	doc, err := p.Document()
+	assert.NoError(t, err)
+	assert.Equal(t, "by script", doc.Find("p.added").Text())
+	assert.Equal(t, p.MustInfo().URL, doc.Url.String())
+
+	p.MustEval(`() => document.querySelector('p.added').remove()`)
+	assert.Equal(t, 1, doc.Find("p.added").Length())
+}
@@ -0,0 +1,71 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// ThrottleCPU slows down the CPU of this page by the rate, such as 4 for a device four times slower than the host,
+// such that slow devices can be simulated. Rate of 1 or lower removes the throttling.
+// The throttling is restored when the tab of the page is replaced by the browser.
+func (p *Page) ThrottleCPU(rate float64) error {
+	if rate < 1 {
+		rate = 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := throttleCPU(p.Page, rate); err != nil {
+		return err
+	}
+	p.cpuRate = rate
+	return nil
+}
+
+// SetOffline cuts this page off the network if offline is set, failing its requests as if connectivity is lost,
+// or brings it back online otherwise, such that loss of connectivity can be simulated during automation runs.
+// Note that WebSocket connections already open are left as they are. The state is restored when the tab of the page
+// is replaced by the browser.
+func (p *Page) SetOffline(offline bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := setOffline(p.Page, offline); err != nil {
+		return err
+	}
+	p.offline = offline
+	return nil
+}
+
+// restoreEmulation throttles the CPU of given tab and cuts it off the network if this page does so, as the tab is
+// about to replace the current tab.
+func (p *Page) restoreEmulation(page *rod.Page) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.cpuRate > 1 {
+		if err := throttleCPU(page, p.cpuRate); err != nil {
+			return err
+		}
+	}
+	if !p.offline {
+		return nil
+	}
+	return setOffline(page, true)
+}
+
+// throttleCPU sets the rate of throttling to the tab.
+func throttleCPU(page *rod.Page, rate float64) error {
+	return replaceAbortedError(proto.EmulationSetCPUThrottlingRate{Rate: rate}.Call(page))
+}
+
+// setOffline sets the network conditions of the tab, leaving the network domain enabled, which keeps them across
+// navigations.
+func setOffline(page *rod.Page, offline bool) error {
+	if err := (proto.NetworkEnable{}).Call(page); err != nil {
+		return replaceAbortedError(err)
+	}
+	return replaceAbortedError(proto.NetworkEmulateNetworkConditions{
+		Offline:            offline,
+		Latency:            0,
+		DownloadThroughput: -1,
+		UploadThroughput:   -1,
+	}.Call(page))
+}
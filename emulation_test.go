@@ -0,0 +1,43 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_ThrottleCPU_Slows_Down_Scripts(t *testing.T) {
+	_, p, s := setup(t)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	const loopJS = `() => { const begin = performance.now(); let n = 0; for (let i = 0; i < 3e7; i++) { n += i } return performance.now() - begin }`
+
+	base := p.MustEval(loopJS).Num()
+	assert.NoError(t, p.ThrottleCPU(8))
+	throttled := p.MustEval(loopJS).Num()
+	assert.Greater(t, throttled, base*2)
+
+	assert.NoError(t, p.ThrottleCPU(0))
+	assert.Less(t, p.MustEval(loopJS).Num(), throttled)
+}
+
+func Test_SetOffline_Fails_Navigation(t *testing.T) {
+	_, p, s := setup(t)
+	p.SetDefaultTimeout(5 * time.Second)
+	assert.NoError(t, p.SetOffline(true))
+	err := p.TryNavigate(s.URL, func(*Page) bool { return true }, time.Millisecond)
+	assert.ErrorContains(t, err, "ERR_INTERNET_DISCONNECTED")
+
+	assert.NoError(t, p.SetOffline(false))
+	assert.NoError(t, p.TryNavigate(s.URL, func(*Page) bool { return true }, time.Millisecond))
+}
+
+func Test_SetOffline_Is_Restored_After_Page_Is_Recycled(t *testing.T) {
+	p, s := recycledPage(t, func(p *Page) {
+		assert.NoError(t, p.ThrottleCPU(4))
+		assert.NoError(t, p.SetOffline(true))
+	})
+	p.SetDefaultTimeout(5 * time.Second)
+	err := p.TryNavigate(s.URL, func(*Page) bool { return true }, time.Millisecond)
+	assert.ErrorContains(t, err, "ERR_INTERNET_DISCONNECTED")
+	assert.Equal(t, 4.0, p.cpuRate)
+}
@@ -7,19 +7,73 @@ import (
 	"strings"
 )
 
-// defined errors for uniform error handling.
+// defined errors for uniform error handling, which are named after what has happened without the Err prefix,
+// such as ChallengeNotResolved rather than ErrChallengeNotResolved.
 
 var (
-	ElementMissing = errors.New("element missing")
-	InputFailed    = errors.New("input failed")
-	WaitFailed     = errors.New("wait failed")
-	ClickFailed    = errors.New("click failed")
-	TaskTimeout    = errors.New("task timeout")
+	ElementMissing       = errors.New("element missing")
+	InputFailed          = errors.New("input failed")
+	WaitFailed           = errors.New("wait failed")
+	ClickFailed          = errors.New("click failed")
+	TaskTimeout          = errors.New("task timeout")
+	Disconnected         = errors.New("browser disconnected")
+	QueueFull            = errors.New("queue full")
+	QueueClosed          = errors.New("queue closed")
+	BrowserClosed        = errors.New("browser closed")
+	PredicateFailed      = errors.New("predicate failed")
+	ChallengeNotResolved = errors.New("challenge not resolved")
+	CaptchaFailed        = errors.New("captcha failed")
+	PageMissing          = errors.New("page missing")
+	DecodeFailed         = errors.New("decode failed")
+	DisallowedByRobots   = errors.New("disallowed by robots.txt")
+	BadStatus            = errors.New("bad status")
 )
 
-// wrapError wraps an error with given topic, such that the type of error to be consistent.
-func wrap(err error, topic string) error {
-	return fmt.Errorf("%w, %+v", replaceAbortedError(err), topic)
+// Error is an error of an operation on a page, telling where the operation has failed.
+// It wraps the cause, such that errors.Is reaches the defined errors above, and errors.As reaches the Error.
+type Error struct {
+	Op       string // operation that has failed, such as navigate, find, input, click or wait.
+	Selector string // selector of the element that the operation is on, or name of the object for waitJS, if any.
+	URL      string // URL that the operation is on, if any.
+	Attempt  int    // number of attempts made, if the operation has been retried.
+	Err      error  // cause of the failure.
+}
+
+func (e *Error) Error() string {
+	b := &strings.Builder{}
+	b.WriteString(e.Op)
+	if len(e.Selector) > 0 {
+		fmt.Fprintf(b, " %q", e.Selector)
+	}
+	if len(e.URL) > 0 {
+		fmt.Fprintf(b, " %s", e.URL)
+	}
+	if e.Attempt > 0 {
+		fmt.Fprintf(b, ", attempt %d", e.Attempt)
+	}
+	fmt.Fprintf(b, ": %v", e.Err)
+	return b.String()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// classify returns the error as is if it is one of the defined errors or cancellation,
+// otherwise an error that wraps the sentinel while telling the original error.
+func classify(sentinel, err error) error {
+	err = replaceTimeoutError(replaceAbortedError(err))
+	if err == nil || isKnownError(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", sentinel, err)
+}
+
+// elementError returns an Error of the operation on the element of the selector,
+// replacing an aborted cause by context.Canceled.
+func elementError(op, selector string, err error) error {
+	return &Error{Op: op, Selector: selector, Err: replaceAbortedError(err)}
 }
 
 func replaceAbortedError(err error) error {
@@ -35,6 +89,14 @@ func replaceAbortedError(err error) error {
 	return err
 }
 
+// replaceTimeoutError replaces context.DeadlineExceeded to TaskTimeout, such that timeouts are reported uniformly.
+func replaceTimeoutError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return TaskTimeout
+	}
+	return err
+}
+
 func isKnownError(err error) bool {
 	if err == nil {
 		return false
@@ -44,5 +106,16 @@ func isKnownError(err error) bool {
 		errors.Is(err, WaitFailed) ||
 		errors.Is(err, ClickFailed) ||
 		errors.Is(err, TaskTimeout) ||
+		errors.Is(err, Disconnected) ||
+		errors.Is(err, QueueFull) ||
+		errors.Is(err, QueueClosed) ||
+		errors.Is(err, BrowserClosed) ||
+		errors.Is(err, PredicateFailed) ||
+		errors.Is(err, ChallengeNotResolved) ||
+		errors.Is(err, CaptchaFailed) ||
+		errors.Is(err, PageMissing) ||
+		errors.Is(err, DecodeFailed) ||
+		errors.Is(err, DisallowedByRobots) ||
+		errors.Is(err, BadStatus) ||
 		errors.Is(err, context.Canceled)
 }
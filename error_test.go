@@ -4,6 +4,11 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -31,3 +36,70 @@ func Test_isKnownError_Returns_False_When_Error_Is_Nil(t *testing.T) {
 		assert.False(t, isKnownError(nil))
 	})
 }
+
+func Test_replaceTimeoutError_Replaces_Deadline_To_TaskTimeout(t *testing.T) {
+	assert.ErrorIs(t, replaceTimeoutError(context.DeadlineExceeded), TaskTimeout)
+}
+
+func Test_replaceTimeoutError_Returns_Error_If_Not_Deadline(t *testing.T) {
+	err := errors.New("test error")
+	assert.ErrorIs(t, replaceTimeoutError(err), err)
+	assert.Nil(t, replaceTimeoutError(nil))
+}
+
+func Test_elementError_Keeps_Original_Error_Unwrappable(t *testing.T) {
+	err := elementError(opFind, "li > a", ElementMissing)
+	assert.ErrorIs(t, err, ElementMissing)
+	assert.Equal(t, ElementMissing, errors.Unwrap(err))
+	assert.EqualError(t, err, `find "li > a": element missing`)
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, opFind, e.Op)
+		assert.Equal(t, "li > a", e.Selector)
+	}
+	assert.ErrorIs(t, elementError(ActionClick, "a", errors.New(abortedError)), context.Canceled)
+}
+
+func Test_Error_Tells_URL_And_Attempt(t *testing.T) {
+	err := &Error{Op: ActionNavigate, URL: "http://test.local", Attempt: 3, Err: TaskTimeout}
+	assert.EqualError(t, err, "navigate http://test.local, attempt 3: task timeout")
+	assert.ErrorIs(t, err, TaskTimeout)
+}
+
+func Test_Production_Code_Does_Not_Import_Internal_Test_Packages(t *testing.T) {
+	var files []string
+	err := filepath.WalkDir(".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name == "testdata" || name == filepath.Join("internal", "test") || (name != "." && strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			files = append(files, name)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	for _, name := range files {
+		f, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.ImportsOnly)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		for _, spec := range f.Imports {
+			assert.NotContains(t, spec.Path.Value, "/internal/test", "%s imports test utilities", name)
+		}
+	}
+}
+
+func Test_classify_Wraps_Unknown_Errors_With_Sentinel(t *testing.T) {
+	err := classify(InputFailed, errors.New("node is detached"))
+	assert.ErrorIs(t, err, InputFailed)
+	assert.ErrorContains(t, err, "node is detached")
+	assert.Equal(t, TaskTimeout, classify(InputFailed, context.DeadlineExceeded))
+	assert.Equal(t, context.Canceled, classify(InputFailed, errors.New(abortedError)))
+	assert.Nil(t, classify(InputFailed, nil))
+}
@@ -0,0 +1,36 @@
+package chromium
+
+import (
+	"encoding/json"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// evalJSON evaluates the JavaScript function with given arguments, then decodes its result into dst as JSON.
+func (p *Page) evalJSON(dst any, js string, args ...any) error {
+	obj, err := p.Eval(js, args...)
+	if err != nil {
+		return replaceTimeoutError(replaceAbortedError(err))
+	}
+	return decodeJSON(obj, dst)
+}
+
+// decodeJSON decodes the value of the remote object into dst as JSON.
+func decodeJSON(obj *proto.RuntimeRemoteObject, dst any) error {
+	data, err := obj.Value.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// EvalAs evaluates the JavaScript function on the page with given arguments, then decodes its result into T as JSON.
+// The result must be serializable by JSON, hence DOM nodes and functions cannot be returned.
+// On error, the zero value of T is returned.
+func EvalAs[T any](p *Page, js string, args ...any) (T, error) {
+	var v T
+	if err := p.evalJSON(&v, js, args...); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
@@ -0,0 +1,41 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysmood/gson"
+	"testing"
+)
+
+func Test_decodeJSON_Decodes_Value(t *testing.T) {
+	var v struct {
+		Name  string   `json:"name"`
+		Items []string `json:"items"`
+	}
+	obj := &proto.RuntimeRemoteObject{Value: gson.New(map[string]any{"name": "test", "items": []string{"a", "b"}})}
+	assert.NoError(t, decodeJSON(obj, &v))
+	assert.Equal(t, "test", v.Name)
+	assert.Equal(t, []string{"a", "b"}, v.Items)
+}
+
+func Test_EvalAs_Decodes_Result_Into_Type(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	items, err := EvalAs[[]string](p, `() => Array.from(document.querySelectorAll("li"), li => li.textContent)`)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, items)
+
+	sum, err := EvalAs[int](p, `(a, b) => a + b`, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sum)
+
+	type point struct{ X, Y float64 }
+	pt, err := EvalAs[point](p, `() => ({X: 1.5, Y: 2})`)
+	assert.NoError(t, err)
+	assert.Equal(t, point{X: 1.5, Y: 2}, pt)
+
+	_, err = EvalAs[int](p, `() => "text"`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package chromium
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-rod/rod"
+	"github.com/ysmood/gson"
+)
+
+// exposeJS defines a function of given name on window that passes its arguments as an array to the binding,
+// and rejects with an Error if the Go function has failed.
+const exposeJS = `(name, binding) => {
+	window[name] = (...args) => window[binding](args).then((res) => {
+		if (res.error !== undefined) throw new Error(res.error);
+		return res.value;
+	});
+}`
+
+// exposedFunc is a function exposed by ExposeFunc, along with the stop of its binding on the current tab of the page.
+type exposedFunc struct {
+	name string
+	fn   func(args ...gson.JSON) (any, error)
+	stop func() error
+}
+
+// ExposeFunc defines a function of given name on window of this page, which calls fn with its arguments and
+// resolves to the result of fn, or rejects with an Error of the message of the error that fn returns.
+// The function is defined on the current document and on every subsequent document of this page,
+// including those of the tab that replaces a recycled one.
+// It stays exposed until the returned stop is called, or the page is cleaned up.
+func (p *Page) ExposeFunc(name string, fn func(args ...gson.JSON) (any, error)) (stop func() error, err error) {
+	e := &exposedFunc{name: name, fn: fn}
+	if e.stop, err = e.bind(p.Page); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.exposed = append(p.exposed, e)
+	p.mu.Unlock()
+	return func() error { return p.unexpose(e) }, nil
+}
+
+// bind defines the function on the current and every subsequent document of the tab, returning the stop of the binding.
+func (e *exposedFunc) bind(page *rod.Page) (stop func() error, err error) {
+	binding := "__chromium_" + e.name
+	stopBinding, err := page.Expose(binding, func(payload gson.JSON) (any, error) {
+		res, err := e.fn(payload.Arr()...)
+		if err != nil {
+			return map[string]any{"error": err.Error()}, nil
+		}
+		return map[string]any{"value": res}, nil
+	})
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	args, _ := json.Marshal([]string{e.name, binding})
+	removeScript, err := page.EvalOnNewDocument(fmt.Sprintf("(%s)(...%s)", exposeJS, args))
+	if err == nil {
+		_, err = page.Eval(exposeJS, e.name, binding)
+	}
+	if err != nil {
+		_ = stopBinding()
+		return nil, replaceAbortedError(err)
+	}
+	return func() error {
+		if err := removeScript(); err != nil {
+			return replaceAbortedError(err)
+		}
+		return replaceAbortedError(stopBinding())
+	}, nil
+}
+
+// unexpose stops the function, which does nothing if it has been stopped already.
+func (p *Page) unexpose(e *exposedFunc) error {
+	p.mu.Lock()
+	var stop func() error
+	for i, exposed := range p.exposed {
+		if exposed == e {
+			p.exposed = append(p.exposed[:i:i], p.exposed[i+1:]...)
+			stop = e.stop
+			break
+		}
+	}
+	p.mu.Unlock()
+	if stop == nil {
+		return nil
+	}
+	return stop()
+}
+
+// restoreExposed binds the functions exposed by ExposeFunc to given tab, which is about to replace the current tab.
+// Bindings of the current tab are stopped once all functions are bound to the new one.
+func (p *Page) restoreExposed(page *rod.Page) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stops := make([]func() error, 0, len(p.exposed))
+	for _, e := range p.exposed {
+		stop, err := e.bind(page)
+		if err != nil {
+			for _, stop := range stops {
+				_ = stop()
+			}
+			return err
+		}
+		stops = append(stops, stop)
+	}
+	for i, e := range p.exposed {
+		_ = e.stop() // the current tab may be gone already.
+		e.stop = stops[i]
+	}
+	return nil
+}
+
+// unexposeAll stops all functions exposed by ExposeFunc, ignoring errors as the page may be gone already.
+func (p *Page) unexposeAll() {
+	p.mu.Lock()
+	stops := make([]func() error, 0, len(p.exposed))
+	for _, e := range p.exposed {
+		stops = append(stops, e.stop)
+	}
+	p.exposed = nil
+	p.mu.Unlock()
+	for _, stop := range stops {
+		_ = stop()
+	}
+}
@@ -0,0 +1,62 @@
+package chromium
+
+import (
+	"errors"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysmood/gson"
+	"testing"
+)
+
+func Test_ExposeFunc_Calls_Go_With_Arguments(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	rows := make([]string, 0)
+	stop, err := p.ExposeFunc("report", func(args ...gson.JSON) (any, error) {
+		if len(args) == 0 {
+			return nil, errors.New("no rows")
+		}
+		for _, arg := range args {
+			rows = append(rows, arg.Str())
+		}
+		return len(args), nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, p.MustEval(`() => window.report("a", "b")`).Int())
+	assert.Equal(t, []string{"a", "b"}, rows)
+	assert.Equal(t, "no rows", p.MustEval(`() => window.report().catch(e => e.message)`).Str())
+
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.Equal(t, 1, p.MustEval(`() => window.report("c")`).Int())
+
+	assert.NoError(t, stop())
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.True(t, p.MustEval(`() => window.report === undefined`).Bool())
+}
+
+func Test_unexpose_Stops_Function_Once(t *testing.T) {
+	p := newPage(nil, func() {})
+	stops := 0
+	e := &exposedFunc{name: "report", stop: func() error { stops++; return nil }}
+	p.exposed = []*exposedFunc{e}
+	assert.NoError(t, p.unexpose(e))
+	assert.NoError(t, p.unexpose(e))
+	assert.Equal(t, 1, stops)
+	assert.Empty(t, p.exposed)
+}
+
+func Test_ExposeFunc_Survives_Recycle(t *testing.T) {
+	var stop func() error
+	p, s := recycledPage(t, func(p *Page) {
+		var err error
+		stop, err = p.ExposeFunc("double", func(args ...gson.JSON) (any, error) { return args[0].Int() * 2, nil })
+		assert.NoError(t, err)
+	})
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.Equal(t, 4, p.MustEval(`() => window.double(2)`).Int())
+
+	assert.NoError(t, stop())
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.True(t, p.MustEval(`() => window.double === undefined`).Bool())
+}
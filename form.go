@@ -0,0 +1,88 @@
+package chromium
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// selectJS selects options of the select element whose value or text is one of the values, deselecting the others,
+// then dispatches events as if a user selected them. It returns the reason of failure, or empty string on success.
+const selectJS = `function(values) {
+	if (this.tagName !== 'SELECT') return 'not a select element';
+	if (!this.multiple && values.length > 1) return 'not a multiple select';
+	const matches = (option, value) => option.value === value || option.text.trim() === value;
+	const missing = values.filter(v => !Array.from(this.options).some(o => matches(o, v)));
+	if (missing.length > 0) return 'no option of ' + missing.join(', ');
+	for (const option of this.options) option.selected = values.some(v => matches(option, v));
+	this.dispatchEvent(new Event('input', {bubbles: true}));
+	this.dispatchEvent(new Event('change', {bubbles: true}));
+	return '';
+}`
+
+// TrySelect waits for the select element matching the selector to be visible, then selects the options
+// whose value or text is one of the values, deselecting the others.
+// It returns an error wrapping InputFailed if any of the values has no option, or the element is not a select.
+func (p *Page) TrySelect(selector string, values ...string) error {
+	return p.TrySelectContext(p.GetContext(), selector, values...)
+}
+
+// TrySelectContext is TrySelect that also stops once ctx is done.
+func (p *Page) TrySelectContext(ctx context.Context, selector string, values ...string) (err error) {
+	defer p.record(Step{Action: ActionSelect, Selector: selector, Values: values}, time.Now(), &err)
+	el, err := p.waitVisibleElement(ctx, selector)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	if values == nil {
+		values = make([]string, 0)
+	}
+	obj, err := el.Context(ctx).Eval(selectJS, values)
+	if err != nil {
+		return elementError(ActionSelect, selector, classify(InputFailed, err))
+	} else if reason := obj.Value.Str(); len(reason) > 0 {
+		return elementError(ActionSelect, selector, fmt.Errorf("%w: %s", InputFailed, reason))
+	}
+	return nil
+}
+
+// TryCheck waits for the checkbox or radio button matching the selector to be visible,
+// then clicks it unless it is already in the state of checked.
+// It returns an error wrapping InputFailed if the state does not change by the click.
+func (p *Page) TryCheck(selector string, checked bool) error {
+	return p.TryCheckContext(p.GetContext(), selector, checked)
+}
+
+// TryCheckContext is TryCheck that also stops once ctx is done.
+func (p *Page) TryCheckContext(ctx context.Context, selector string, checked bool) (err error) {
+	defer p.record(Step{Action: ActionCheck, Selector: selector, Checked: checked}, time.Now(), &err)
+	el, err := p.waitVisibleElement(ctx, selector)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	el = el.Context(ctx)
+	state := func() (bool, error) {
+		obj, err := el.Property("checked")
+		if err != nil {
+			return false, elementError(ActionCheck, selector, classify(InputFailed, err))
+		}
+		return obj.Bool(), nil
+	}
+	current, err := state()
+	if err != nil || current == checked {
+		return err
+	}
+	if err = p.click(el); err != nil {
+		return elementError(ActionCheck, selector, classify(InputFailed, err))
+	}
+	if current, err = state(); err != nil {
+		return err
+	} else if current != checked {
+		return elementError(ActionCheck, selector, fmt.Errorf("%w: checked is still %v", InputFailed, current))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_TrySelect_Selects_Options_By_Value_Or_Text(t *testing.T) {
+	_, p, s := setup(t, testfile.FormHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	assert.NoError(t, p.TrySelect("#color", "Blue"))
+	assert.Equal(t, "b", p.MustElement("#color").MustProperty("value").Str())
+	assert.NoError(t, p.TrySelect("#sizes", "s", "Large"))
+	assert.Equal(t, []any{"s", "l"}, p.MustEval(`() => Array.from(document.querySelector("#sizes").selectedOptions, o => o.value)`).Val())
+
+	assert.ErrorIs(t, p.TrySelect("#color", "Purple"), InputFailed)
+	assert.ErrorIs(t, p.TrySelect("#color", "r", "g"), InputFailed)
+	assert.ErrorIs(t, p.TrySelect("#agree", "r"), InputFailed)
+}
+
+func Test_TryCheck_Sets_State_Of_Checkbox(t *testing.T) {
+	_, p, s := setup(t, testfile.FormHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetDefaultTimeout(time.Second * 5)
+
+	assert.NoError(t, p.TryCheck("#agree", true))
+	assert.True(t, p.MustElement("#agree").MustProperty("checked").Bool())
+	assert.NoError(t, p.TryCheck("#agree", true))
+	assert.True(t, p.MustElement("#agree").MustProperty("checked").Bool())
+	assert.NoError(t, p.TryCheck("#agree", false))
+	assert.False(t, p.MustElement("#agree").MustProperty("checked").Bool())
+
+	assert.ErrorIs(t, p.TryCheck("#disabled", true), InputFailed)
+	assert.ErrorIs(t, p.TryCheck("#never", true), ElementMissing)
+}
@@ -0,0 +1,54 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// scrollIntoViewJS scrolls the element to the center of the viewport.
+const scrollIntoViewJS = `function() { this.scrollIntoView({block: 'center', inline: 'center', behavior: 'instant'}) }`
+
+// scrollByJS scrolls the window by the offset.
+const scrollByJS = `(x, y) => window.scrollBy({left: x, top: y, behavior: 'instant'})`
+
+// ElementBox returns the box of the first element matching the selector in CSS pixels, relative to the top left corner
+// of the viewport, such that positioning logic can tell whether the element is covered or out of view.
+// It returns an error wrapping ElementMissing if no element matches the selector.
+func (p *Page) ElementBox(selector string) (proto.DOMRect, error) {
+	el, err := p.HasElement(selector)
+	if err != nil {
+		return proto.DOMRect{}, err
+	}
+	obj, err := el.Eval(elementBoxJS)
+	if err != nil {
+		return proto.DOMRect{}, elementError(opBox, selector, classify(ElementMissing, err))
+	}
+	var box elementBox
+	if err = decodeJSON(obj, &box); err != nil {
+		return proto.DOMRect{}, elementError(opBox, selector, err)
+	}
+	return box.DOMRect, nil
+}
+
+// ScrollIntoView scrolls the first element matching the selector to the center of the viewport, along with its
+// scrollable containers, such that sticky headers and footers do not cover the element as they do at the edges,
+// and lazy sections around the element are rendered.
+// It returns an error wrapping ElementMissing if no element matches the selector.
+func (p *Page) ScrollIntoView(selector string) error {
+	el, err := p.HasElement(selector)
+	if err != nil {
+		return err
+	}
+	if _, err = el.Eval(scrollIntoViewJS); err != nil {
+		return elementError(opScroll, selector, classify(ElementMissing, err))
+	}
+	return nil
+}
+
+// ScrollBy scrolls the window of this page by the offset in CSS pixels, such as 0 and 600 to scroll down by 600 pixels,
+// or negative ones to scroll up or left. Scrolling stops at the edges of the document.
+func (p *Page) ScrollBy(x, y float64) error {
+	if _, err := p.Eval(scrollByJS, x, y); err != nil {
+		return &Error{Op: opScroll, Err: replaceTimeoutError(replaceAbortedError(err))}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package chromium
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// tallHTML is a page taller than the viewport, with a sticky header and a target far below.
+var tallHTML = []byte(`<html><body style="margin: 0">
+<header style="position: sticky; top: 0; height: 80px">header</header>
+<div style="height: 3000px"></div>
+<button id="target" style="display: block; width: 120px; height: 40px">target</button>
+<div style="height: 3000px"></div>
+</body></html>`)
+
+// tallPage returns a page of the shared setup that is loaded with tallHTML.
+func tallPage(t *testing.T) *Page {
+	_, p, s := setup(t, tallHTML)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.WaitLoad())
+	return p
+}
+
+func Test_ElementBox_Tells_Box_In_Viewport(t *testing.T) {
+	p := tallPage(t)
+	box, err := p.ElementBox("#target")
+	assert.NoError(t, err)
+	assert.Equal(t, 3080.0, box.Y)
+	assert.Equal(t, 120.0, box.Width)
+	assert.Equal(t, 40.0, box.Height)
+}
+
+func Test_ElementBox_Returns_ElementMissing(t *testing.T) {
+	p := tallPage(t)
+	_, err := p.ElementBox("#missing")
+	assert.True(t, errors.Is(err, ElementMissing))
+}
+
+func Test_ScrollBy_Moves_Viewport(t *testing.T) {
+	p := tallPage(t)
+	assert.NoError(t, p.ScrollBy(0, 1000))
+	box, err := p.ElementBox("#target")
+	assert.NoError(t, err)
+	assert.Equal(t, 2080.0, box.Y)
+}
+
+func Test_ScrollBy_Stops_At_Edge(t *testing.T) {
+	p := tallPage(t)
+	assert.NoError(t, p.ScrollBy(0, 1000))
+	assert.NoError(t, p.ScrollBy(0, -5000))
+	assert.Equal(t, 0, p.MustEval(`() => window.scrollY`).Int())
+}
+
+func Test_ScrollIntoView_Centers_Element(t *testing.T) {
+	p := tallPage(t)
+	assert.NoError(t, p.ScrollIntoView("#target"))
+	box, err := p.ElementBox("#target")
+	assert.NoError(t, err)
+	viewport := p.MustEval(`() => window.innerHeight`).Num()
+	assert.InDelta(t, viewport/2, box.Y+box.Height/2, 1)
+}
+
+func Test_ScrollIntoView_Returns_ElementMissing(t *testing.T) {
+	p := tallPage(t)
+	assert.True(t, errors.Is(p.ScrollIntoView("#missing"), ElementMissing))
+}
+
+func Test_elementClip_Tells_Box_In_Document(t *testing.T) {
+	p := tallPage(t)
+	assert.NoError(t, p.ScrollBy(0, 1000))
+	clip, err := p.elementClip("#target")
+	assert.NoError(t, err)
+	assert.Equal(t, 3080.0, clip.Y)
+	assert.Equal(t, 120.0, clip.Width)
+}
@@ -0,0 +1,187 @@
+package chromium
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/go-rod/rod/lib/proto"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HAR is an HTTP Archive 1.2 document, that holds a subset of fields required for recording and replaying pages.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root of the exported data of HAR.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator names the application that created the HAR.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a pair of request and response.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+// HARRequest is a request of HAREntry.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse is a response of HAREntry.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a pair of name and value, which is used for headers, cookies and query strings.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is a body of HARRequest.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is a body of HARResponse. Text is base64 encoded if Encoding is "base64".
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings is a breakdown of the time of HAREntry in milliseconds, where -1 stands for not applicable.
+type HARTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// Bytes returns the decoded body of the content.
+func (c HARContent) Bytes() ([]byte, error) {
+	if c.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(c.Text)
+	}
+	return []byte(c.Text), nil
+}
+
+// ReadHAR decodes a HAR document from the reader.
+func ReadHAR(r io.Reader) (*HAR, error) {
+	h := &HAR{}
+	if err := json.NewDecoder(r).Decode(h); err != nil {
+		return nil, fmt.Errorf("failed to decode HAR: %w", err)
+	}
+	return h, nil
+}
+
+// ReplayHAR fulfills every request of this page with responses that are recorded in the HAR, without any network.
+// Responses of the same method and URL are served in the recorded order, repeating the last one.
+// Requests that are missing from the HAR fail as if the network is disconnected.
+// Returned function stops the replay, such that requests go through the network again.
+func (p *Page) ReplayHAR(h *HAR) (stop func() error, err error) {
+	index := newHARIndex(h)
+	return p.Intercept("*", func(req *InterceptedRequest) {
+		entry, found := index.next(req.Method(), req.URL().String())
+		if !found {
+			req.Abort(proto.NetworkErrorReasonInternetDisconnected)
+			return
+		}
+		body, err := entry.Response.Content.Bytes()
+		if err != nil {
+			req.Abort(proto.NetworkErrorReasonFailed)
+			return
+		}
+		header := http.Header{}
+		for _, h := range entry.Response.Headers {
+			if !isEncodingHeader(h.Name) {
+				header.Add(h.Name, h.Value)
+			}
+		}
+		req.Fulfill(entry.Response.Status, header, body)
+	})
+}
+
+// isEncodingHeader checks if the header describes an encoding of the body, which does not apply to decoded HAR content.
+func isEncodingHeader(name string) bool {
+	switch strings.ToLower(name) {
+	case "content-encoding", "content-length", "transfer-encoding":
+		return true
+	}
+	return false
+}
+
+// harIndex looks up entries of HAR by their method and URL.
+type harIndex struct {
+	mu      *sync.Mutex
+	entries map[string][]*HAREntry
+}
+
+func newHARIndex(h *HAR) *harIndex {
+	index := &harIndex{mu: &sync.Mutex{}, entries: make(map[string][]*HAREntry)}
+	for i := range h.Log.Entries {
+		entry := &h.Log.Entries[i]
+		key := harKey(entry.Request.Method, entry.Request.URL)
+		index.entries[key] = append(index.entries[key], entry)
+	}
+	return index
+}
+
+// next returns the next entry for the method and URL, keeping the last entry once others are consumed.
+func (i *harIndex) next(method, url string) (*HAREntry, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	key := harKey(method, url)
+	entries := i.entries[key]
+	if len(entries) == 0 {
+		return nil, false
+	}
+	entry := entries[0]
+	if len(entries) > 1 {
+		i.entries[key] = entries[1:]
+	}
+	return entry, true
+}
+
+func harKey(method, url string) string {
+	return strings.ToUpper(method) + " " + url
+}
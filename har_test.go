@@ -0,0 +1,77 @@
+package chromium
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testHAR = `{"log": {"version": "1.2", "creator": {"name": "test", "version": "1"}, "entries": [
+	{"request": {"method": "GET", "url": "http://test.local/"},
+	 "response": {"status": 200, "headers": [{"name": "Content-Type", "value": "text/html"}, {"name": "Content-Encoding", "value": "gzip"}],
+	  "content": {"mimeType": "text/html", "text": "<ul><li>first</li></ul>"}}},
+	{"request": {"method": "GET", "url": "http://test.local/"},
+	 "response": {"status": 200, "content": {"mimeType": "text/html", "text": "PHVsPjxsaT5zZWNvbmQ8L2xpPjwvdWw+", "encoding": "base64"}}}
+]}}`
+
+func Test_ReadHAR_Decodes_Entries(t *testing.T) {
+	h, err := ReadHAR(strings.NewReader(testHAR))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2", h.Log.Version)
+	if assert.Len(t, h.Log.Entries, 2) {
+		assert.Equal(t, "http://test.local/", h.Log.Entries[0].Request.URL)
+		assert.Equal(t, 200, h.Log.Entries[0].Response.Status)
+	}
+}
+
+func Test_ReadHAR_Returns_Err_When_Invalid(t *testing.T) {
+	_, err := ReadHAR(strings.NewReader("{"))
+	assert.ErrorContains(t, err, "HAR")
+}
+
+func Test_HARContent_Bytes_Decodes_Base64(t *testing.T) {
+	body, err := HARContent{Text: base64.StdEncoding.EncodeToString([]byte("test")), Encoding: "base64"}.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", string(body))
+	body, err = HARContent{Text: "plain"}.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", string(body))
+}
+
+func Test_harIndex_Serves_Entries_In_Order_And_Repeats_Last(t *testing.T) {
+	h, err := ReadHAR(strings.NewReader(testHAR))
+	assert.NoError(t, err)
+	index := newHARIndex(h)
+	first, found := index.next("get", "http://test.local/")
+	assert.True(t, found)
+	second, _ := index.next("GET", "http://test.local/")
+	third, _ := index.next("GET", "http://test.local/")
+	assert.Equal(t, "text/html", first.Response.Content.MimeType)
+	assert.Equal(t, "base64", second.Response.Content.Encoding)
+	assert.Same(t, second, third)
+	_, found = index.next("POST", "http://test.local/")
+	assert.False(t, found)
+}
+
+func Test_isEncodingHeader_Matches_Case_Insensitive(t *testing.T) {
+	assert.True(t, isEncodingHeader("Content-Encoding"))
+	assert.True(t, isEncodingHeader("content-length"))
+	assert.False(t, isEncodingHeader("Content-Type"))
+}
+
+func Test_ReplayHAR_Fulfills_Requests_Without_Network(t *testing.T) {
+	_, p, _ := setup(t)
+	h, err := ReadHAR(strings.NewReader(testHAR))
+	assert.NoError(t, err)
+	stop, err := p.ReplayHAR(h)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = stop() })
+
+	assert.NoError(t, p.TryNavigate("http://test.local/", func(p *Page) bool { return p.MustHas("li") }, time.Millisecond))
+	assert.Equal(t, "first", p.MustElement("li").MustText())
+	assert.NoError(t, p.TryNavigate("http://test.local/", func(p *Page) bool { return p.MustHas("li") }, time.Millisecond))
+	assert.Equal(t, "second", p.MustElement("li").MustText())
+	assert.Error(t, p.Navigate("http://test.local/missing"))
+}
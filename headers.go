@@ -0,0 +1,48 @@
+package chromium
+
+import "github.com/go-rod/rod"
+
+// SetExtraHeaders sets headers that are sent with every request of this page, replacing the ones set before,
+// such as an authorization token, or "Accept-Language" for language preferences.
+// Headers persist across navigations, and are restored when the tab of the page is replaced by the browser.
+// Nil or empty headers stop sending extra headers.
+func (p *Page) SetExtraHeaders(headers map[string]string) error {
+	copied := make(map[string]string, len(headers))
+	for key, value := range headers {
+		copied[key] = value
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := setExtraHeaders(p.Page, copied); err != nil {
+		return err
+	}
+	p.headers = copied
+	return nil
+}
+
+// ExtraHeaders returns a copy of headers that are set by SetExtraHeaders.
+func (p *Page) ExtraHeaders() map[string]string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	headers := make(map[string]string, len(p.headers))
+	for key, value := range p.headers {
+		headers[key] = value
+	}
+	return headers
+}
+
+// restoreExtraHeaders sets the extra headers of this page to given tab, which is about to replace the current tab.
+func (p *Page) restoreExtraHeaders(page *rod.Page) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.headers) == 0 {
+		return nil
+	}
+	return setExtraHeaders(page, p.headers)
+}
+
+// setExtraHeaders sets the headers to the tab, leaving the network domain enabled, which keeps them across navigations.
+func setExtraHeaders(page *rod.Page, headers map[string]string) error {
+	_, err := page.SetExtraHeaders(headerDict(headers))
+	return replaceAbortedError(err)
+}
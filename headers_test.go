@@ -0,0 +1,33 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_SetExtraHeaders_Persist_Across_Navigations_And_Recycle(t *testing.T) {
+	b, p, s := setup(t, testfile.BlankHTML)
+	headers := map[string]string{"Authorization": "Bearer token", "Accept-Language": "ko-KR"}
+	assert.NoError(t, p.SetExtraHeaders(headers))
+	headers["Authorization"] = "changed"
+	assert.Equal(t, "Bearer token", p.ExtraHeaders()["Authorization"])
+
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustNavigate(s.URL + "/next").MustWaitLoad()
+	assert.NoError(t, b.recyclePage(p))
+	p.MustNavigate(s.URL + "/recycled").MustWaitLoad()
+	requests := s.Requests()
+	if assert.GreaterOrEqual(t, len(requests), 3) {
+		for _, r := range requests {
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.Equal(t, "ko-KR", r.Header.Get("Accept-Language"))
+		}
+	}
+
+	assert.NoError(t, p.SetExtraHeaders(nil))
+	assert.Empty(t, p.ExtraHeaders())
+	p.MustNavigate(s.URL + "/cleared").MustWaitLoad()
+	requests = s.Requests()
+	assert.Empty(t, requests[len(requests)-1].Header.Get("Authorization"))
+}
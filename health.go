@@ -0,0 +1,35 @@
+package chromium
+
+import "time"
+
+// pingJS is evaluated to tell whether the renderer of a page responds.
+const pingJS = `() => true`
+
+// WithHealthCheck pings each page when it is acquired from the page pool,
+// replacing the page by a fresh blank one if it does not respond within the timeout, e.g. once its renderer crashed.
+// Note that a page blocked by an unhandled dialog does not respond either, hence it is replaced as well.
+func WithHealthCheck(timeout time.Duration) BrowserOption {
+	return func(o *browserOptions) {
+		o.healthTimeout = timeout
+	}
+}
+
+// alive tells whether the page responds to a script within the timeout.
+func (p *Page) alive(timeout time.Duration) bool {
+	_, err := p.Timeout(timeout).Eval(pingJS)
+	return err == nil
+}
+
+// checkPage replaces the page by a fresh one if health checks are enabled and the page does not respond.
+// The page is returned as is when the replacement fails, as the browser itself may be gone.
+func (b *Browser) checkPage(p *Page) *Page {
+	if p == nil || b.healthTimeout <= 0 || p.alive(b.healthTimeout) {
+		return p
+	}
+	if err := b.recyclePage(p); err == nil {
+		b.mu.Lock()
+		b.usage.replaced++
+		b.mu.Unlock()
+	}
+	return p
+}
@@ -0,0 +1,31 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_checkPage_Returns_Page_As_Is_Without_HealthCheck(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	p := b.pagePool.Get()
+	assert.Equal(t, p, b.checkPage(p))
+	assert.Nil(t, b.checkPage(nil))
+	assert.Zero(t, b.PoolStats().Replaced)
+}
+
+func Test_WithHealthCheck_Replaces_Crashed_Page(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithHealthCheck(time.Second))
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	p := b.GetPage()
+	_ = proto.PageCrash{}.Call(p)
+	b.PutPage(p)
+
+	p = b.GetPage()
+	defer b.PutPage(p)
+	assert.True(t, p.alive(time.Second))
+	assert.Equal(t, 1, b.PoolStats().Replaced)
+}
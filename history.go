@@ -0,0 +1,79 @@
+package chromium
+
+import (
+	"time"
+)
+
+// ActionRecord is an entry of the history of a page, telling a helper that has been invoked on the page.
+type ActionRecord struct {
+	Action   string        // action of the helper, as of Step, such as navigate for TryNavigate.
+	Selector string        // selector of the element that the helper is on, if any.
+	URL      string        // URL that the helper navigates to, if any.
+	Text     string        // text that the helper inputs, or name of the object that it waits for, if any.
+	Begin    time.Time     // time that the helper began.
+	Duration time.Duration // time taken by the helper.
+	Err      error         // error that the helper returned, if failed.
+}
+
+// History returns a copy of the history of helpers invoked on this page, from the oldest to the latest, such that what
+// the page did leading up to a failure can be dumped. Helpers that a Recorder captures are recorded, whether or not
+// the page is recording. Once the history reaches its limit, the oldest record is overwritten.
+// Note that the text of input helpers is kept as-is, including any credential typed into the page by TryInput,
+// while TryInputSecret keeps a placeholder instead.
+func (p *Page) History() []ActionRecord {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.orderedHistory()
+}
+
+// orderedHistory returns a copy of the ring buffer of the history in order, which must be called under the lock.
+func (p *Page) orderedHistory() []ActionRecord {
+	history := make([]ActionRecord, 0, len(p.history))
+	history = append(history, p.history[p.historyHead:]...)
+	return append(history, p.history[:p.historyHead]...)
+}
+
+// ClearHistory removes all records from the history of this page.
+func (p *Page) ClearHistory() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.history = make([]ActionRecord, 0)
+	p.historyHead = 0
+}
+
+// SetHistoryLimit sets the maximum number of records kept in the history of this page, keeping the latest ones.
+// Zero or negative limit disables the history. The limit defaults to 100.
+func (p *Page) SetHistoryLimit(limit int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	history := p.orderedHistory()
+	if limit <= 0 {
+		history = history[:0]
+	} else if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	p.history, p.historyHead, p.historyLimit = history, 0, limit
+}
+
+// addHistory appends the step with its timings from given beginning to the history of this page.
+func (p *Page) addHistory(step Step, begin time.Time, err error) {
+	r := ActionRecord{
+		Action:   step.Action,
+		Selector: step.Selector,
+		URL:      step.URL,
+		Text:     step.Text,
+		Begin:    begin,
+		Duration: time.Since(begin),
+		Err:      err,
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.historyLimit <= 0 {
+		return
+	} else if len(p.history) < p.historyLimit {
+		p.history = append(p.history, r)
+		return
+	}
+	p.history[p.historyHead] = r
+	p.historyHead = (p.historyHead + 1) % len(p.history)
+}
@@ -0,0 +1,57 @@
+package chromium
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// selectorsOf returns the selectors of the records.
+func selectorsOf(history []ActionRecord) []string {
+	selectors := make([]string, len(history))
+	for i, r := range history {
+		selectors[i] = r.Selector
+	}
+	return selectors
+}
+
+func Test_History_Records_Helpers(t *testing.T) {
+	p := newPage(nil, func() {})
+	assert.NoError(t, p.WaitJSObjectForContext(context.Background(), "", time.Second))
+	err := TaskTimeout
+	p.record(Step{Action: ActionNavigate, URL: "https://example.com/"}, time.Now().Add(-time.Second), &err)
+
+	history := p.History()
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, ActionWaitJS, history[0].Action)
+		assert.NoError(t, history[0].Err)
+		assert.Equal(t, ActionNavigate, history[1].Action)
+		assert.Equal(t, "https://example.com/", history[1].URL)
+		assert.ErrorIs(t, history[1].Err, TaskTimeout)
+		assert.GreaterOrEqual(t, history[1].Duration, time.Second)
+	}
+}
+
+func Test_History_Keeps_Latest_Records(t *testing.T) {
+	p := newPage(nil, func() {})
+	p.SetHistoryLimit(2)
+	var err error
+	for _, selector := range []string{"#a", "#b", "#c"} {
+		p.record(Step{Action: ActionClick, Selector: selector}, time.Now(), &err)
+	}
+	assert.Equal(t, []string{"#b", "#c"}, selectorsOf(p.History()))
+
+	p.SetHistoryLimit(1)
+	assert.Equal(t, []string{"#c"}, selectorsOf(p.History()))
+
+	p.SetHistoryLimit(0)
+	p.record(Step{Action: ActionClick, Selector: "#d"}, time.Now(), &err)
+	assert.Empty(t, p.History())
+
+	p.SetHistoryLimit(3)
+	p.record(Step{Action: ActionClick, Selector: "#e"}, time.Now(), &err)
+	assert.Equal(t, []string{"#e"}, selectorsOf(p.History()))
+	p.ClearHistory()
+	assert.Empty(t, p.History())
+}
@@ -0,0 +1,46 @@
+package chromium
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WithHostRules resolves the hosts of the rules to their targets, such as "example.com" to "127.0.0.1:8080",
+// such that staging environments can be driven against production hostnames. A host may be a wildcard such as
+// "*.example.com", and a target may carry a port, which replaces the port of requests to the host.
+// Requests keep the original host in their URL and Host header, and TLS certificates are still verified against it.
+// The rules are applied to the browser launched by NewBrowser or NewBrowserWithProxy, by --host-resolver-rules,
+// thus NewBrowserFromControlURL returns an error with them.
+// Note that the browser resolves "localhost" by itself, and a proxy resolves hosts on behalf of the browser.
+func WithHostRules(rules map[string]string) BrowserOption {
+	return func(o *browserOptions) {
+		o.hostRules = make(map[string]string, len(rules))
+		for host, target := range rules {
+			o.hostRules[host] = target
+		}
+	}
+}
+
+// hostResolverRules returns the rules as of --host-resolver-rules, such as "MAP example.com 127.0.0.1:8080",
+// ordered by their hosts.
+func hostResolverRules(rules map[string]string) (string, error) {
+	hosts := make([]string, 0, len(rules))
+	for host, target := range rules {
+		if !isHostRuleField(host) || !isHostRuleField(target) {
+			return "", fmt.Errorf("invalid host rule %+v -> %+v", host, target)
+		}
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	maps := make([]string, len(hosts))
+	for i, host := range hosts {
+		maps[i] = "MAP " + host + " " + rules[host]
+	}
+	return strings.Join(maps, ", "), nil
+}
+
+// isHostRuleField tells whether the host or target is not empty, and free of delimiters of the rules.
+func isHostRuleField(s string) bool {
+	return len(s) > 0 && !strings.ContainsAny(s, ", \t\r\n")
+}
@@ -0,0 +1,42 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_hostResolverRules_Orders_Rules_By_Host(t *testing.T) {
+	rules, err := hostResolverRules(map[string]string{"example.com": "127.0.0.1:8080", "*.api.test": "10.0.0.1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "MAP *.api.test 10.0.0.1, MAP example.com 127.0.0.1:8080", rules)
+
+	_, err = hostResolverRules(map[string]string{"example.com": ""})
+	assert.Error(t, err)
+	_, err = hostResolverRules(map[string]string{"a.test, MAP *": "127.0.0.1"})
+	assert.Error(t, err)
+}
+
+func Test_WithHostRules_Resolves_Hosts_To_Targets(t *testing.T) {
+	t.Parallel()
+	hosts := make(chan string, 10)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts <- r.Host
+		_, _ = w.Write(testfile.BlankHTML)
+	}))
+	t.Cleanup(s.Close)
+	b, err := NewBrowser(1, WithHostRules(map[string]string{"staging.test": strings.TrimPrefix(s.URL, "http://")}))
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	p := b.GetPage()
+	defer b.PutPage(p)
+
+	assert.NoError(t, p.Navigate("http://staging.test/"))
+	assert.Equal(t, "staging.test", receive(t, hosts))
+
+	_, err = NewBrowser(1, WithHostRules(map[string]string{"staging.test": ""}))
+	assert.Error(t, err)
+}
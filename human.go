@@ -0,0 +1,168 @@
+package chromium
+
+import (
+	"context"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	humanStepDistance = 12.0                   // distance in pixels covered by a step of the cursor on average.
+	humanMinSteps     = 8                      // minimum number of steps of a cursor path.
+	humanMaxSteps     = 60                     // maximum number of steps of a cursor path.
+	humanStepDelay    = time.Millisecond * 6   // minimum delay between steps of a cursor path.
+	humanPressDelay   = time.Millisecond * 60  // minimum delay between pressing and releasing a button.
+	humanJitter       = time.Millisecond * 60  // maximum random delay added to delays above.
+	humanSettleDelay  = time.Millisecond * 40  // minimum delay after the cursor arrives, before pressing.
+	humanBoxMargin    = 0.25                   // ratio of each side of the box of an element, which is avoided as a target.
+	humanCurvature    = 0.3                    // maximum offset of control points of a path, relative to its length.
+	humanIdleDelay    = time.Millisecond * 120 // maximum delay before the cursor starts moving.
+)
+
+// WithHumanInput makes pages of the browser click like a human, as SetHumanInput does.
+func WithHumanInput(enabled bool) BrowserOption {
+	return func(o *browserOptions) {
+		o.human = enabled
+	}
+}
+
+// SetHumanInput sets whether ClickNavigate, TryClick and TryCheck of this page click like a human,
+// by moving the cursor along a curved path with variable speed to a random point of the element, then pressing
+// and releasing the button with a short delay in between. Otherwise, the cursor jumps to the center of the element.
+// Note that the path starts from where the last human click of this page has left the cursor.
+func (p *Page) SetHumanInput(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.human = enabled
+}
+
+// humanInput tells whether this page clicks like a human, and where the cursor is left by the last human click.
+func (p *Page) humanInput() (bool, proto.Point) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.human, p.cursor
+}
+
+// TryClick waits for an element matching the selector to be visible, then clicks it without waiting for navigation.
+// It returns an error wrapping ClickFailed if the element cannot be clicked, such as when it is covered by another.
+func (p *Page) TryClick(selector string) error {
+	return p.TryClickContext(p.GetContext(), selector)
+}
+
+// TryClickContext is TryClick that also stops once ctx is done.
+func (p *Page) TryClickContext(ctx context.Context, selector string) (err error) {
+	defer p.record(Step{Action: ActionClick, Selector: selector}, time.Now(), &err)
+	el, err := p.waitVisibleElement(ctx, selector)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	if err = p.click(el.Context(ctx)); err != nil {
+		return elementError(ActionClick, selector, classify(ClickFailed, err))
+	}
+	return nil
+}
+
+// click clicks the element with the left button, like a human if this page is set so.
+func (p *Page) click(el *rod.Element) error {
+	if human, from := p.humanInput(); human {
+		return p.humanClick(el, from)
+	}
+	return el.Click(proto.InputMouseButtonLeft)
+}
+
+// humanClick moves the cursor from the point to a random point of the element along a curved path, then clicks it.
+func (p *Page) humanClick(el *rod.Element, from proto.Point) error {
+	if err := el.ScrollIntoView(); err != nil {
+		return err
+	}
+	if err := el.WaitEnabled(); err != nil {
+		return err
+	}
+	if _, err := el.WaitInteractable(); err != nil {
+		return err
+	}
+	shape, err := el.Shape()
+	if err != nil {
+		return err
+	}
+	box := shape.Box()
+	to := proto.Point{
+		X: box.X + box.Width*(humanBoxMargin+rand.Float64()*(1-2*humanBoxMargin)),
+		Y: box.Y + box.Height*(humanBoxMargin+rand.Float64()*(1-2*humanBoxMargin)),
+	}
+
+	ctx := el.GetContext()
+	mouse := el.Page().Mouse
+	if err = p.sleep(ctx, jitter(0, humanIdleDelay)); err != nil {
+		return err
+	}
+	for _, pt := range humanPath(from, to) {
+		if err = mouse.Move(pt.X, pt.Y, 1); err != nil {
+			return err
+		}
+		p.mu.Lock()
+		p.cursor = pt
+		p.mu.Unlock()
+		if err = p.sleep(ctx, jitter(humanStepDelay, humanStepDelay)); err != nil {
+			return err
+		}
+	}
+	if err = p.sleep(ctx, jitter(humanSettleDelay, humanJitter)); err != nil {
+		return err
+	}
+	if err = mouse.Down(proto.InputMouseButtonLeft, 1); err != nil {
+		return err
+	}
+	if err = p.sleep(ctx, jitter(humanPressDelay, humanJitter)); err != nil {
+		_ = mouse.Up(proto.InputMouseButtonLeft, 1)
+		return err
+	}
+	return mouse.Up(proto.InputMouseButtonLeft, 1)
+}
+
+// humanPath returns points of a cubic Bezier curve from the point to the point, excluding the start,
+// whose control points are randomly offset from the straight line.
+// Points are eased in and out, such that the cursor speeds up then slows down as it approaches the target.
+func humanPath(from, to proto.Point) []proto.Point {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	distance := math.Hypot(dx, dy)
+	steps := int(distance / humanStepDistance)
+	if steps < humanMinSteps {
+		steps = humanMinSteps
+	} else if steps > humanMaxSteps {
+		steps = humanMaxSteps
+	}
+	// normal of the line, scaled by its length, so that control points bend the path to either side.
+	nx, ny := -dy*humanCurvature, dx*humanCurvature
+	control := func(at float64) proto.Point {
+		bend := rand.Float64()*2 - 1
+		return proto.Point{X: from.X + dx*at + nx*bend, Y: from.Y + dy*at + ny*bend}
+	}
+	c1, c2 := control(0.2+rand.Float64()*0.2), control(0.6+rand.Float64()*0.2)
+
+	path := make([]proto.Point, 0, steps)
+	for i := 1; i <= steps; i++ {
+		t := easeInOut(float64(i) / float64(steps))
+		u := 1 - t
+		path = append(path, proto.Point{
+			X: u*u*u*from.X + 3*u*u*t*c1.X + 3*u*t*t*c2.X + t*t*t*to.X,
+			Y: u*u*u*from.Y + 3*u*u*t*c1.Y + 3*u*t*t*c2.Y + t*t*t*to.Y,
+		})
+	}
+	return path
+}
+
+// easeInOut maps the progress in [0, 1] by a smoothstep, which is slow at both ends.
+func easeInOut(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// jitter returns the duration added by a random duration up to the spread.
+func jitter(d, spread time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(spread)+1))
+}
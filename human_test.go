@@ -0,0 +1,50 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func Test_HumanPath_Ends_At_Target_Within_Step_Bounds(t *testing.T) {
+	from, to := proto.Point{X: 10, Y: 10}, proto.Point{X: 400, Y: 250}
+	path := humanPath(from, to)
+	assert.GreaterOrEqual(t, len(path), humanMinSteps)
+	assert.LessOrEqual(t, len(path), humanMaxSteps)
+	last := path[len(path)-1]
+	assert.InDelta(t, to.X, last.X, 1e-9)
+	assert.InDelta(t, to.Y, last.Y, 1e-9)
+
+	assert.Len(t, humanPath(from, from), humanMinSteps)
+	assert.Len(t, humanPath(proto.Point{}, proto.Point{X: 1e5}), humanMaxSteps)
+}
+
+func Test_HumanPath_Varies_Speed(t *testing.T) {
+	path := humanPath(proto.Point{}, proto.Point{X: 600})
+	step := func(i int) float64 {
+		return math.Hypot(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y)
+	}
+	mid := len(path) / 2
+	assert.Greater(t, step(mid), step(1))
+	assert.Greater(t, step(mid), step(len(path)-1))
+}
+
+func Test_TryClick_Clicks_Like_Human(t *testing.T) {
+	_, p, s := setup(t, testfile.ClickNavigateHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => {
+		window.moves = 0;
+		document.addEventListener('mousemove', () => window.moves++);
+		document.querySelector('a').addEventListener('click', () => window.clicked = true);
+	}`)
+	p.SetHumanInput(true)
+	assert.NoError(t, p.TryClick("a"))
+	assert.True(t, p.MustEval(`() => window.clicked === true`).Bool())
+	assert.GreaterOrEqual(t, p.MustEval(`() => window.moves`).Int(), humanMinSteps)
+	_, cursor := p.humanInput()
+	assert.NotEqual(t, proto.Point{}, cursor)
+
+	assert.ErrorIs(t, p.TryClick("#never"), ElementMissing)
+}
@@ -0,0 +1,114 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"sort"
+	"sync"
+)
+
+// Identity is a set of browser traits that a page presents to the websites.
+// Empty fields are left as they are.
+type Identity struct {
+	UserAgent      string            `json:"userAgent,omitempty"`
+	AcceptLanguage string            `json:"acceptLanguage,omitempty"`
+	Platform       string            `json:"platform,omitempty"`
+	Timezone       string            `json:"timezone,omitempty"` // such as "Asia/Seoul"
+	Viewport       *Viewport         `json:"viewport,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"` // extra headers to be sent with every request
+}
+
+// Viewport is the size of the screen that a page emulates.
+type Viewport struct {
+	Width             int     `json:"width"`
+	Height            int     `json:"height"`
+	DeviceScaleFactor float64 `json:"deviceScaleFactor,omitempty"`
+	Mobile            bool    `json:"mobile,omitempty"`
+}
+
+// ApplyIdentity overrides the traits of this page by the identity.
+// The overrides last until the page is closed, or overridden by another identity.
+func (p *Page) ApplyIdentity(id Identity) error {
+	if len(id.UserAgent) > 0 || len(id.AcceptLanguage) > 0 || len(id.Platform) > 0 {
+		userAgent := id.UserAgent
+		if len(userAgent) == 0 {
+			version, err := proto.BrowserGetVersion{}.Call(p.Browser())
+			if err != nil {
+				return replaceAbortedError(err)
+			}
+			userAgent = version.UserAgent
+		}
+		override := &proto.NetworkSetUserAgentOverride{UserAgent: userAgent, AcceptLanguage: id.AcceptLanguage, Platform: id.Platform}
+		if err := p.SetUserAgent(override); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	if len(id.Timezone) > 0 {
+		if err := (proto.EmulationSetTimezoneOverride{TimezoneID: id.Timezone}).Call(p); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	if v := id.Viewport; v != nil {
+		metrics := &proto.EmulationSetDeviceMetricsOverride{Width: v.Width, Height: v.Height, DeviceScaleFactor: v.DeviceScaleFactor, Mobile: v.Mobile}
+		if err := p.SetViewport(metrics); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	if len(id.Headers) > 0 {
+		if err := p.SetExtraHeaders(id.Headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// headerDict flattens the headers into pairs of key and value, ordered by keys.
+func headerDict(headers map[string]string) []string {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	dict := make([]string, 0, len(headers)*2)
+	for _, key := range keys {
+		dict = append(dict, key, headers[key])
+	}
+	return dict
+}
+
+// IdentityRotation hands out identities in round-robin manner. It is safe for concurrent use.
+type IdentityRotation struct {
+	mu         *sync.Mutex
+	identities []Identity
+	next       int
+}
+
+// NewIdentityRotation returns a rotation over given identities.
+func NewIdentityRotation(identities ...Identity) *IdentityRotation {
+	return &IdentityRotation{mu: &sync.Mutex{}, identities: identities}
+}
+
+// Next returns the next identity of the rotation, or an empty identity if the rotation has none.
+func (r *IdentityRotation) Next() Identity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.identities) == 0 {
+		return Identity{}
+	}
+	id := r.identities[r.next%len(r.identities)]
+	r.next++
+	return id
+}
+
+// GetPageWithIdentity is GetPage that applies the next identity of the rotation to the page.
+// If the identity fails to be applied, the page is put back to the pool and the error is returned.
+func (b *Browser) GetPageWithIdentity(r *IdentityRotation) (*Page, error) {
+	p := b.GetPage()
+	if p == nil {
+		return nil, BrowserClosed
+	}
+	if err := p.ApplyIdentity(r.Next()); err != nil {
+		b.PutPage(p)
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,54 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_headerDict_Flattens_Headers_By_Key_Order(t *testing.T) {
+	dict := headerDict(map[string]string{"X-B": "2", "X-A": "1"})
+	assert.Equal(t, []string{"X-A", "1", "X-B", "2"}, dict)
+}
+
+func Test_IdentityRotation_Hands_Out_Identities_In_Order(t *testing.T) {
+	r := NewIdentityRotation(Identity{UserAgent: "a"}, Identity{UserAgent: "b"})
+	assert.Equal(t, "a", r.Next().UserAgent)
+	assert.Equal(t, "b", r.Next().UserAgent)
+	assert.Equal(t, "a", r.Next().UserAgent)
+}
+
+func Test_IdentityRotation_Returns_Empty_Identity_When_None(t *testing.T) {
+	assert.Equal(t, Identity{}, NewIdentityRotation().Next())
+}
+
+func Test_GetPageWithIdentity_Returns_BrowserClosed_When_Closed(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	b.stopServing()
+	p, err := b.GetPageWithIdentity(NewIdentityRotation())
+	assert.Nil(t, p)
+	assert.ErrorIs(t, err, BrowserClosed)
+}
+
+func Test_ApplyIdentity_Overrides_Page_Traits(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	id := Identity{
+		UserAgent:      "test-agent/1.0",
+		AcceptLanguage: "ko-KR",
+		Timezone:       "Asia/Seoul",
+		Viewport:       &Viewport{Width: 800, Height: 600, DeviceScaleFactor: 1},
+		Headers:        map[string]string{"X-Test": "identity"},
+	}
+	assert.NoError(t, p.ApplyIdentity(id))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	assert.Equal(t, "test-agent/1.0", p.MustEval(`() => navigator.userAgent`).String())
+	assert.Equal(t, "Asia/Seoul", p.MustEval(`() => Intl.DateTimeFormat().resolvedOptions().timeZone`).String())
+	assert.Equal(t, 800, p.MustEval(`() => window.innerWidth`).Int())
+	requests := s.Requests()
+	if assert.NotEmpty(t, requests) {
+		last := requests[len(requests)-1]
+		assert.Equal(t, "identity", last.Header.Get("X-Test"))
+		assert.Equal(t, "ko-KR", last.Header.Get("Accept-Language"))
+	}
+}
@@ -0,0 +1,59 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// initScript is a script added by AddInitScript, along with its identifier on the current tab of the page.
+type initScript struct {
+	source string
+	id     proto.PageScriptIdentifier
+}
+
+// AddInitScript adds a script that runs on every new document of this page, before any script of the document,
+// such that fingerprint patches and polyfills are in place when site scripts run.
+// The script does not run on the current document. It persists across navigations, including retries of TryNavigate,
+// and is added again once the page is replaced by a fresh tab, until the returned remove is called.
+func (p *Page) AddInitScript(js string) (remove func() error, err error) {
+	script := &initScript{source: js}
+	if script.id, err = addInitScript(p.Page, js); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.initScripts = append(p.initScripts, script)
+	p.mu.Unlock()
+	return func() error {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for i, s := range p.initScripts {
+			if s == script {
+				p.initScripts = append(p.initScripts[:i:i], p.initScripts[i+1:]...)
+				return replaceAbortedError(proto.PageRemoveScriptToEvaluateOnNewDocument{Identifier: s.id}.Call(p))
+			}
+		}
+		return nil
+	}, nil
+}
+
+func addInitScript(page *rod.Page, js string) (proto.PageScriptIdentifier, error) {
+	res, err := proto.PageAddScriptToEvaluateOnNewDocument{Source: js}.Call(page)
+	if err != nil {
+		return "", replaceAbortedError(err)
+	}
+	return res.Identifier, nil
+}
+
+// restoreInitScripts adds the init scripts of this page to given tab, which is about to replace the current tab.
+func (p *Page) restoreInitScripts(page *rod.Page) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, s := range p.initScripts {
+		id, err := addInitScript(page, s.source)
+		if err != nil {
+			return err
+		}
+		s.id = id
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_AddInitScript_Runs_On_Every_Navigation(t *testing.T) {
+	items := makeItems(testfile.BlankHTML, testfile.ItemsHTML, 2)
+	_, p, s := setup(t, items...)
+	remove, err := p.AddInitScript(`window.patched = (window.patched || 0) + 1`)
+	assert.NoError(t, err)
+
+	attempts := 0
+	err = p.TryNavigate(s.URL, func(p *Page) bool {
+		attempts++
+		return p.MustEval(`() => window.patched === 1`).Bool() && p.MustHas("li")
+	}, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	assert.NoError(t, remove())
+	assert.NoError(t, remove())
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.True(t, p.MustEval(`() => window.patched === undefined`).Bool())
+}
+
+func Test_AddInitScript_Persists_Across_Recycle(t *testing.T) {
+	b, p, s := setup(t, testfile.BlankHTML)
+	_, err := p.AddInitScript(`window.patched = true`)
+	assert.NoError(t, err)
+	assert.NoError(t, b.recyclePage(p))
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.True(t, p.MustEval(`() => window.patched === true`).Bool())
+}
@@ -0,0 +1,278 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// InterceptedRequest is a request of a page that is paused by Intercept, until its handlers decide what to do with it.
+// Unless a handler blocks or fulfills it, the request continues to the network along with its modifications.
+type InterceptedRequest struct {
+	hijack  *rod.Hijack
+	header  http.Header
+	body    []byte
+	changed bool // whether the header or the body is modified.
+
+	abort     proto.NetworkErrorReason
+	fulfilled bool
+	status    int
+	resHeader http.Header
+	resBody   []byte
+}
+
+func newInterceptedRequest(h *rod.Hijack) *InterceptedRequest {
+	header := http.Header{}
+	for key, value := range h.Request.Headers() {
+		header.Add(key, value.Str())
+	}
+	return &InterceptedRequest{hijack: h, header: header, body: []byte(h.Request.Body())}
+}
+
+// Method returns the method of the request.
+func (r *InterceptedRequest) Method() string {
+	return r.hijack.Request.Method()
+}
+
+// URL returns the URL of the request.
+func (r *InterceptedRequest) URL() *url.URL {
+	return r.hijack.Request.URL()
+}
+
+// ResourceType returns the type of resource that the request loads, such as Document, Image or XHR.
+func (r *InterceptedRequest) ResourceType() proto.NetworkResourceType {
+	return r.hijack.Request.Type()
+}
+
+// Header returns the value of the header of the request, including modifications by handlers.
+func (r *InterceptedRequest) Header(key string) string {
+	return r.header.Get(key)
+}
+
+// Body returns the body of the request, including modifications by handlers.
+func (r *InterceptedRequest) Body() []byte {
+	return r.body
+}
+
+// SetHeader sets the header of the request before it is sent. Empty value removes the header.
+func (r *InterceptedRequest) SetHeader(key, value string) {
+	if len(value) == 0 {
+		r.header.Del(key)
+	} else {
+		r.header.Set(key, value)
+	}
+	r.changed = true
+}
+
+// SetBody replaces the body of the request before it is sent.
+func (r *InterceptedRequest) SetBody(body []byte) {
+	r.body = body
+	r.changed = true
+}
+
+// Block fails the request as if it is blocked by the client.
+func (r *InterceptedRequest) Block() {
+	r.Abort(proto.NetworkErrorReasonBlockedByClient)
+}
+
+// Abort fails the request by given reason, without sending it.
+func (r *InterceptedRequest) Abort(reason proto.NetworkErrorReason) {
+	r.abort = reason
+}
+
+// Fulfill responds to the request with given response, without sending it.
+func (r *InterceptedRequest) Fulfill(status int, header http.Header, body []byte) {
+	r.fulfilled, r.status, r.resHeader, r.resBody = true, status, header, body
+}
+
+// Handled checks if the request is either blocked or fulfilled, in which case subsequent handlers are skipped.
+func (r *InterceptedRequest) Handled() bool {
+	return len(r.abort) > 0 || r.fulfilled
+}
+
+// apply reports the decision on the request back to the browser.
+func (r *InterceptedRequest) apply() {
+	switch {
+	case len(r.abort) > 0:
+		r.hijack.Response.Fail(r.abort)
+	case r.fulfilled:
+		payload := r.hijack.Response.Payload()
+		payload.ResponseCode = r.status
+		payload.ResponseHeaders = headerEntries(r.resHeader)
+		payload.Body = r.resBody
+	case r.changed:
+		r.hijack.ContinueRequest(&proto.FetchContinueRequest{Headers: headerEntries(r.header), PostData: r.body})
+	default:
+		r.hijack.ContinueRequest(&proto.FetchContinueRequest{})
+	}
+}
+
+// headerEntries converts the header into entries of DevTools protocol, ordered by keys.
+func headerEntries(header http.Header) []*proto.FetchHeaderEntry {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]*proto.FetchHeaderEntry, 0, len(header))
+	for _, key := range keys {
+		for _, value := range header[key] {
+			entries = append(entries, &proto.FetchHeaderEntry{Name: key, Value: value})
+		}
+	}
+	return entries
+}
+
+// interceptRule is a handler for requests whose URL matches the pattern, and whose type is one of types if any.
+type interceptRule struct {
+	pattern *regexp.Regexp
+	types   map[proto.NetworkResourceType]bool
+	handler func(*InterceptedRequest)
+}
+
+func (r *interceptRule) match(req *InterceptedRequest) bool {
+	if len(r.types) > 0 && !r.types[req.ResourceType()] {
+		return false
+	}
+	return r.pattern.MatchString(req.URL().String())
+}
+
+// interceptor dispatches paused requests of a page to its rules, with a single hijack router of the page,
+// such that multiple rules do not override patterns of each other.
+type interceptor struct {
+	mu     *sync.Mutex
+	router *rod.HijackRouter
+	target proto.TargetTargetID // target of the tab that the router is bound to.
+	rules  []*interceptRule
+}
+
+// Intercept pauses requests of this page whose URL matches the pattern, then passes them to the handler,
+// which may block them, modify their headers and body, or fulfill them with a canned response.
+// The pattern is a wildcard where '*' matches zero or more characters and '?' matches exactly one character,
+// such as "*/api/*". Handlers run in the order of being added, until one of them blocks or fulfills the request.
+// Returned function removes the handler.
+func (p *Page) Intercept(pattern string, handler func(*InterceptedRequest)) (remove func() error, err error) {
+	return p.intercept(pattern, nil, handler)
+}
+
+// intercept adds a rule for requests of the types if any, whose URL matches the pattern.
+func (p *Page) intercept(pattern string, types []proto.NetworkResourceType, handler func(*InterceptedRequest)) (func() error, error) {
+	reg, err := regexp.Compile(proto.PatternToReg(pattern))
+	if err != nil {
+		return nil, err
+	}
+	rule := &interceptRule{pattern: reg, handler: handler, types: make(map[proto.NetworkResourceType]bool)}
+	for _, t := range types {
+		rule.types[t] = true
+	}
+
+	p.mu.Lock()
+	if p.interceptor == nil {
+		p.interceptor = &interceptor{mu: &sync.Mutex{}}
+	}
+	ic, page := p.interceptor, p.Page
+	p.mu.Unlock()
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	if err = ic.arm(page); err != nil {
+		return nil, err
+	}
+	ic.rules = append(ic.rules, rule)
+	return func() error { return ic.remove(rule) }, nil
+}
+
+// arm binds the router to the tab, replacing the router of another tab, e.g. the one that the page had before being recycled.
+// The lock must be held by the caller.
+func (ic *interceptor) arm(page *rod.Page) error {
+	if ic.router != nil && ic.target == page.TargetID {
+		return nil
+	}
+	if ic.router != nil {
+		_ = ic.router.Stop() // the tab may be closed already.
+		ic.router = nil
+	}
+	router := page.HijackRequests()
+	if err := router.Add("*", "", ic.dispatch); err != nil {
+		return replaceAbortedError(err)
+	}
+	go router.Run()
+	ic.router, ic.target = router, page.TargetID
+	return nil
+}
+
+// rearm binds the router to the tab if any rule is left, such that rules keep firing on the new tab of a recycled page.
+func (ic *interceptor) rearm(page *rod.Page) error {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	if len(ic.rules) == 0 {
+		return nil
+	}
+	return ic.arm(page)
+}
+
+// restoreInterceptor binds the rules of this page to given tab, which is about to replace the current tab.
+func (p *Page) restoreInterceptor(page *rod.Page) error {
+	p.mu.Lock()
+	ic := p.interceptor
+	p.mu.Unlock()
+	if ic == nil {
+		return nil
+	}
+	return ic.rearm(page)
+}
+
+// remove removes the rule, then stops the router once no rule is left.
+func (ic *interceptor) remove(rule *interceptRule) error {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	for i, r := range ic.rules {
+		if r == rule {
+			ic.rules = append(ic.rules[:i:i], ic.rules[i+1:]...)
+			break
+		}
+	}
+	if len(ic.rules) > 0 || ic.router == nil {
+		return nil
+	}
+	router := ic.router
+	ic.router = nil
+	return replaceAbortedError(router.Stop())
+}
+
+// dispatch passes the paused request to the matching rules in order, then applies their decision.
+func (ic *interceptor) dispatch(h *rod.Hijack) {
+	ic.mu.Lock()
+	rules := ic.rules
+	ic.mu.Unlock()
+	req := newInterceptedRequest(h)
+	for _, rule := range rules {
+		if rule.match(req) {
+			rule.handler(req)
+			if req.Handled() {
+				break
+			}
+		}
+	}
+	req.apply()
+}
+
+// HeavyResources are types of resources that are rarely needed for scraping, while taking most of the bandwidth.
+var HeavyResources = []proto.NetworkResourceType{
+	proto.NetworkResourceTypeImage,
+	proto.NetworkResourceTypeFont,
+	proto.NetworkResourceTypeMedia,
+}
+
+// BlockResources blocks requests of this page that load any of the types, such as HeavyResources.
+// Returned function removes the block.
+func (p *Page) BlockResources(types ...proto.NetworkResourceType) (remove func() error, err error) {
+	if len(types) == 0 {
+		return func() error { return nil }, nil
+	}
+	return p.intercept("*", types, func(req *InterceptedRequest) { req.Block() })
+}
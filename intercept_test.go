@@ -0,0 +1,130 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_headerEntries_Flattens_Header_By_Key_Order(t *testing.T) {
+	entries := headerEntries(http.Header{"X-B": {"2"}, "X-A": {"1", "3"}})
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "X-A", entries[0].Name)
+		assert.Equal(t, "1", entries[0].Value)
+		assert.Equal(t, "3", entries[1].Value)
+		assert.Equal(t, "X-B", entries[2].Name)
+	}
+}
+
+func Test_interceptor_remove_Keeps_Other_Rules(t *testing.T) {
+	a, b := &interceptRule{}, &interceptRule{}
+	ic := &interceptor{mu: &sync.Mutex{}, rules: []*interceptRule{a, b}}
+	assert.NoError(t, ic.remove(a))
+	assert.Equal(t, []*interceptRule{b}, ic.rules)
+	assert.NoError(t, ic.remove(a))
+	assert.Equal(t, []*interceptRule{b}, ic.rules)
+}
+
+func Test_Intercept_Fulfills_Request(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	remove, err := p.Intercept("*", func(req *InterceptedRequest) {
+		req.Fulfill(http.StatusOK, http.Header{"Content-Type": {"text/html"}}, []byte(`<p id="mock">mocked</p>`))
+	})
+	assert.NoError(t, err)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.Equal(t, "mocked", p.MustElement("#mock").MustText())
+	requestCountMustBeAsExpected(t, s, 0)
+
+	assert.NoError(t, remove())
+	p.MustNavigate(s.URL).MustWaitLoad()
+	requestCountMustBeAsExpected(t, s, 1)
+}
+
+func Test_Intercept_Modifies_Request_Header(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	_, err := p.Intercept("*", func(req *InterceptedRequest) { req.SetHeader("X-Test", "intercepted") })
+	assert.NoError(t, err)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	requests := s.Requests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "intercepted", requests[0].Header.Get("X-Test"))
+	}
+}
+
+func Test_Intercept_Blocks_Request_And_Skips_Later_Handlers(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	called := false
+	_, err := p.Intercept("*", func(req *InterceptedRequest) { req.Block() })
+	assert.NoError(t, err)
+	_, err = p.Intercept("*", func(req *InterceptedRequest) { called = true })
+	assert.NoError(t, err)
+	assert.Error(t, p.Navigate(s.URL))
+	assert.False(t, called)
+	requestCountMustBeAsExpected(t, s, 0)
+}
+
+func Test_BlockResources_Returns_Noop_When_No_Types(t *testing.T) {
+	remove, err := newPage(nil, func() {}).BlockResources()
+	assert.NoError(t, err)
+	assert.NoError(t, remove())
+}
+
+func Test_BlockResources_Blocks_Only_Given_Types(t *testing.T) {
+	_, p, s := setup(t, []byte(`<html><body><img id="img" src="/image.png"><p>text</p></body></html>`))
+	_, err := p.BlockResources(HeavyResources...)
+	assert.NoError(t, err)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.Equal(t, "text", p.MustElement("p").MustText())
+	assert.Equal(t, 0, p.MustEval(`() => document.querySelector('#img').naturalWidth`).Int())
+	requestCountMustBeAsExpected(t, s, 1)
+}
+
+// recycledPage returns a page of a browser with health checks, whose tab has been replaced once it crashed,
+// after calling before on its original tab.
+func recycledPage(t *testing.T, before func(p *Page)) (*Page, *testserver.TestServer) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithHealthCheck(time.Second))
+	assert.NoError(t, err)
+	p := b.GetPage()
+	t.Cleanup(b.CleanUp)
+	before(p)
+	_ = proto.PageCrash{}.Call(p)
+	b.PutPage(p)
+	p = b.GetPage()
+	t.Cleanup(func() { b.PutPage(p) })
+	assert.Equal(t, 1, b.PoolStats().Replaced)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+	return p, s
+}
+
+func Test_Intercept_Fires_After_Page_Is_Recycled(t *testing.T) {
+	p, s := recycledPage(t, func(p *Page) {
+		_, err := p.Intercept("*/other", func(req *InterceptedRequest) {})
+		assert.NoError(t, err)
+	})
+	_, err := p.Intercept("*", func(req *InterceptedRequest) {
+		req.Fulfill(http.StatusOK, http.Header{"Content-Type": {"text/html"}}, []byte(`<p id="mock">mocked</p>`))
+	})
+	assert.NoError(t, err)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.Equal(t, "mocked", p.MustElement("#mock").MustText())
+	requestCountMustBeAsExpected(t, s, 0)
+}
+
+func Test_Intercept_Keeps_Rules_Of_Recycled_Page(t *testing.T) {
+	p, s := recycledPage(t, func(p *Page) {
+		_, err := p.Intercept("*", func(req *InterceptedRequest) { req.SetHeader("X-Test", "intercepted") })
+		assert.NoError(t, err)
+	})
+	p.MustNavigate(s.URL).MustWaitLoad()
+	requests := s.Requests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "intercepted", requests[0].Header.Get("X-Test"))
+	}
+}
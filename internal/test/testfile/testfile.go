@@ -13,6 +13,7 @@ var (
 	InputTestHTML     = readFile(testHTML + "/input-test.html")
 	AlertHTML         = readFile(testHTML + "/alert.html")
 	ClickNavigateHTML = readFile(testHTML + "/click-navigate.html")
+	FormHTML          = readFile(testHTML + "/form.html")
 )
 
 func readFile(path string) []byte {
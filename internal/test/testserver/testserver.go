@@ -47,7 +47,7 @@ func rotate[T any](items ...T) func() T {
 
 // WithRotatingResponses creates a server with rotating response.
 // If no payload is given, the payload will be
-func WithRotatingResponses(t *testing.T, payload ...[]byte) *TestServer {
+func WithRotatingResponses(t testing.TB, payload ...[]byte) *TestServer {
 	var getPayload func() []byte
 	if payload == nil || len(payload) == 0 {
 		getPayload = rotate(testfile.BlankHTML)
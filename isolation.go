@@ -0,0 +1,64 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// WithIsolatedPages backs each page of the pool by its own incognito browser context, instead of the default one,
+// such that cookies, storages and caches are never shared between pages, hence between concurrent jobs.
+// The context of a page is disposed along with the page, and a recycled page gets a fresh context.
+// Note that Duplicate of an isolated page opens the tab in the context of the page, which is disposed together.
+func WithIsolatedPages() BrowserOption {
+	return func(o *browserOptions) {
+		o.isolated = true
+	}
+}
+
+// openTab opens a new tab navigated to the URL with the default viewport, in a new incognito browser context
+// if isolated, whose requests egress through the proxy if any. Empty URL leaves the tab at about:blank.
+func openTab(b *rod.Browser, url string, isolated bool, proxy string) (*rod.Page, error) {
+	if isolated {
+		incognito, err := newContext(b, proxy)
+		if err != nil {
+			return nil, err
+		}
+		b = incognito
+	}
+	page, err := b.Page(proto.TargetCreateTarget{URL: url})
+	if err == nil {
+		metrics := &proto.EmulationSetDeviceMetricsOverride{Width: defaultViewportWidth, Height: defaultViewportHeight}
+		if err = page.SetViewport(metrics); err != nil {
+			_ = page.Close()
+		}
+	}
+	if err != nil {
+		if isolated {
+			_ = b.Close()
+		}
+		return nil, replaceAbortedError(err)
+	}
+	return page, nil
+}
+
+// newContext creates an incognito browser context, whose requests egress through the proxy if any.
+func newContext(b *rod.Browser, proxy string) (*rod.Browser, error) {
+	res, err := proto.TargetCreateBrowserContext{ProxyServer: proxy}.Call(b)
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	incognito := *b
+	incognito.BrowserContextID = res.BrowserContextID
+	return &incognito, nil
+}
+
+// closeTab closes the tab, then disposes its browser context if isolated.
+func closeTab(page *rod.Page, isolated bool) error {
+	err := page.Close()
+	if isolated {
+		if disposeErr := page.Browser().Close(); err == nil {
+			err = disposeErr
+		}
+	}
+	return err
+}
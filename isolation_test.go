@@ -0,0 +1,38 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_WithIsolatedPages_Does_Not_Share_Cookies_Between_Pages(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(2, WithIsolatedPages())
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+
+	first, second := b.GetPage(), b.GetPage()
+	defer b.PutPage(first)
+	defer b.PutPage(second)
+	assert.NotEqual(t, first.Browser().BrowserContextID, second.Browser().BrowserContextID)
+	first.MustNavigate(s.URL).MustWaitLoad()
+	second.MustNavigate(s.URL).MustWaitLoad()
+	assert.NoError(t, first.SetCookies([]*http.Cookie{{Name: "session", Value: "first", Path: "/"}}))
+
+	cookies, err := second.GetCookies()
+	assert.NoError(t, err)
+	assert.Empty(t, cookies)
+
+	context := first.Browser().BrowserContextID
+	assert.NoError(t, b.recyclePage(first))
+	assert.NotEqual(t, context, first.Browser().BrowserContextID)
+	first.MustNavigate(s.URL).MustWaitLoad()
+	cookies, err = first.GetCookies()
+	assert.NoError(t, err)
+	assert.Empty(t, cookies)
+}
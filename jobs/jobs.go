@@ -0,0 +1,191 @@
+// Package jobs runs scrape jobs by a pool of workers, each of which navigates a page of package chromium to the URL
+// of a job and runs its handler, such that a batch of URLs can be scraped by submitting jobs and reading results.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/state303/chromium"
+	"sync"
+	"time"
+)
+
+const (
+	// retryBackoff is the delay before the second attempt of a job, which grows by itself on each attempt.
+	retryBackoff = time.Second
+	// navigateBackoff is the backoff of TryNavigate, which never retries as every loaded page is accepted.
+	navigateBackoff = time.Second
+)
+
+// loaded accepts any page that has been navigated to, leaving failures of navigation to TryNavigate.
+var loaded chromium.Predicate[*chromium.Page] = func(*chromium.Page) bool { return true }
+
+// Job is a URL to scrape, along with the handler that scrapes the page once it is loaded.
+type Job struct {
+	// URL to navigate to before the handler. Empty URL runs the handler against the page as it is drawn from the pool.
+	URL string
+	// Handler scrapes the page, whose value is reported along with its error. Panics are recovered as its error.
+	Handler func(*chromium.Page) (any, error)
+	// Retries is the number of attempts after the first one, while the job fails. Cancellation is never retried.
+	Retries int
+}
+
+// Result is the outcome of a job.
+type Result struct {
+	Job      Job
+	Value    any
+	Err      error
+	Attempts int // number of attempts made, which is zero if the job is drained without running.
+}
+
+// Queue runs jobs by a fixed number of workers, each of which draws a page from the pool of the browser for each attempt.
+// Results are sent to Results in the order that jobs finish, which must be read for workers to proceed.
+type Queue struct {
+	browser *chromium.Browser
+	ctx     context.Context
+	jobs    chan Job
+	results chan Result
+
+	mu      *sync.Mutex
+	closed  bool
+	workers *sync.WaitGroup
+	stop    chan struct{}
+}
+
+// NewQueue returns a queue that holds up to size jobs waiting for the workers, then starts the workers.
+// Once ctx is done, the queue stops accepting jobs as Close does, while jobs in progress end by the cancellation,
+// and jobs left in the queue are drained with the error of ctx. Zero or negative workers or size is treated as 1.
+func NewQueue(ctx context.Context, b *chromium.Browser, workers, size int) *Queue {
+	if workers <= 0 {
+		workers = 1
+	}
+	if size <= 0 {
+		size = 1
+	}
+	q := &Queue{
+		browser: b,
+		ctx:     ctx,
+		jobs:    make(chan Job, size),
+		results: make(chan Result, workers),
+		mu:      &sync.Mutex{},
+		workers: &sync.WaitGroup{},
+		stop:    make(chan struct{}),
+	}
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	go func() {
+		q.workers.Wait()
+		close(q.results)
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			q.Close()
+		case <-q.stop:
+		}
+	}()
+	return q
+}
+
+// Submit queues the job without blocking. It returns chromium.QueueFull if the queue has no room,
+// or chromium.QueueClosed once the queue is closed or its context is done.
+func (q *Queue) Submit(job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.ctx.Err() != nil {
+		return chromium.QueueClosed
+	}
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+		return chromium.QueueFull
+	}
+}
+
+// Results returns the channel of results, which is closed once the queue is closed and every job is done.
+func (q *Queue) Results() <-chan Result {
+	return q.results
+}
+
+// Close stops accepting jobs, letting the workers finish the jobs in the queue. It is safe to call more than once.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+		close(q.stop)
+	}
+}
+
+// work runs jobs of the queue until it is closed and empty, draining jobs with the error of ctx once ctx is done.
+func (q *Queue) work() {
+	defer q.workers.Done()
+	for job := range q.jobs {
+		if err := q.ctx.Err(); err != nil {
+			q.results <- Result{Job: job, Err: err}
+			continue
+		}
+		q.results <- q.run(job)
+	}
+}
+
+// run attempts the job until it succeeds, runs out of retries, or is cancelled.
+func (q *Queue) run(job Job) Result {
+	res := Result{Job: job}
+	for attempt := 0; attempt <= job.Retries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(q.ctx, retryBackoff*time.Duration(attempt)); err != nil {
+				res.Err = err
+				return res
+			}
+		}
+		res.Attempts++
+		res.Value, res.Err = q.attempt(job)
+		if res.Err == nil || q.ctx.Err() != nil || errors.Is(res.Err, context.Canceled) ||
+			errors.Is(res.Err, chromium.BrowserClosed) || errors.Is(res.Err, chromium.DisallowedByRobots) {
+			return res
+		}
+	}
+	return res
+}
+
+// attempt navigates a page of the pool to the URL of the job, then runs its handler, recovering a panic as an error.
+func (q *Queue) attempt(job Job) (value any, err error) {
+	p, err := q.browser.GetPageContext(q.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer q.browser.PutPage(p)
+	if len(job.URL) > 0 {
+		if err = p.TryNavigateContext(q.ctx, job.URL, loaded, navigateBackoff); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		if pe := recover(); pe != nil {
+			if e, ok := pe.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("job panicked: %+v", pe)
+			}
+		}
+	}()
+	return job.Handler(p)
+}
+
+// sleep pauses for the duration, or returns the error of ctx once ctx is done meanwhile.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
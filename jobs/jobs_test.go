@@ -0,0 +1,135 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"github.com/state303/chromium"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// setup returns a new browser, and a server that responds the path of each request as the title.
+func setup(t *testing.T) (*chromium.Browser, *httptest.Server) {
+	t.Parallel()
+	b, err := chromium.NewBrowser(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.CleanUp)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><head><title>" + r.URL.Path + "</title></head><body></body></html>"))
+	}))
+	t.Cleanup(s.Close)
+	return b, s
+}
+
+// title scrapes the title of the page.
+func title(p *chromium.Page) (any, error) {
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+	return info.Title, nil
+}
+
+// collect closes the queue, then returns its results by the URL of their jobs.
+func collect(q *Queue) map[string]Result {
+	q.Close()
+	res := make(map[string]Result)
+	for r := range q.Results() {
+		res[r.Job.URL] = r
+	}
+	return res
+}
+
+func Test_Queue_Runs_Jobs(t *testing.T) {
+	b, s := setup(t)
+	q := NewQueue(context.Background(), b, 2, 3)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		assert.NoError(t, q.Submit(Job{URL: s.URL + path, Handler: title}))
+	}
+	res := collect(q)
+	assert.Len(t, res, 3)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		r := res[s.URL+path]
+		assert.NoError(t, r.Err)
+		assert.Equal(t, path, r.Value)
+		assert.Equal(t, 1, r.Attempts)
+	}
+}
+
+func Test_Queue_Retries_Failed_Job(t *testing.T) {
+	b, s := setup(t)
+	q := NewQueue(context.Background(), b, 1, 1)
+	calls := &atomic.Int32{}
+	assert.NoError(t, q.Submit(Job{URL: s.URL + "/retry", Retries: 2, Handler: func(p *chromium.Page) (any, error) {
+		if calls.Add(1) < 2 {
+			return nil, errors.New("flaky")
+		}
+		return title(p)
+	}}))
+	r := collect(q)[s.URL+"/retry"]
+	assert.NoError(t, r.Err)
+	assert.Equal(t, "/retry", r.Value)
+	assert.Equal(t, 2, r.Attempts)
+}
+
+func Test_Queue_Reports_Panic_As_Error(t *testing.T) {
+	b, s := setup(t)
+	q := NewQueue(context.Background(), b, 1, 1)
+	assert.NoError(t, q.Submit(Job{URL: s.URL + "/panic", Handler: func(*chromium.Page) (any, error) { panic("boom") }}))
+	r := collect(q)[s.URL+"/panic"]
+	assert.ErrorContains(t, r.Err, "job panicked: boom")
+	assert.Equal(t, 1, r.Attempts)
+}
+
+func Test_Queue_Drains_On_Cancel(t *testing.T) {
+	b, s := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	q := NewQueue(ctx, b, 1, 2)
+	started, release := make(chan struct{}), make(chan struct{})
+	assert.NoError(t, q.Submit(Job{URL: s.URL + "/first", Handler: func(p *chromium.Page) (any, error) {
+		close(started)
+		<-release
+		return title(p)
+	}}))
+	assert.NoError(t, q.Submit(Job{URL: s.URL + "/second", Handler: title}))
+	<-started
+	cancel()
+	close(release)
+	res := collect(q)
+	assert.NoError(t, res[s.URL+"/first"].Err)
+	assert.Equal(t, "/first", res[s.URL+"/first"].Value)
+	assert.ErrorIs(t, res[s.URL+"/second"].Err, context.Canceled)
+	assert.Zero(t, res[s.URL+"/second"].Attempts)
+	assert.ErrorIs(t, q.Submit(Job{URL: s.URL + "/third", Handler: title}), chromium.QueueClosed)
+}
+
+func Test_Submit_Returns_QueueFull(t *testing.T) {
+	b, s := setup(t)
+	release := make(chan struct{})
+	q := NewQueue(context.Background(), b, 1, 1)
+	t.Cleanup(func() { close(release); collect(q) })
+	started := make(chan struct{})
+	assert.NoError(t, q.Submit(Job{URL: s.URL + "/block", Handler: func(*chromium.Page) (any, error) {
+		close(started)
+		<-release
+		return nil, nil
+	}}))
+	<-started
+	assert.NoError(t, q.Submit(Job{URL: s.URL + "/queued", Handler: title}))
+	assert.ErrorIs(t, q.Submit(Job{URL: s.URL + "/full", Handler: title}), chromium.QueueFull)
+}
+
+func Test_Submit_After_Close_Returns_QueueClosed(t *testing.T) {
+	b, _ := setup(t)
+	q := NewQueue(context.Background(), b, 1, 1)
+	q.Close()
+	q.Close()
+	assert.ErrorIs(t, q.Submit(Job{Handler: title}), chromium.QueueClosed)
+	_, ok := <-q.Results()
+	assert.False(t, ok)
+}
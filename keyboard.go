@@ -0,0 +1,112 @@
+package chromium
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// PressKeys types the keys one after another, each of which is released before the next is pressed,
+// such as input.Escape to close a modal, or input.Tab then input.Enter to submit a form by keyboard.
+// It returns an error wrapping InputFailed if any of the keys is not defined, or cannot be dispatched.
+func (p *Page) PressKeys(keys ...input.Key) error {
+	return p.PressKeysContext(p.GetContext(), keys...)
+}
+
+// PressKeysContext is PressKeys that also stops once ctx is done.
+func (p *Page) PressKeysContext(ctx context.Context, keys ...input.Key) error {
+	if err := checkKeys(keys); err != nil {
+		return elementError(opKeys, "", err)
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	page := p.Context(ctx)
+	for _, key := range keys {
+		if err := dispatchKeys(page, nil, key); err != nil {
+			return elementError(opKeys, "", classify(InputFailed, err))
+		}
+	}
+	return nil
+}
+
+// TryShortcut waits for an element matching the selector to be visible then focuses it, and presses the keys
+// as a shortcut, where the last one is pressed while the others are held down in order, such as
+// TryShortcut("#editor", input.ControlLeft, input.KeyA). Empty selector presses the shortcut on whatever is focused.
+// Modifiers are released in reverse order afterwards, even if pressing the key fails.
+// It returns an error wrapping InputFailed if any of the keys is not defined, or cannot be dispatched.
+func (p *Page) TryShortcut(selector string, keys ...input.Key) error {
+	return p.TryShortcutContext(p.GetContext(), selector, keys...)
+}
+
+// TryShortcutContext is TryShortcut that also stops once ctx is done.
+func (p *Page) TryShortcutContext(ctx context.Context, selector string, keys ...input.Key) (err error) {
+	if len(keys) == 0 {
+		return elementError(opKeys, selector, fmt.Errorf("%w: no key", InputFailed))
+	} else if err = checkKeys(keys); err != nil {
+		return elementError(opKeys, selector, err)
+	}
+	if len(selector) > 0 {
+		el, err := p.waitVisibleElement(ctx, selector)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := p.timeoutContextOf(ctx)
+		defer cancel()
+		if err = el.Context(ctx).Focus(); err != nil {
+			return elementError(opKeys, selector, classify(InputFailed, err))
+		}
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	if err = dispatchKeys(p.Context(ctx), keys[:len(keys)-1], keys[len(keys)-1]); err != nil {
+		return elementError(opKeys, selector, classify(InputFailed, err))
+	}
+	return nil
+}
+
+// checkKeys returns an error wrapping InputFailed if any of the keys is not defined by package input.
+func checkKeys(keys []input.Key) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", InputFailed, r)
+		}
+	}()
+	for _, key := range keys {
+		key.Info()
+	}
+	return nil
+}
+
+// dispatchKeys presses the modifiers in order, types the key, then releases the modifiers in reverse order.
+// Events are dispatched to the page directly rather than its Keyboard, so that they are bound to the context of the page.
+func dispatchKeys(page *rod.Page, modifiers []input.Key, key input.Key) (err error) {
+	held := 0
+	for i, modifier := range modifiers {
+		if err = modifier.Encode(proto.InputDispatchKeyEventTypeKeyDown, held).Call(page); err != nil {
+			_ = releaseKeys(page, modifiers[:i], held)
+			return err
+		}
+		held |= modifier.Modifier()
+	}
+	if err = key.Encode(proto.InputDispatchKeyEventTypeKeyDown, held).Call(page); err == nil {
+		err = key.Encode(proto.InputDispatchKeyEventTypeKeyUp, held).Call(page)
+	}
+	if releaseErr := releaseKeys(page, modifiers, held); err == nil {
+		err = releaseErr
+	}
+	return err
+}
+
+// releaseKeys releases the modifiers in reverse order, which are held as the bits of held.
+// It releases every modifier regardless of failures, returning the first error if any.
+func releaseKeys(page *rod.Page, modifiers []input.Key, held int) (err error) {
+	for i := len(modifiers) - 1; i >= 0; i-- {
+		held &^= modifiers[i].Modifier()
+		if releaseErr := modifiers[i].Encode(proto.InputDispatchKeyEventTypeKeyUp, held).Call(page); err == nil {
+			err = releaseErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/input"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_CheckKeys_Rejects_Undefined_Key(t *testing.T) {
+	assert.NoError(t, checkKeys([]input.Key{input.ControlLeft, input.KeyA}))
+	assert.ErrorIs(t, checkKeys([]input.Key{input.Key(-1)}), InputFailed)
+}
+
+func Test_TryShortcut_Holds_Modifiers_While_Pressing_Key(t *testing.T) {
+	_, p, s := setup(t, testfile.InputTestHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => {
+		window.keys = [];
+		document.addEventListener('keydown', e => e.key !== 'Control' &&
+			window.keys.push((e.ctrlKey ? 'Control+' : '') + e.key + '@' + document.activeElement.id));
+	}`)
+
+	assert.NoError(t, p.TryShortcut("#item1", input.ControlLeft, input.KeyA))
+	assert.NoError(t, p.PressKeys(input.Escape, input.KeyB))
+	assert.Equal(t, []any{"Control+a@item1", "Escape@item1", "b@item1"}, p.MustEval(`() => window.keys`).Val())
+
+	assert.ErrorIs(t, p.TryShortcut("", input.Key(-1)), InputFailed)
+	assert.ErrorIs(t, p.TryShortcut(""), InputFailed)
+	assert.ErrorIs(t, p.PressKeys(input.Key(-1)), InputFailed)
+}
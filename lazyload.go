@@ -0,0 +1,58 @@
+package chromium
+
+import (
+	"time"
+)
+
+// lazyLoadPause is how long each scroll of ForceLazyLoad lets observers of the page react to it.
+const lazyLoadPause = 100 * time.Millisecond
+
+// forceLazyLoadJS loads lazy images and frames eagerly, then scrolls through the document by the height of the viewport
+// until its end or the deadline, pausing after each scroll, and scrolls back to where it was.
+const forceLazyLoadJS = `async (pause, deadline) => {
+	const sleep = (ms) => new Promise(resolve => setTimeout(resolve, ms));
+	document.querySelectorAll('img[loading="lazy"], iframe[loading="lazy"]').forEach(el => el.loading = 'eager');
+	const root = document.scrollingElement || document.documentElement;
+	const x = window.scrollX, y = window.scrollY;
+	for (let top = 0; top < root.scrollHeight && Date.now() < deadline; top += window.innerHeight) {
+		window.scrollTo(0, top);
+		await sleep(pause);
+	}
+	window.scrollTo(0, root.scrollHeight);
+	await sleep(pause);
+	window.scrollTo(x, y);
+}`
+
+// imagesCompleteJS tells whether every image of the document has been loaded, or has failed to.
+const imagesCompleteJS = `() => Array.from(document.images).every(img => img.complete)`
+
+// ForceLazyLoad loads the images of this page that are loaded lazily, such that full page screenshots or captures of
+// HTML of image heavy pages have all of their images. Lazy images and frames are loaded eagerly, and the document is
+// scrolled through to trigger IntersectionObservers of the page, then it waits for every <img> to complete,
+// polling by the poll interval of this page. The window is scrolled back to where it was afterwards.
+// Images that fail to load are counted as complete, as the browser does.
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping TaskTimeout if the images do not complete in time.
+func (p *Page) ForceLazyLoad(timeout time.Duration) error {
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	deadline := time.Now().Add(time.Hour)
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	}
+	if _, err := page.Eval(forceLazyLoadJS, lazyLoadPause.Milliseconds(), deadline.UnixMilli()); err != nil {
+		return &Error{Op: opLazyLoad, Err: classify(WaitFailed, err)}
+	}
+	_, err := Await(ctx, func() (bool, bool, error) {
+		obj, err := page.Eval(imagesCompleteJS)
+		if err != nil {
+			return false, false, err
+		}
+		return true, obj.Value.Bool(), nil
+	}, p.pollInterval(), 0)
+	if err != nil {
+		return &Error{Op: opLazyLoad, Err: classify(WaitFailed, err)}
+	}
+	return nil
+}
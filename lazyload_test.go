@@ -0,0 +1,67 @@
+package chromium
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// lazyHTML is a page whose images far below are loaded lazily, natively and by an IntersectionObserver.
+var lazyHTML = []byte(`<html><body style="margin: 0">
+<div style="height: 4000px"></div>
+<img id="native" loading="lazy" src="/native.png" width="10" height="10">
+<div style="height: 4000px"></div>
+<img id="observed" data-src="/observed.png" width="10" height="10">
+<script>
+	new IntersectionObserver((entries, observer) => entries.forEach(e => {
+		if (!e.isIntersecting) return;
+		e.target.src = e.target.dataset.src;
+		observer.unobserve(e.target);
+	})).observe(document.getElementById("observed"));
+</script>
+</body></html>`)
+
+// loadedImagesJS tells whether each image of the document has been loaded.
+const loadedImagesJS = `() => Array.from(document.images).map(img => img.complete && img.naturalWidth > 0)`
+
+// lazyPage returns a page of the shared setup that is loaded with lazyHTML, whose images are served slowly.
+func lazyPage(t *testing.T) *Page {
+	_, p, _ := setup(t)
+	img := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(img, image.NewRGBA(image.Rect(0, 0, 10, 10))))
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			_, _ = w.Write(lazyHTML)
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(img.Bytes())
+	}))
+	t.Cleanup(s.Close)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.WaitLoad())
+	return p
+}
+
+func Test_LazyImages_Are_Not_Loaded_Out_Of_View(t *testing.T) {
+	p := lazyPage(t)
+	assert.Equal(t, `[false,false]`, p.MustEval(loadedImagesJS).JSON("", ""))
+}
+
+func Test_ForceLazyLoad_Loads_Lazy_Images(t *testing.T) {
+	p := lazyPage(t)
+	assert.NoError(t, p.ForceLazyLoad(10*time.Second))
+	assert.Equal(t, `[true,true]`, p.MustEval(loadedImagesJS).JSON("", ""))
+}
+
+func Test_ForceLazyLoad_Restores_Scroll_Position(t *testing.T) {
+	p := lazyPage(t)
+	assert.NoError(t, p.ForceLazyLoad(10*time.Second))
+	assert.Equal(t, 0, p.MustEval(`() => window.scrollY`).Int())
+}
@@ -0,0 +1,69 @@
+package chromium
+
+import (
+	"log"
+	"time"
+)
+
+// PageLeak reports a page that has not been put back to the pool within the threshold of leak detection.
+type PageLeak struct {
+	Page  *Page
+	Since time.Time     // time of the checkout.
+	Held  time.Duration // duration the page has been held so far.
+	Stack []byte        // goroutine stack of the borrower at the checkout.
+}
+
+// WithLeakDetection watches pages checked out of the page pool, reporting each page that has not been put back
+// within the threshold once to the handler. If the handler is nil, leaks are written to the standard logger.
+// Note that the stack of the borrower is captured on every checkout while enabled.
+func WithLeakDetection(threshold time.Duration, handler func(leak PageLeak)) BrowserOption {
+	return func(o *browserOptions) {
+		o.leakThreshold = threshold
+		o.onLeak = handler
+	}
+}
+
+// logLeak writes the leak to the standard logger.
+func logLeak(leak PageLeak) {
+	log.Printf("chromium: page has not been put back for %s, checked out by:\n%s", leak.Held, leak.Stack)
+}
+
+// watchLeaks reports leaks to the handler periodically, until the browser begins to shut down.
+func (b *Browser) watchLeaks(threshold time.Duration, handler func(PageLeak)) {
+	if handler == nil && b.logger != nil {
+		handler = func(leak PageLeak) { b.log(LevelWarn, "page leaked", "held", leak.Held, "stack", string(leak.Stack)) }
+	} else if handler == nil {
+		handler = logLeak
+	}
+	interval := threshold / 2
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.closed:
+			return
+		case now := <-ticker.C:
+			for _, leak := range b.findLeaks(now, threshold) {
+				handler(leak)
+			}
+		}
+	}
+}
+
+// findLeaks returns pages held longer than the threshold at the time, which have not been reported yet.
+func (b *Browser) findLeaks(now time.Time, threshold time.Duration) []PageLeak {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	leaks := make([]PageLeak, 0)
+	for p, c := range b.usage.checkouts {
+		if c.reported || now.Sub(c.at) < threshold {
+			continue
+		}
+		c.reported = true
+		leaks = append(leaks, PageLeak{Page: p, Since: c.at, Held: now.Sub(c.at), Stack: c.stack})
+	}
+	return leaks
+}
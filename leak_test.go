@@ -0,0 +1,49 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_findLeaks_Reports_Each_Leak_Once(t *testing.T) {
+	b := newTaskBrowser(2, 1)
+	b.leakThreshold = time.Minute
+	p := b.GetPage()
+	assert.Empty(t, b.findLeaks(time.Now(), time.Minute))
+
+	leaks := b.findLeaks(time.Now().Add(time.Minute), time.Minute)
+	if assert.Len(t, leaks, 1) {
+		assert.Equal(t, p, leaks[0].Page)
+		assert.GreaterOrEqual(t, leaks[0].Held, time.Minute)
+		assert.Contains(t, string(leaks[0].Stack), "Test_findLeaks_Reports_Each_Leak_Once")
+	}
+	assert.Empty(t, b.findLeaks(time.Now().Add(time.Minute), time.Minute))
+}
+
+func Test_watchLeaks_Invokes_Handler_Until_Closed(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	b.leakThreshold = time.Millisecond * 10
+	leaks := make(chan PageLeak, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.watchLeaks(b.leakThreshold, func(leak PageLeak) { leaks <- leak })
+	}()
+	p := b.GetPage()
+	select {
+	case leak := <-leaks:
+		assert.Equal(t, p, leak.Page)
+	case <-time.After(time.Second):
+		t.Fatal("expected leak to be reported")
+	}
+	b.stopServing()
+	<-done
+}
+
+func Test_WithLeakDetection_Sets_Threshold_And_Handler(t *testing.T) {
+	o := &browserOptions{}
+	WithLeakDetection(time.Second, func(PageLeak) {})(o)
+	assert.Equal(t, time.Second, o.leakThreshold)
+	assert.NotNil(t, o.onLeak)
+}
@@ -0,0 +1,42 @@
+package chromium
+
+import (
+	"net/url"
+)
+
+// linksJS returns the resolved URLs of links of the document, in document order.
+const linksJS = `() => Array.from(document.querySelectorAll('a[href], area[href]'), a => a.href)`
+
+// Links returns the absolute URLs of links of the current document of this page, such as of anchors, in document order.
+// Links are resolved against the base URL of the document, and those other than http and https, such as mailto and
+// javascript, are left out. Fragments are dropped, and duplicate URLs are returned once.
+func (p *Page) Links() ([]string, error) {
+	ctx, cancel := p.timeoutContext()
+	defer cancel()
+	var hrefs []string
+	obj, err := p.Context(ctx).Eval(linksJS)
+	if err != nil {
+		return nil, replaceTimeoutError(replaceAbortedError(err))
+	} else if err = decodeJSON(obj, &hrefs); err != nil {
+		return nil, err
+	}
+	return normalizeLinks(hrefs), nil
+}
+
+// normalizeLinks drops fragments of the links, then returns http and https links once each, in order.
+func normalizeLinks(hrefs []string) []string {
+	links, seen := make([]string, 0, len(hrefs)), make(map[string]bool, len(hrefs))
+	for _, href := range hrefs {
+		u, err := url.Parse(href)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		u.Fragment, u.RawFragment = "", ""
+		link := u.String()
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
@@ -0,0 +1,28 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_normalizeLinks_Drops_Fragments_And_Duplicates(t *testing.T) {
+	links := normalizeLinks([]string{
+		"https://example.com/a#top",
+		"https://example.com/a",
+		"mailto:someone@example.com",
+		"javascript:void(0)",
+		"http://example.com/b?q=1",
+	})
+	assert.Equal(t, []string{"https://example.com/a", "http://example.com/b?q=1"}, links)
+}
+
+func Test_Links_Resolves_Relative_Links(t *testing.T) {
+	_, p, s := setup(t, []byte(`<html><body>
+<a href="/a">a</a><a href="b#part">b</a><a href="mailto:someone@example.com">mail</a><a href="/a#again">a</a>
+</body></html>`))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	links, err := p.Links()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{s.URL + "/a", s.URL + "/b"}, links)
+}
@@ -0,0 +1,91 @@
+package chromium
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Level is the severity of an event logged to Logger.
+type Level int
+
+const (
+	LevelDebug Level = iota // retries and routine steps of the pool.
+	LevelInfo               // navigations, dialogs and the lifecycle of the browser.
+	LevelWarn               // failures that are recovered from, such as failed navigations and recycled pages.
+	LevelError              // failures that are not recovered from, such as disconnection.
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// Logger receives structured events of a browser and its pages, where keysAndValues alternate keys of string and their
+// values, as logr and slog take them, such that any structured logger can be adapted. Log is called synchronously by
+// the goroutine that makes the event, hence it must be safe for concurrent use and return quickly.
+type Logger interface {
+	Log(level Level, msg string, keysAndValues ...any)
+}
+
+// LoggerFunc adapts a function to Logger.
+type LoggerFunc func(level Level, msg string, keysAndValues ...any)
+
+func (f LoggerFunc) Log(level Level, msg string, keysAndValues ...any) {
+	f(level, msg, keysAndValues...)
+}
+
+// NewStdLogger returns a Logger that writes events at the level or above to the standard logger l, as a line of
+// the level, the message and key=value pairs. Nil l writes to the standard logger of package log.
+func NewStdLogger(l *log.Logger, level Level) Logger {
+	if l == nil {
+		l = log.Default()
+	}
+	return LoggerFunc(func(lv Level, msg string, keysAndValues ...any) {
+		if lv < level {
+			return
+		}
+		b := &strings.Builder{}
+		fmt.Fprintf(b, "chromium: %s %s", lv, msg)
+		for i := 0; i < len(keysAndValues); i += 2 {
+			if i+1 < len(keysAndValues) {
+				fmt.Fprintf(b, " %v=%q", keysAndValues[i], fmt.Sprint(keysAndValues[i+1]))
+			} else {
+				fmt.Fprintf(b, " %q", fmt.Sprint(keysAndValues[i]))
+			}
+		}
+		l.Print(b.String())
+	})
+}
+
+// WithLogger logs navigations, retries, dialogs, recycled pages, disconnection and cleanup of the browser and its
+// pages to the logger, including pages out of the pool. Leaks found by WithLeakDetection without a handler are
+// logged to the logger as well, instead of the standard logger.
+func WithLogger(logger Logger) BrowserOption {
+	return func(o *browserOptions) {
+		o.logger = logger
+	}
+}
+
+// log logs the event to the logger of this browser, if any.
+func (b *Browser) log(level Level, msg string, keysAndValues ...any) {
+	if b.logger != nil {
+		b.logger.Log(level, msg, keysAndValues...)
+	}
+}
+
+// log logs the event to the logger of this page, if any.
+func (p *Page) log(level Level, msg string, keysAndValues ...any) {
+	if p.logger != nil {
+		p.logger.Log(level, msg, keysAndValues...)
+	}
+}
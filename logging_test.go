@@ -0,0 +1,90 @@
+package chromium
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+// logEvent is an event logged to recordingLogger.
+type logEvent struct {
+	level Level
+	msg   string
+	kv    []any
+}
+
+// recordingLogger records events logged to it.
+type recordingLogger struct {
+	mu     sync.Mutex
+	events []logEvent
+}
+
+func (l *recordingLogger) Log(level Level, msg string, keysAndValues ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, logEvent{level: level, msg: msg, kv: keysAndValues})
+}
+
+// messages returns messages of the events at the level.
+func (l *recordingLogger) messages(level Level) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	msgs := make([]string, 0)
+	for _, e := range l.events {
+		if e.level == level {
+			msgs = append(msgs, e.msg)
+		}
+	}
+	return msgs
+}
+
+func Test_Level_String(t *testing.T) {
+	assert.Equal(t, "DEBUG", LevelDebug.String())
+	assert.Equal(t, "ERROR", LevelError.String())
+	assert.Equal(t, "LEVEL(9)", Level(9).String())
+}
+
+func Test_NewStdLogger_Writes_Key_Values_Above_Level(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewStdLogger(log.New(buf, "", 0), LevelInfo)
+	logger.Log(LevelDebug, "ignored", "k", "v")
+	logger.Log(LevelWarn, "navigation failed", "url", "https://example.com/", "error", errors.New("net::ERR_FAILED"), "odd")
+	assert.Equal(t, "chromium: WARN navigation failed url=\"https://example.com/\" error=\"net::ERR_FAILED\" \"odd\"\n", buf.String())
+}
+
+func Test_Retry_And_SaveDialog_Log_Events(t *testing.T) {
+	logger := &recordingLogger{}
+	p := detachedPage(context.Background())
+	p.logger = logger
+	_ = Retry(p, RetryPolicy{MaxAttempts: 2}, func(*Page) error { return TaskTimeout })
+	p.SaveDialog(&proto.PageJavascriptDialogOpening{Type: proto.PageDialogTypeAlert, Message: "hello"})
+
+	assert.Equal(t, []string{"retrying"}, logger.messages(LevelDebug))
+	assert.Equal(t, []any{"op", opRetry, "attempt", 1, "error", TaskTimeout}, logger.events[0].kv)
+	assert.Equal(t, []string{"dialog opened"}, logger.messages(LevelInfo))
+}
+
+func Test_WithLogger_Logs_Navigation_And_CleanUp(t *testing.T) {
+	t.Parallel()
+	logger := &recordingLogger{}
+	b, err := NewBrowser(1, WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+
+	p := b.GetPage()
+	assert.NoError(t, p.TryNavigate(s.URL, func(*Page) bool { return true }, time.Millisecond))
+	b.PutPage(p)
+	b.CleanUp()
+	assert.Equal(t, []string{"navigated", "cleaning up browser", "browser cleaned up"}, logger.messages(LevelInfo))
+}
@@ -0,0 +1,14 @@
+package chromium
+
+// WithFakeMedia replaces cameras and microphones of the browser by fake devices, and accepts prompts of media
+// permissions by itself, such that video calls and selfie verifications can be automated in tests.
+// The fake camera streams the video file, which is either Y4M or MJPEG such as "testdata/face.y4m", looping over it,
+// or a generated test pattern if the file is empty. The fake microphone streams a beep.
+// The flags are applied to the browser launched by NewBrowser or NewBrowserWithProxy,
+// thus NewBrowserFromControlURL returns an error with them. Launching fails if the video file does not exist.
+func WithFakeMedia(videoFile string) BrowserOption {
+	return func(o *browserOptions) {
+		o.fakeMedia = true
+		o.fakeVideo = videoFile
+	}
+}
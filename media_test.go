@@ -0,0 +1,50 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_browserOptions_setFlags_Sets_Fake_Media(t *testing.T) {
+	video := filepath.Join(t.TempDir(), "face.y4m")
+	assert.NoError(t, os.WriteFile(video, []byte("YUV4MPEG2"), 0o600))
+	o := &browserOptions{}
+	WithFakeMedia(video)(o)
+	l := launcher.New()
+	assert.NoError(t, o.setFlags(l))
+	assert.True(t, l.Has("use-fake-device-for-media-stream"))
+	assert.True(t, l.Has("use-fake-ui-for-media-stream"))
+	assert.Equal(t, video, l.Get("use-file-for-fake-video-capture"))
+	assert.Equal(t, "WithFakeMedia", o.launchOption())
+
+	WithFakeMedia(filepath.Join(t.TempDir(), "missing.y4m"))(o)
+	assert.Error(t, o.setFlags(launcher.New()))
+}
+
+func Test_NewBrowserFromControlURL_Rejects_Launch_Options(t *testing.T) {
+	_, err := NewBrowserFromControlURL("ws://127.0.0.1:0", 1, WithFakeMedia(""))
+	assert.ErrorContains(t, err, "WithFakeMedia")
+	_, err = NewBrowserFromControlURL("ws://127.0.0.1:0", 1, WithHostRules(map[string]string{"a.test": "127.0.0.1"}))
+	assert.ErrorContains(t, err, "WithHostRules")
+}
+
+func Test_WithFakeMedia_Provides_Camera_And_Microphone(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithFakeMedia(""))
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+	p := b.GetPage()
+	defer b.PutPage(p)
+	assert.NoError(t, p.Navigate(s.URL))
+
+	tracks := p.MustEval(`() => navigator.mediaDevices.getUserMedia({video: true, audio: true})
+		.then(stream => stream.getTracks().map(track => track.kind).sort())`)
+	assert.Equal(t, `["audio","video"]`, tracks.JSON("", ""))
+}
@@ -0,0 +1,288 @@
+package chromium
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/go-rod/rod/lib/proto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PageMemory is the memory usage of an idle page in the pool.
+type PageMemory struct {
+	TargetID proto.TargetTargetID
+	HeapUsed uint64 // bytes of JavaScript heap in use.
+	Recycled bool   // true if the page has been recycled by this sample.
+}
+
+// MemorySample is a single sample of memory usage of the browser.
+type MemorySample struct {
+	Time     time.Time
+	Pages    []PageMemory // idle pages at the time of sampling, heaviest first.
+	TotalRSS uint64       // bytes of resident memory of the browser processes, or zero if unavailable.
+	Err      error        // the first error while sampling or recycling, if any.
+}
+
+// MemoryOptions configures MonitorMemory.
+type MemoryOptions struct {
+	// Interval between samples, which defaults to 30 seconds.
+	Interval time.Duration
+	// PageLimit recycles every idle page whose JavaScript heap exceeds it. Zero disables the limit.
+	PageLimit uint64
+	// TotalLimit recycles the heaviest idle page on each sample while the resident memory of the browser processes
+	// exceeds it. Zero disables the limit. Resident memory is only available for browsers launched on Linux.
+	TotalLimit uint64
+	// OnSample receives every sample, such that it can be exported as metrics.
+	OnSample func(MemorySample)
+}
+
+// MonitorMemory samples memory usage of idle pages and the browser by the interval, and recycles idle pages
+// by the limits of the options. Pages that are checked out are never touched.
+// A recycled page is replaced by a blank tab with the same pool slot, which drops its history and dialogs.
+// It returns a function that stops the monitor and waits for the ongoing sample to finish.
+func (b *Browser) MonitorMemory(opts MemoryOptions) (stop func()) {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	done, stopped, once := make(chan struct{}), make(chan struct{}), &sync.Once{}
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-b.closed:
+				return
+			case <-ticker.C:
+				sample := b.sampleMemory(opts)
+				if opts.OnSample != nil {
+					opts.OnSample(sample)
+				}
+			}
+		}
+	}()
+	return func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}
+}
+
+// sampleMemory measures idle pages one at a time and recycles them by the limits.
+// Each page is taken out of the pool only while it is measured or recycled, such that the others stay available.
+func (b *Browser) sampleMemory(opts MemoryOptions) MemorySample {
+	sample := MemorySample{Time: time.Now()}
+	fail := func(err error) {
+		if sample.Err == nil {
+			sample.Err = err
+		}
+	}
+	seen := make(map[proto.TargetTargetID]bool)
+	for n := len(b.pagePool); n > 0; n-- {
+		p, ok := b.pagePool.TryGet()
+		if !ok {
+			break
+		}
+		if seen[p.TargetID] {
+			b.pagePool.Put(p) // every idle page has been measured once.
+			break
+		}
+		seen[p.TargetID] = true
+		usage, err := proto.RuntimeGetHeapUsage{}.Call(p)
+		b.pagePool.Put(p) // idle pages need no reset.
+		if err != nil {
+			fail(replaceAbortedError(err))
+			continue
+		}
+		sample.Pages = append(sample.Pages, PageMemory{TargetID: p.TargetID, HeapUsed: uint64(usage.UsedSize)})
+	}
+	sort.SliceStable(sample.Pages, func(i, j int) bool { return sample.Pages[i].HeapUsed > sample.Pages[j].HeapUsed })
+
+	if b.launcher != nil {
+		rss, err := processTreeRSS(procDir, b.launcher.PID())
+		if err != nil && opts.TotalLimit > 0 {
+			fail(err)
+		}
+		sample.TotalRSS = rss
+	}
+
+	for _, i := range recycleTargets(sample, opts) {
+		p, ok := b.takeIdlePage(sample.Pages[i].TargetID)
+		if !ok {
+			continue // checked out since measured, which is never touched.
+		}
+		err := b.recyclePage(p)
+		b.pagePool.Put(p)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		sample.Pages[i].Recycled = true
+	}
+	return sample
+}
+
+// recycleTargets returns indices of pages in the sample to be recycled by the limits.
+// Pages of the sample must be ordered heaviest first.
+func recycleTargets(sample MemorySample, opts MemoryOptions) []int {
+	targets := make([]int, 0)
+	for i, page := range sample.Pages {
+		if opts.PageLimit > 0 && page.HeapUsed > opts.PageLimit {
+			targets = append(targets, i)
+		}
+	}
+	if opts.TotalLimit > 0 && sample.TotalRSS > opts.TotalLimit && len(targets) == 0 && len(sample.Pages) > 0 {
+		targets = append(targets, 0)
+	}
+	return targets
+}
+
+// takeIdlePage takes the idle page of the target out of the pool without blocking,
+// putting back every other page as soon as it is taken. It reports false if the page is not idle.
+func (b *Browser) takeIdlePage(id proto.TargetTargetID) (*Page, bool) {
+	for n := len(b.pagePool); n > 0; n-- {
+		p, ok := b.pagePool.TryGet()
+		if !ok {
+			return nil, false
+		}
+		if p.TargetID == id {
+			return p, true
+		}
+		b.pagePool.Put(p)
+	}
+	return nil, false
+}
+
+// recyclePage replaces the tab of the page by a new blank tab, then closes the old one.
+// The page keeps its pool slot, default timeout, init scripts, extra headers, rules of Intercept, functions of ExposeFunc,
+// bypass of service workers, CPU throttling, offline state and credentials of EnableAuth, but loses its history and
+// dialogs.
+// A network recording in progress is stopped, keeping what has been recorded, while a capture of console is dropped.
+// An isolated page gets a fresh browser context as well, losing its cookies and storages, but keeping its proxy.
+// The page must not be in use by anyone else.
+func (b *Browser) recyclePage(p *Page) error {
+	page, err := openTab(b.Browser, blankURL, p.isolated, p.proxy)
+	if err != nil {
+		return err
+	}
+	if err = p.restoreInitScripts(page); err == nil {
+		err = p.restoreExtraHeaders(page)
+	}
+	if err == nil {
+		err = p.restoreStealth(page)
+	}
+	if err == nil {
+		err = p.restoreServiceWorkers(page)
+	}
+	if err == nil {
+		err = p.restoreEmulation(page)
+	}
+	if err == nil {
+		err = p.restoreExposed(page)
+	}
+	if err == nil {
+		err = p.restoreInterceptor(page)
+	}
+	if err == nil {
+		err = p.restoreAuth(page)
+	}
+	if err != nil {
+		_ = closeTab(page, p.isolated)
+		return err
+	}
+	_ = p.StopNetworkRecording() // the recording is bound to the old tab, thus a later StartNetworkRecording records the new one.
+	p.detachConsole()
+	p.mu.Lock()
+	old := p.Page
+	p.Page = page
+	p.cursor = proto.Point{}
+	p.mu.Unlock()
+	p.ClearDialogs()
+	b.setPooled(page.TargetID, true)
+	b.setPooled(old.TargetID, false)
+	p.authenticator().moveServer(old.FrameID, page.FrameID)
+	_ = closeTab(old, p.isolated)
+	if b.collector != nil {
+		b.collector.PageRecycled()
+	}
+	b.log(LevelWarn, "page recycled", "target", page.TargetID)
+	return nil
+}
+
+// procDir is the mount point of procfs.
+var procDir = "/proc"
+
+// processTreeRSS returns the sum of resident memory of the process and all of its descendants, by reading procfs.
+func processTreeRSS(proc string, root int) (uint64, error) {
+	stats, err := filepath.Glob(filepath.Join(proc, "[0-9]*", "stat"))
+	if err != nil {
+		return 0, err
+	}
+	children := make(map[int][]int)
+	for _, stat := range stats {
+		pid, ppid, err := readParent(stat)
+		if err != nil {
+			continue // the process may have exited meanwhile.
+		}
+		children[ppid] = append(children[ppid], pid)
+	}
+	var total uint64
+	queue := []int{root}
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = append(queue[1:], children[pid]...)
+		rss, err := readRSS(filepath.Join(proc, strconv.Itoa(pid), "status"))
+		if err != nil {
+			if pid == root {
+				return 0, err
+			}
+			continue
+		}
+		total += rss
+	}
+	return total, nil
+}
+
+// readParent reads the pid and the parent pid from the stat file of a process.
+func readParent(stat string) (pid, ppid int, err error) {
+	data, err := os.ReadFile(stat)
+	if err != nil {
+		return 0, 0, err
+	}
+	// the command name is in parentheses, which may contain spaces, thus fields are read after the last parenthesis.
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return 0, 0, fmt.Errorf("malformed stat: %s", stat)
+	}
+	if pid, err = strconv.Atoi(string(bytes.TrimSpace(data[:bytes.IndexByte(data, '(')]))); err != nil {
+		return 0, 0, err
+	}
+	var state string
+	if _, err = fmt.Sscan(string(data[end+1:]), &state, &ppid); err != nil {
+		return 0, 0, err
+	}
+	return pid, ppid, nil
+}
+
+// readRSS reads the resident memory in bytes from the status file of a process.
+func readRSS(status string) (uint64, error) {
+	f, err := os.Open(status)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var kb uint64
+		if _, err := fmt.Sscanf(scanner.Text(), "VmRSS: %d kB", &kb); err == nil {
+			return kb * 1024, nil
+		}
+	}
+	return 0, scanner.Err()
+}
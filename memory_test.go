@@ -0,0 +1,82 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_recycleTargets_Selects_Pages_Over_PageLimit(t *testing.T) {
+	sample := MemorySample{Pages: []PageMemory{{HeapUsed: 300}, {HeapUsed: 200}, {HeapUsed: 100}}}
+	assert.Equal(t, []int{0, 1}, recycleTargets(sample, MemoryOptions{PageLimit: 150}))
+	assert.Empty(t, recycleTargets(sample, MemoryOptions{}))
+}
+
+func Test_recycleTargets_Selects_Heaviest_Page_When_Total_Exceeded(t *testing.T) {
+	sample := MemorySample{Pages: []PageMemory{{HeapUsed: 300}, {HeapUsed: 200}}, TotalRSS: 1000}
+	assert.Equal(t, []int{0}, recycleTargets(sample, MemoryOptions{TotalLimit: 500}))
+	assert.Empty(t, recycleTargets(sample, MemoryOptions{TotalLimit: 5000}))
+	assert.Empty(t, recycleTargets(MemorySample{TotalRSS: 1000}, MemoryOptions{TotalLimit: 500}))
+}
+
+func writeProc(t *testing.T, dir string, pid, ppid int, comm string, rssKB int) {
+	base := filepath.Join(dir, strconv.Itoa(pid))
+	assert.NoError(t, os.MkdirAll(base, 0o755))
+	stat := strconv.Itoa(pid) + " (" + comm + ") S " + strconv.Itoa(ppid) + " 1 1 0"
+	assert.NoError(t, os.WriteFile(filepath.Join(base, "stat"), []byte(stat), 0o644))
+	status := "Name:\t" + comm + "\nVmRSS:\t    " + strconv.Itoa(rssKB) + " kB\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(base, "status"), []byte(status), 0o644))
+}
+
+func Test_processTreeRSS_Sums_Descendants(t *testing.T) {
+	dir := t.TempDir()
+	writeProc(t, dir, 100, 1, "chrome", 10)
+	writeProc(t, dir, 101, 100, "chrome (renderer)", 20)
+	writeProc(t, dir, 102, 101, "chrome", 30)
+	writeProc(t, dir, 200, 1, "other", 1000)
+	rss, err := processTreeRSS(dir, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(60*1024), rss)
+}
+
+func Test_processTreeRSS_Returns_Err_When_Root_Missing(t *testing.T) {
+	_, err := processTreeRSS(t.TempDir(), 100)
+	assert.Error(t, err)
+}
+
+func Test_MonitorMemory_Emits_Samples_Until_Stopped(t *testing.T) {
+	b := newTaskBrowser(0, 1)
+	samples := make(chan MemorySample, 16)
+	stop := b.MonitorMemory(MemoryOptions{Interval: time.Millisecond * 5, OnSample: func(s MemorySample) { samples <- s }})
+	select {
+	case s := <-samples:
+		assert.NoError(t, s.Err)
+		assert.Empty(t, s.Pages)
+	case <-time.After(time.Second):
+		t.Fatal("expected a sample")
+	}
+	stop()
+	stop()
+}
+
+func Test_takeIdlePage_Leaves_Other_Pages_In_Pool(t *testing.T) {
+	b := newTaskBrowser(3, 0)
+	ids := []proto.TargetTargetID{"a", "b", "c"}
+	for _, id := range ids {
+		p := <-b.pagePool
+		p.TargetID = id
+		b.pagePool <- p
+	}
+	p, ok := b.takeIdlePage("b")
+	assert.True(t, ok)
+	assert.Equal(t, proto.TargetTargetID("b"), p.TargetID)
+	assert.Len(t, b.pagePool, 2)
+
+	_, ok = b.takeIdlePage("z")
+	assert.False(t, ok)
+	assert.Len(t, b.pagePool, 2)
+}
@@ -0,0 +1,60 @@
+package chromium
+
+import (
+	"time"
+)
+
+// Collector receives metrics of a browser and its pages, such that production scrapers can be monitored, as the metrics
+// subpackage exports them to Prometheus. Methods are called synchronously by the goroutine that makes the change,
+// hence they must be safe for concurrent use and return quickly.
+type Collector interface {
+	// PoolChanged is called with the statistics of the page pool after a page is acquired or put back.
+	PoolChanged(stats PoolStats)
+	// Navigated is called after each navigation with its host and latency, with its error if failed.
+	Navigated(host string, latency time.Duration, err error)
+	// Retried is called before the wait for each retry of the operation, such as navigate by TryNavigate or retry by Retry.
+	Retried(op string)
+	// DialogOpened is called for each dialog saved to the history of a page, with its type such as alert.
+	DialogOpened(dialogType string)
+	// PageRecycled is called after a tab of the pool is replaced by a fresh one, such as once its renderer crashed.
+	PageRecycled()
+}
+
+// opRetry is the operation of Retry, as reported to Collector.
+const opRetry = "retry"
+
+// WithCollector reports metrics of the browser and its pages to the collector, including pages out of the pool.
+func WithCollector(c Collector) BrowserOption {
+	return func(o *browserOptions) {
+		o.collector = c
+	}
+}
+
+// poolChanged reports the statistics of the page pool to the collector of this browser, if any.
+func (b *Browser) poolChanged() {
+	if b.collector != nil {
+		b.collector.PoolChanged(b.PoolStats())
+	}
+}
+
+// navigated reports a navigation of this page to the collector and the logger, if any.
+func (p *Page) navigated(rawURL string, begin time.Time, err error) {
+	latency := time.Since(begin)
+	if p.collector != nil {
+		p.collector.Navigated(hostOf(rawURL), latency, err)
+	}
+	if err != nil {
+		p.log(LevelWarn, "navigation failed", "url", rawURL, "latency", latency, "error", err)
+	} else {
+		p.log(LevelInfo, "navigated", "url", rawURL, "latency", latency)
+	}
+}
+
+// retried reports a retry of the operation on this page after the attempt failed by the error, to the collector and
+// the logger, if any.
+func (p *Page) retried(op string, attempt int, err error) {
+	if p.collector != nil {
+		p.collector.Retried(op)
+	}
+	p.log(LevelDebug, "retrying", "op", op, "attempt", attempt, "error", err)
+}
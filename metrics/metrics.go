@@ -0,0 +1,105 @@
+// Package metrics exports metrics of browsers of package chromium to Prometheus, by a chromium.Collector that is given
+// to a browser on its initialization, such as:
+//
+//	c := metrics.New(prometheus.DefaultRegisterer, "scraper")
+//	b, err := chromium.NewBrowser(4, chromium.WithCollector(c))
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/state303/chromium"
+	"time"
+)
+
+const (
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+// Collector is a chromium.Collector that exports metrics to Prometheus, which are named under the namespace:
+//
+//   - pool_pages: gauge of pages of the pool by state, which is in_use or idle.
+//   - pool_waiting: gauge of callers blocked until a page of the pool is available.
+//   - navigation_duration_seconds: histogram of latencies of navigations by result, which is ok or error.
+//   - retries_total: counter of retries by operation, such as navigate.
+//   - dialogs_total: counter of dialogs by type, such as alert.
+//   - page_recycles_total: counter of tabs of the pool replaced by fresh ones, such as once their renderer crashed.
+//
+// Hosts are left out of labels, as a crawl may reach unbounded number of them.
+// A Collector may be shared by browsers, whose pools are then reported as a whole by the latest change of any of them.
+type Collector struct {
+	pages      *prometheus.GaugeVec
+	waiting    prometheus.Gauge
+	navigation *prometheus.HistogramVec
+	retries    *prometheus.CounterVec
+	dialogs    *prometheus.CounterVec
+	recycles   prometheus.Counter
+}
+
+// New returns a Collector whose metrics are registered to the registerer, which panics if any of them is already
+// registered, as promauto does. Nil registerer leaves the metrics unregistered.
+func New(reg prometheus.Registerer, namespace string) *Collector {
+	factory := promauto.With(reg)
+	return &Collector{
+		pages: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pool_pages",
+			Help:      "Number of pages of the page pool by state.",
+		}, []string{"state"}),
+		waiting: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pool_waiting",
+			Help:      "Number of callers waiting for a page of the page pool.",
+		}),
+		navigation: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "navigation_duration_seconds",
+			Help:      "Latency of navigations by result.",
+			Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"result"}),
+		retries: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of retries by operation.",
+		}, []string{"op"}),
+		dialogs: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dialogs_total",
+			Help:      "Number of dialogs opened by type.",
+		}, []string{"type"}),
+		recycles: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "page_recycles_total",
+			Help:      "Number of tabs of the page pool replaced by fresh ones.",
+		}),
+	}
+}
+
+var _ chromium.Collector = (*Collector)(nil)
+
+func (c *Collector) PoolChanged(stats chromium.PoolStats) {
+	c.pages.WithLabelValues("in_use").Set(float64(stats.InUse))
+	c.pages.WithLabelValues("idle").Set(float64(stats.Idle))
+	c.waiting.Set(float64(stats.Waiting))
+}
+
+func (c *Collector) Navigated(_ string, latency time.Duration, err error) {
+	result := resultOK
+	if err != nil {
+		result = resultError
+	}
+	c.navigation.WithLabelValues(result).Observe(latency.Seconds())
+}
+
+func (c *Collector) Retried(op string) {
+	c.retries.WithLabelValues(op).Inc()
+}
+
+func (c *Collector) DialogOpened(dialogType string) {
+	c.dialogs.WithLabelValues(dialogType).Inc()
+}
+
+func (c *Collector) PageRecycled() {
+	c.recycles.Inc()
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/state303/chromium"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_Collector_Exports_Metrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c := New(reg, "test")
+	c.PoolChanged(chromium.PoolStats{InUse: 2, Idle: 1, Waiting: 3})
+	c.Navigated("example.com", time.Second, nil)
+	c.Navigated("example.com", time.Second, errors.New("failed"))
+	c.Navigated("example.com", time.Second, errors.New("failed"))
+	c.Retried(chromium.ActionNavigate)
+	c.DialogOpened("alert")
+	c.PageRecycled()
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(c.pages.WithLabelValues("in_use")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.pages.WithLabelValues("idle")))
+	assert.Equal(t, 3.0, testutil.ToFloat64(c.waiting))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.retries.WithLabelValues(chromium.ActionNavigate)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.dialogs.WithLabelValues("alert")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.recycles))
+	assert.Equal(t, 1, testutil.CollectAndCount(c.navigation.WithLabelValues(resultOK).(prometheus.Histogram)))
+
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+	names := make([]string, 0, len(families))
+	for _, f := range families {
+		names = append(names, f.GetName())
+		if f.GetName() == "test_navigation_duration_seconds" {
+			for _, m := range f.GetMetric() {
+				if m.GetLabel()[0].GetValue() == resultError {
+					assert.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+				}
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"test_pool_pages", "test_pool_waiting", "test_navigation_duration_seconds",
+		"test_retries_total", "test_dialogs_total", "test_page_recycles_total"}, names)
+}
+
+func Test_New_Panics_On_Duplicate_Registration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	New(reg, "test")
+	assert.Panics(t, func() { New(reg, "test") })
+	assert.NotPanics(t, func() { New(nil, "test") })
+}
+
+func Test_Browser_Reports_To_Collector(t *testing.T) {
+	t.Parallel()
+	c := New(prometheus.NewRegistry(), "test")
+	b, err := chromium.NewBrowser(1, chromium.WithCollector(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.CleanUp)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body></body></html>"))
+	}))
+	t.Cleanup(s.Close)
+
+	p := b.GetPage()
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.pages.WithLabelValues("in_use")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(c.pages.WithLabelValues("idle")))
+	assert.NoError(t, p.TryNavigate(s.URL, func(*chromium.Page) bool { return true }, time.Millisecond))
+	b.PutPage(p)
+	assert.Equal(t, 0.0, testutil.ToFloat64(c.pages.WithLabelValues("in_use")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.pages.WithLabelValues("idle")))
+	assert.Equal(t, 1, testutil.CollectAndCount(c.navigation))
+}
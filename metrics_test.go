@@ -0,0 +1,95 @@
+package chromium
+
+import (
+	"context"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingCollector records what is reported to it.
+type recordingCollector struct {
+	mu       sync.Mutex
+	stats    []PoolStats
+	hosts    []string
+	retries  []string
+	dialogs  []string
+	recycles int
+}
+
+func (c *recordingCollector) PoolChanged(stats PoolStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = append(c.stats, stats)
+}
+
+func (c *recordingCollector) Navigated(host string, _ time.Duration, _ error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hosts = append(c.hosts, host)
+}
+
+func (c *recordingCollector) Retried(op string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retries = append(c.retries, op)
+}
+
+func (c *recordingCollector) DialogOpened(dialogType string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dialogs = append(c.dialogs, dialogType)
+}
+
+func (c *recordingCollector) PageRecycled() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recycles++
+}
+
+func Test_SaveDialog_Reports_To_Collector(t *testing.T) {
+	c := &recordingCollector{}
+	p := newPage(nil, func() {})
+	p.collector = c
+	p.SaveDialog(&proto.PageJavascriptDialogOpening{Type: proto.PageDialogTypeConfirm})
+	assert.Equal(t, []string{"confirm"}, c.dialogs)
+}
+
+func Test_Retry_Reports_To_Collector(t *testing.T) {
+	c := &recordingCollector{}
+	p := detachedPage(context.Background())
+	p.collector = c
+	err := Retry(p, RetryPolicy{MaxAttempts: 3}, func(*Page) error { return TaskTimeout })
+	assert.ErrorIs(t, err, TaskTimeout)
+	assert.Equal(t, []string{opRetry, opRetry}, c.retries)
+}
+
+func Test_WithCollector_Reports_Pool_And_Navigation(t *testing.T) {
+	t.Parallel()
+	c := &recordingCollector{}
+	b, err := NewBrowser(1, WithCollector(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.CleanUp)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+
+	p := b.GetPage()
+	attempts := 0
+	assert.NoError(t, p.TryNavigate(s.URL, func(*Page) bool { attempts++; return attempts > 1 }, time.Millisecond))
+	b.PutPage(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.Equal(t, []PoolStats{{InUse: 1, Acquisitions: 1}, {Idle: 1, Acquisitions: 1}}, []PoolStats{
+		{InUse: c.stats[0].InUse, Idle: c.stats[0].Idle, Acquisitions: c.stats[0].Acquisitions},
+		{InUse: c.stats[1].InUse, Idle: c.stats[1].Idle, Acquisitions: c.stats[1].Acquisitions},
+	})
+	assert.Equal(t, []string{hostOf(s.URL), hostOf(s.URL)}, c.hosts)
+	assert.Equal(t, []string{ActionNavigate}, c.retries)
+}
@@ -0,0 +1,131 @@
+package chromium
+
+import (
+	"context"
+	"github.com/go-rod/rod/lib/proto"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NavResult is what a navigation has ended up with, as of the responses to the document of the main frame.
+type NavResult struct {
+	URL        string      // final URL after redirects.
+	Status     int         // HTTP status of the final response.
+	StatusText string      // HTTP status text of the final response, which may be empty for HTTP/2.
+	Header     http.Header // headers of the final response.
+	Redirects  []Redirect  // redirects that the navigation has followed, in order.
+}
+
+// Redirect is a response that redirected a navigation.
+type Redirect struct {
+	URL      string // URL that has redirected.
+	Status   int    // HTTP status of the redirect, such as 301 or 302.
+	Location string // URL that it has redirected to.
+}
+
+// navCapture gathers responses to the document request of the main frame of a page.
+type navCapture struct {
+	frame proto.PageFrameID
+	mu    *sync.Mutex
+	id    proto.NetworkRequestID // request of the document, once it is sent.
+	res   *NavResult
+	done  chan struct{}
+}
+
+// NavigateResult navigates this page to the URL as TryNavigate does without retrying, then returns the final URL,
+// HTTP status, redirect chain and response headers of the document, such that what actually happened can be told.
+// Statuses of 4xx and 5xx are returned as they are, without an error.
+// URLs other than of HTTP and HTTPS, such as about:blank, have no response, hence only the URL is returned for them.
+// The wait for the response is bound to the default timeout of the page, returning TaskTimeout once it elapses.
+func (p *Page) NavigateResult(url string) (*NavResult, error) {
+	res, err := p.navigateResult(p.GetContext(), url, true)
+	if err != nil {
+		return nil, &Error{Op: ActionNavigate, URL: url, Err: err}
+	}
+	return res, nil
+}
+
+// SetFailOnHTTPError makes TryNavigate and TryNavigateWithPolicy of this page fail fast by an error wrapping BadStatus,
+// once the document responds with 4xx or 5xx status, instead of examining the predicate. The error is not retryable
+// by IsRetryable, hence TryNavigateWithPolicy gives up at once as well, unless its policy tells otherwise.
+func (p *Page) SetFailOnHTTPError(fail bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failOnHTTPError = fail
+}
+
+// failsOnHTTPError tells whether navigations of this page fail by 4xx and 5xx statuses.
+func (p *Page) failsOnHTTPError() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.failOnHTTPError
+}
+
+// navigateResult navigates this page to the url, within ctx and the default timeout of this page,
+// returning the result of the navigation if capture is set, or only the URL otherwise.
+func (p *Page) navigateResult(ctx context.Context, url string, capture bool) (*NavResult, error) {
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	if p.robots != nil {
+		if err := p.robots.check(ctx, url); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.waitRate(ctx, url); err != nil {
+		return nil, err
+	}
+	capture = capture && (strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://"))
+	var c *navCapture
+	if capture {
+		c = &navCapture{frame: p.FrameID, mu: &sync.Mutex{}, done: make(chan struct{})}
+		listenCtx, stop := context.WithCancel(ctx)
+		defer stop()
+		wait := p.Context(listenCtx).EachEvent(c.requestWillBeSent, c.responseReceived)
+		go wait()
+	}
+	begin := time.Now()
+	err := replaceTimeoutError(replaceAbortedError(p.Context(ctx).Navigate(url)))
+	p.navigated(url, begin, err)
+	if err != nil {
+		return nil, err
+	} else if !capture {
+		return &NavResult{URL: url}, nil
+	}
+	select {
+	case <-c.done:
+		return c.res, nil
+	case <-ctx.Done():
+		return nil, replaceTimeoutError(ctx.Err())
+	}
+}
+
+func (c *navCapture) requestWillBeSent(e *proto.NetworkRequestWillBeSent) {
+	if e.Type != proto.NetworkResourceTypeDocument || e.FrameID != c.frame {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.res == nil || e.RequestID != c.id {
+		c.id, c.res = e.RequestID, &NavResult{URL: e.Request.URL, Redirects: make([]Redirect, 0)}
+		return
+	}
+	if r := e.RedirectResponse; r != nil {
+		c.res.Redirects = append(c.res.Redirects, Redirect{URL: r.URL, Status: r.Status, Location: e.Request.URL})
+	}
+	c.res.URL = e.Request.URL
+}
+
+// responseReceived completes the result by the final response to the document, then stops listening.
+func (c *navCapture) responseReceived(e *proto.NetworkResponseReceived) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.res == nil || e.RequestID != c.id {
+		return false
+	}
+	c.res.URL, c.res.Status, c.res.StatusText = e.Response.URL, e.Response.Status, e.Response.StatusText
+	c.res.Header = httpHeader(e.Response.Headers)
+	close(c.done)
+	return true
+}
@@ -0,0 +1,93 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysmood/gson"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_navCapture_Follows_Redirects_Of_Main_Frame(t *testing.T) {
+	c := &navCapture{frame: "main", mu: &sync.Mutex{}, done: make(chan struct{})}
+	document := func(id proto.NetworkRequestID, frame proto.PageFrameID, url string, redirect *proto.NetworkResponse) *proto.NetworkRequestWillBeSent {
+		return &proto.NetworkRequestWillBeSent{RequestID: id, FrameID: frame, Type: proto.NetworkResourceTypeDocument,
+			Request: &proto.NetworkRequest{URL: url}, RedirectResponse: redirect}
+	}
+	c.requestWillBeSent(document("1", "main", "http://a.test/old", nil))
+	c.requestWillBeSent(document("2", "child", "http://a.test/frame", nil))
+	c.requestWillBeSent(&proto.NetworkRequestWillBeSent{RequestID: "3", FrameID: "main", Type: proto.NetworkResourceTypeScript, Request: &proto.NetworkRequest{URL: "http://a.test/app.js"}})
+	c.requestWillBeSent(document("1", "main", "http://a.test/new", &proto.NetworkResponse{URL: "http://a.test/old", Status: 301}))
+	assert.False(t, c.responseReceived(&proto.NetworkResponseReceived{RequestID: "2", Response: &proto.NetworkResponse{URL: "http://a.test/frame", Status: 200}}))
+	assert.True(t, c.responseReceived(&proto.NetworkResponseReceived{RequestID: "1", Response: &proto.NetworkResponse{
+		URL: "http://a.test/new", Status: 200, StatusText: "OK", Headers: proto.NetworkHeaders{"Content-Type": gson.New("text/html")},
+	}}))
+
+	<-c.done
+	assert.Equal(t, &NavResult{
+		URL:        "http://a.test/new",
+		Status:     200,
+		StatusText: "OK",
+		Header:     http.Header{"Content-Type": {"text/html"}},
+		Redirects:  []Redirect{{URL: "http://a.test/old", Status: 301, Location: "http://a.test/new"}},
+	}, c.res)
+}
+
+// redirectServer serves /old redirecting to /new, /new, and 404 for others.
+func redirectServer(t *testing.T) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/new":
+			w.Header().Set("X-Page", "new")
+			_, _ = w.Write([]byte("<html><body>new</body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func Test_NavigateResult_Returns_Redirect_Chain(t *testing.T) {
+	_, p, _ := setup(t)
+	s := redirectServer(t)
+
+	res, err := p.NavigateResult(s.URL + "/old")
+	assert.NoError(t, err)
+	assert.Equal(t, s.URL+"/new", res.URL)
+	assert.Equal(t, http.StatusOK, res.Status)
+	assert.Equal(t, "new", res.Header.Get("X-Page"))
+	assert.Equal(t, []Redirect{{URL: s.URL + "/old", Status: http.StatusMovedPermanently, Location: s.URL + "/new"}}, res.Redirects)
+
+	res, err = p.NavigateResult(s.URL + "/missing")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.Status)
+	assert.Empty(t, res.Redirects)
+
+	res, err = p.NavigateResult(blankURL)
+	assert.NoError(t, err)
+	assert.Equal(t, &NavResult{URL: blankURL}, res)
+}
+
+func Test_SetFailOnHTTPError_Fails_TryNavigate_Fast(t *testing.T) {
+	_, p, _ := setup(t)
+	s := redirectServer(t)
+	examined := 0
+	pred := func(*Page) bool { examined++; return true }
+
+	assert.NoError(t, p.TryNavigate(s.URL+"/missing", pred, time.Millisecond))
+	assert.Equal(t, 1, examined)
+
+	p.SetFailOnHTTPError(true)
+	err := p.TryNavigate(s.URL+"/missing", pred, time.Millisecond)
+	assert.ErrorIs(t, err, BadStatus)
+	assert.ErrorContains(t, err, "404")
+	assert.Equal(t, 1, examined)
+	assert.False(t, IsRetryable(err))
+	assert.NoError(t, p.TryNavigate(s.URL+"/old", pred, time.Millisecond))
+}
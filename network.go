@@ -0,0 +1,284 @@
+package chromium
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// networkRecorder captures network activity of a page into entries of HAR.
+type networkRecorder struct {
+	mu       *sync.Mutex
+	entries  []*networkEntry
+	requests map[proto.NetworkRequestID]*networkEntry
+	cancel   context.CancelFunc
+	done     chan struct{}
+	bodies   *sync.WaitGroup
+}
+
+// networkEntry is an entry of HAR under recording, along with monotonic timestamps of its request.
+type networkEntry struct {
+	HAREntry
+	sent   proto.MonotonicTime
+	timing *proto.NetworkResourceTiming
+}
+
+func newNetworkRecorder() *networkRecorder {
+	return &networkRecorder{
+		mu:       &sync.Mutex{},
+		requests: make(map[proto.NetworkRequestID]*networkEntry),
+		done:     make(chan struct{}),
+		bodies:   &sync.WaitGroup{},
+	}
+}
+
+// StartNetworkRecording begins to capture requests, responses, timings and bodies of this page,
+// discarding what has been recorded before. It does nothing if the recording is already in progress.
+func (p *Page) StartNetworkRecording() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if rec := p.network; rec != nil && rec.cancel != nil {
+		return nil
+	}
+	rec := newNetworkRecorder()
+	ctx, cancel := context.WithCancel(p.GetContext())
+	page := p.Context(ctx)
+	wait := page.EachEvent(
+		rec.requestWillBeSent,
+		rec.responseReceived,
+		func(e *proto.NetworkLoadingFinished) { rec.loadingFinished(page, e) },
+		rec.loadingFailed,
+	)
+	go func() {
+		defer close(rec.done)
+		wait()
+	}()
+	rec.cancel = cancel
+	p.network = rec
+	return nil
+}
+
+// StopNetworkRecording stops capturing network activity of this page, keeping what has been recorded for ExportHAR.
+// It waits for bodies of finished responses to be read.
+func (p *Page) StopNetworkRecording() error {
+	p.mu.Lock()
+	rec := p.network
+	p.mu.Unlock()
+	if rec == nil {
+		return nil
+	}
+	rec.mu.Lock()
+	cancel := rec.cancel
+	rec.cancel = nil
+	rec.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-rec.done
+	}
+	rec.bodies.Wait()
+	return nil
+}
+
+// RecordedHAR returns the network activity that is recorded so far, which can be replayed by ReplayHAR.
+func (p *Page) RecordedHAR() *HAR {
+	p.mu.RLock()
+	rec := p.network
+	p.mu.RUnlock()
+	if rec == nil {
+		return newHAR(nil)
+	}
+	return rec.har()
+}
+
+// ExportHAR writes the network activity that is recorded so far as HAR 1.2 document.
+func (p *Page) ExportHAR(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(p.RecordedHAR())
+}
+
+func newHAR(entries []HAREntry) *HAR {
+	if entries == nil {
+		entries = make([]HAREntry, 0)
+	}
+	return &HAR{Log: HARLog{Version: "1.2", Creator: HARCreator{Name: "chromium", Version: "1"}, Entries: entries}}
+}
+
+// har returns a HAR of recorded entries, ordered by the time they started.
+func (r *networkRecorder) har() *HAR {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]HAREntry, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, e.HAREntry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].StartedDateTime.Before(entries[j].StartedDateTime) })
+	return newHAR(entries)
+}
+
+func (r *networkRecorder) requestWillBeSent(e *proto.NetworkRequestWillBeSent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prev, ok := r.requests[e.RequestID]; ok && e.RedirectResponse != nil {
+		prev.respond(e.RedirectResponse)
+		prev.finish(e.Timestamp)
+	}
+	entry := &networkEntry{sent: e.Timestamp}
+	entry.StartedDateTime = e.WallTime.Time()
+	entry.Request = harRequest(e.Request)
+	entry.Response = HARResponse{Cookies: []HARNameValue{}, Headers: []HARNameValue{}}
+	entry.Timings = HARTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1}
+	r.entries = append(r.entries, entry)
+	r.requests[e.RequestID] = entry
+}
+
+func (r *networkRecorder) responseReceived(e *proto.NetworkResponseReceived) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry, ok := r.requests[e.RequestID]; ok {
+		entry.respond(e.Response)
+	}
+}
+
+// loadingFinished completes the entry, then reads its body from the page in background.
+func (r *networkRecorder) loadingFinished(page *rod.Page, e *proto.NetworkLoadingFinished) {
+	r.mu.Lock()
+	entry, ok := r.requests[e.RequestID]
+	if ok {
+		entry.finish(e.Timestamp)
+		entry.Response.BodySize = int(e.EncodedDataLength)
+		delete(r.requests, e.RequestID)
+	}
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	r.bodies.Add(1)
+	go func() {
+		defer r.bodies.Done()
+		body, err := proto.NetworkGetResponseBody{RequestID: e.RequestID}.Call(page)
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		entry.Response.Content.Text = body.Body
+		if body.Base64Encoded {
+			entry.Response.Content.Encoding = "base64"
+		}
+	}()
+}
+
+func (r *networkRecorder) loadingFailed(e *proto.NetworkLoadingFailed) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry, ok := r.requests[e.RequestID]; ok {
+		entry.Response.StatusText = e.ErrorText
+		entry.finish(e.Timestamp)
+		delete(r.requests, e.RequestID)
+	}
+}
+
+// respond fills the response of the entry.
+func (e *networkEntry) respond(res *proto.NetworkResponse) {
+	e.Response.Status = res.Status
+	e.Response.StatusText = res.StatusText
+	e.Response.HTTPVersion = strings.ToUpper(res.Protocol)
+	e.Response.Headers = harHeaders(res.Headers)
+	e.Response.HeadersSize = -1
+	e.Response.BodySize = -1
+	e.Response.Content = HARContent{Size: int(res.EncodedDataLength), MimeType: res.MIMEType}
+	if location, ok := res.Headers["Location"]; ok {
+		e.Response.RedirectURL = location.Str()
+	} else if location, ok = res.Headers["location"]; ok {
+		e.Response.RedirectURL = location.Str()
+	}
+	e.Request.HTTPVersion = e.Response.HTTPVersion
+	e.timing = res.Timing
+}
+
+// finish computes timings of the entry by the timestamp it has finished at.
+func (e *networkEntry) finish(at proto.MonotonicTime) {
+	total := float64(at-e.sent) * 1000
+	e.Time = total
+	t := e.timing
+	if t == nil {
+		e.Timings.Send, e.Timings.Wait, e.Timings.Receive = 0, total, 0
+		return
+	}
+	span := func(start, end float64) float64 {
+		if start < 0 || end < 0 {
+			return -1
+		}
+		return end - start
+	}
+	e.Timings.DNS = span(t.DNSStart, t.DNSEnd)
+	e.Timings.Connect = span(t.ConnectStart, t.ConnectEnd)
+	e.Timings.SSL = span(t.SslStart, t.SslEnd)
+	e.Timings.Blocked = (t.RequestTime-float64(e.sent))*1000 + firstNonNegative(t.DNSStart, t.ConnectStart, t.SendStart)
+	e.Timings.Send = t.SendEnd - t.SendStart
+	e.Timings.Wait = t.ReceiveHeadersEnd - t.SendEnd
+	e.Timings.Receive = (float64(at)-t.RequestTime)*1000 - t.ReceiveHeadersEnd
+	if e.Timings.Receive < 0 {
+		e.Timings.Receive = 0
+	}
+	if e.Timings.Blocked < 0 {
+		e.Timings.Blocked = 0
+	}
+}
+
+func firstNonNegative(values ...float64) float64 {
+	for _, v := range values {
+		if v >= 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+// harRequest converts the request into the request of HAR.
+func harRequest(req *proto.NetworkRequest) HARRequest {
+	res := HARRequest{
+		Method:      req.Method,
+		URL:         req.URL + req.URLFragment,
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(req.Headers),
+		QueryString: []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    len(req.PostData),
+	}
+	if u, err := url.Parse(req.URL); err == nil {
+		for key, values := range u.Query() {
+			for _, value := range values {
+				res.QueryString = append(res.QueryString, HARNameValue{Name: key, Value: value})
+			}
+		}
+		sort.SliceStable(res.QueryString, func(i, j int) bool { return res.QueryString[i].Name < res.QueryString[j].Name })
+	}
+	if req.HasPostData {
+		mimeType := ""
+		for key, value := range req.Headers {
+			if strings.EqualFold(key, "Content-Type") {
+				mimeType = value.Str()
+			}
+		}
+		res.PostData = &HARPostData{MimeType: mimeType, Text: req.PostData}
+	}
+	return res
+}
+
+// harHeaders converts the headers into pairs of HAR, ordered by names.
+func harHeaders(headers proto.NetworkHeaders) []HARNameValue {
+	res := make([]HARNameValue, 0, len(headers))
+	for key, value := range headers {
+		res = append(res, HARNameValue{Name: key, Value: value.Str()})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
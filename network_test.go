@@ -0,0 +1,90 @@
+package chromium
+
+import (
+	"bytes"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysmood/gson"
+	"testing"
+)
+
+func Test_networkEntry_finish_Computes_Timings(t *testing.T) {
+	e := &networkEntry{sent: 10}
+	e.timing = &proto.NetworkResourceTiming{
+		RequestTime: 10.001, DNSStart: 0, DNSEnd: 2, ConnectStart: 2, ConnectEnd: 6, SslStart: 3, SslEnd: 6,
+		SendStart: 6, SendEnd: 7, ReceiveHeadersEnd: 17,
+	}
+	e.finish(10.025)
+	assert.InDelta(t, 25, e.Time, 0.001)
+	assert.InDelta(t, 1, e.Timings.Blocked, 0.001)
+	assert.InDelta(t, 2, e.Timings.DNS, 0.001)
+	assert.InDelta(t, 4, e.Timings.Connect, 0.001)
+	assert.InDelta(t, 3, e.Timings.SSL, 0.001)
+	assert.InDelta(t, 1, e.Timings.Send, 0.001)
+	assert.InDelta(t, 10, e.Timings.Wait, 0.001)
+	assert.InDelta(t, 7, e.Timings.Receive, 0.001)
+}
+
+func Test_networkEntry_finish_Marks_Missing_Phases(t *testing.T) {
+	e := &networkEntry{sent: 1}
+	e.timing = &proto.NetworkResourceTiming{RequestTime: 1, DNSStart: -1, DNSEnd: -1, ConnectStart: -1, ConnectEnd: -1, SslStart: -1, SslEnd: -1}
+	e.finish(1.5)
+	assert.Equal(t, float64(-1), e.Timings.DNS)
+	assert.Equal(t, float64(-1), e.Timings.Connect)
+	assert.Equal(t, float64(-1), e.Timings.SSL)
+}
+
+func Test_harRequest_Converts_Query_And_PostData(t *testing.T) {
+	req := harRequest(&proto.NetworkRequest{
+		Method:      "POST",
+		URL:         "http://test.local/?b=2&a=1",
+		Headers:     proto.NetworkHeaders{"content-type": gson.New("text/plain")},
+		HasPostData: true,
+		PostData:    "body",
+	})
+	assert.Equal(t, []HARNameValue{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}, req.QueryString)
+	if assert.NotNil(t, req.PostData) {
+		assert.Equal(t, "text/plain", req.PostData.MimeType)
+		assert.Equal(t, "body", req.PostData.Text)
+	}
+	assert.Equal(t, 4, req.BodySize)
+}
+
+func Test_RecordedHAR_Returns_Empty_HAR_Before_Recording(t *testing.T) {
+	h := newPage(nil, func() {}).RecordedHAR()
+	assert.Equal(t, "1.2", h.Log.Version)
+	assert.Empty(t, h.Log.Entries)
+}
+
+func Test_NetworkRecording_Exports_Navigation(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	assert.NoError(t, p.StartNetworkRecording())
+	assert.NoError(t, p.StartNetworkRecording())
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.WaitLoad())
+	assert.NoError(t, p.StopNetworkRecording())
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, p.ExportHAR(buf))
+	h, err := ReadHAR(buf)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, h.Log.Entries) {
+		entry := h.Log.Entries[0]
+		assert.Equal(t, s.URL, entry.Request.URL)
+		assert.Equal(t, 200, entry.Response.Status)
+		assert.Contains(t, entry.Response.Content.Text, "<li")
+	}
+}
+
+func Test_StartNetworkRecording_Records_Recycled_Page(t *testing.T) {
+	p, s := recycledPage(t, func(p *Page) { assert.NoError(t, p.StartNetworkRecording()) })
+	assert.NoError(t, p.StartNetworkRecording())
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.WaitLoad())
+	assert.NoError(t, p.StopNetworkRecording())
+	entries := p.RecordedHAR().Log.Entries
+	if assert.NotEmpty(t, entries) {
+		assert.Equal(t, s.URL, entries[0].Request.URL)
+	}
+}
@@ -1,70 +1,360 @@
 package chromium
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/proto"
-	"strings"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
 	"sync"
 	"time"
 )
 
 type Page struct {
 	*rod.Page
-	done    func()
-	once    *sync.Once
-	dialogs []*proto.PageJavascriptDialogOpening
+	*pageShared
 }
 
+// pageShared is the state of a page, which is shared by the page and its views that runTask hands to tasks,
+// whose tabs are bound to the timeout of the task.
+type pageShared struct {
+	done func()
+	once *sync.Once
+
+	mu *sync.RWMutex // guards the states below, which may be accessed from event goroutines.
+	// dialogs is a ring buffer of dialogs, of which the oldest is at dialogHead once full.
+	dialogs         []*proto.PageJavascriptDialogOpening
+	dialogHead      int
+	dialogLimit     int // maximum number of dialogs to keep, which is unbounded if not positive.
+	history         []ActionRecord
+	historyHead     int
+	historyLimit    int // maximum number of records of helpers to keep, which disables the history if not positive.
+	timeout         time.Duration
+	interval        time.Duration
+	recorder        *Recorder
+	interceptor     *interceptor
+	network         *networkRecorder
+	console         *consoleRecorder
+	exposed         []*exposedFunc // functions exposed by ExposeFunc.
+	initScripts     []*initScript
+	headers         map[string]string // extra headers set by SetExtraHeaders.
+	human           bool              // whether to click like a human, as set by SetHumanInput.
+	cursor          proto.Point       // where the cursor is left by the last human click.
+	isolated        bool              // whether the tab is in its own browser context, which is disposed along with it.
+	proxy           string            // proxy server of the browser context of the tab, which is empty for the default one.
+	stealth         bool              // whether the user agent is overridden by SetStealth.
+	limiter         RateLimiter       // limiter of navigations, as set by SetRateLimiter.
+	robots          *robotsPolicy     // policy of robots.txt that navigations obey, as of WithRobotsPolicy.
+	collector       Collector         // collector of metrics, as of WithCollector.
+	tracer          trace.Tracer      // tracer of operations, as of WithTracerProvider.
+	logger          Logger            // logger of events, as of WithLogger.
+	artifactDir     string            // directory of artifacts captured on failure, as set by SetArtifactsOnFailure.
+	screencast      *screencast       // screencast in progress, as of StartScreencast.
+	failOnHTTPError bool              // whether navigations fail by 4xx and 5xx statuses, as set by SetFailOnHTTPError.
+	auth            *proxyAuth        // answerer of authentication challenges, shared by pages of the same browser.
+	authSession     *authSession      // session to the tab answering challenges of servers, as of EnableAuth.
+	bypassWorkers   bool              // whether requests bypass service workers, as set by BypassServiceWorkers.
+	cpuRate         float64           // rate of CPU throttling, as set by ThrottleCPU, which is off if 1 or lower.
+	offline         bool              // whether the tab is cut off the network, as set by SetOffline.
+}
+
+// WaitJSObject is a shortcut of WaitJSObjectFor, with the default timeout of this page.
+// If no default timeout is set for this page, it will wait for 5 seconds.
 func (p *Page) WaitJSObject(objName string) error {
+	if timeout := p.defaultTimeout(); timeout > 0 {
+		return p.WaitJSObjectFor(objName, timeout)
+	}
 	return p.WaitJSObjectFor(objName, time.Second*5)
 }
 
+// SetDefaultTimeout sets the timeout that applies to all subsequent helper operations of this page,
+// such as element waits, navigation waits and evaluations.
+// Helpers that take a timeout as their parameter will keep using the given one instead.
+// Setting zero or negative duration removes the default timeout, such that helpers wait until the page is cleaned up.
+func (p *Page) SetDefaultTimeout(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeout = d
+}
+
+// defaultTimeout returns the default timeout of this page.
+func (p *Page) defaultTimeout() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.timeout
+}
+
+// SetPollInterval sets the interval by which helpers of this page poll for a condition, such as WaitElementFor.
+// Zero or negative interval restores the default of 100 milliseconds.
+func (p *Page) SetPollInterval(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interval = d
+}
+
+// pollInterval returns the poll interval of this page, or the default one if not set.
+func (p *Page) pollInterval() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.interval <= 0 {
+		return defaultPollInterval
+	}
+	return p.interval
+}
+
+// timeoutContext returns a context of this page that is bound to the default timeout, along with its cancel function.
+func (p *Page) timeoutContext() (context.Context, context.CancelFunc) {
+	return p.timeoutContextOf(p.GetContext())
+}
+
+// timeoutContextOf returns a context that is done when either of ctx or this page's context is done,
+// and that is bound to the default timeout, along with its cancel function.
+func (p *Page) timeoutContextOf(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := p.mergeContext(ctx)
+	timeout := p.defaultTimeout()
+	if timeout <= 0 {
+		return ctx, cancel
+	}
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	return ctx, func() { cancelTimeout(); cancel() }
+}
+
+// mergeContext returns a context that is done when either of ctx or this page's context is done,
+// along with its cancel function.
+func (p *Page) mergeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	page := p.GetContext()
+	if ctx == page {
+		return context.WithCancel(ctx)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-page.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
 // CleanUp calls page done once and only once, signalling Browser such that the page is actually closed.
 func (p *Page) CleanUp() {
 	p.once.Do(p.done)
-	_ = p.Close()
+	p.unexposeAll()
+	p.authenticator().setServer(p.FrameID, nil)
+	p.stopAuth()
+	_ = closeTab(p.Page, p.isolated)
+}
+
+// Duplicate opens a new tab navigated to the URL of this page, in the same browser context as this page,
+// such that the tab shares cookies and storage with this page, along with the settings of this page as copySettings
+// tells, such as the default timeout, extra headers, init scripts and stealth. The duplicate has its own history,
+// dialogs, recordings and interceptions.
+// The duplicate is not a part of the page pool, thus it is required for a caller to close it via its CleanUp.
+func (p *Page) Duplicate() (*Page, error) {
+	info, err := p.Info()
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	page, err := p.Browser().Page(proto.TargetCreateTarget{URL: blankURL})
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	dup := newPage(page, func() {})
+	if err = p.copySettings(dup); err == nil {
+		err = replaceAbortedError(page.Navigate(info.URL))
+	}
+	if err != nil {
+		_ = page.Close()
+		return nil, err
+	}
+	return dup, nil
 }
 
-// Dialogs returns history of current page's dialogs.
+// copySettings copies the settings of this page to the other page, applying the ones bound to a tab to the tab of
+// the other page: the default timeout and poll interval, limits of dialogs and history, human input, failing by HTTP
+// errors, artifacts on failure, the rate limiter, robots policy, collector, tracer and logger, credentials of
+// EnableAuth, extra headers, init scripts, stealth, bypass of service workers, CPU throttling and offline state.
+// A setting added to Page is to be copied here.
+func (p *Page) copySettings(dst *Page) error {
+	p.mu.RLock()
+	dst.mu.Lock()
+	dst.timeout, dst.interval = p.timeout, p.interval
+	dst.dialogLimit, dst.historyLimit = p.dialogLimit, p.historyLimit
+	dst.human, dst.failOnHTTPError, dst.artifactDir = p.human, p.failOnHTTPError, p.artifactDir
+	dst.limiter, dst.robots = p.limiter, p.robots
+	dst.collector, dst.tracer, dst.logger = p.collector, p.tracer, p.logger
+	dst.stealth, dst.bypassWorkers = p.stealth, p.bypassWorkers
+	dst.cpuRate, dst.offline = p.cpuRate, p.offline
+	authEnabled := p.authSession != nil
+	dst.headers = make(map[string]string, len(p.headers))
+	for k, v := range p.headers {
+		dst.headers[k] = v
+	}
+	dst.initScripts = make([]*initScript, 0, len(p.initScripts))
+	for _, script := range p.initScripts {
+		dst.initScripts = append(dst.initScripts, &initScript{source: script.source})
+	}
+	dst.mu.Unlock()
+	p.mu.RUnlock()
+
+	auth := p.authenticator()
+	dst.mu.Lock()
+	dst.auth = auth
+	dst.mu.Unlock()
+	auth.copyServer(p.FrameID, dst.FrameID)
+	if authEnabled {
+		if err := dst.listenAuth(dst.Page); err != nil {
+			return err
+		}
+	}
+
+	if err := dst.restoreInitScripts(dst.Page); err != nil {
+		return err
+	} else if err = dst.restoreExtraHeaders(dst.Page); err != nil {
+		return err
+	} else if err = dst.restoreServiceWorkers(dst.Page); err != nil {
+		return err
+	} else if err = dst.restoreEmulation(dst.Page); err != nil {
+		return err
+	}
+	return dst.restoreStealth(dst.Page)
+}
+
+// Dialogs returns a copy of history of current page's dialogs, from the oldest to the latest.
 func (p *Page) Dialogs() []*proto.PageJavascriptDialogOpening {
-	return p.dialogs
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.orderedDialogs()
+}
+
+// orderedDialogs returns a copy of the ring buffer of dialogs in order, which must be called under the lock.
+func (p *Page) orderedDialogs() []*proto.PageJavascriptDialogOpening {
+	dialogs := make([]*proto.PageJavascriptDialogOpening, 0, len(p.dialogs))
+	dialogs = append(dialogs, p.dialogs[p.dialogHead:]...)
+	return append(dialogs, p.dialogs[:p.dialogHead]...)
 }
 
 // SaveDialog appends given proto.PageJavascriptDialogOpening to current page's dialog history.
+// Once the history reaches the dialog limit of the page, the oldest dialog is overwritten.
+// It is safe to be called from event handlers concurrently. The dialog is reported to the collector and the logger of the page, if any.
 func (p *Page) SaveDialog(d *proto.PageJavascriptDialogOpening) {
-	p.dialogs = append(p.dialogs, d)
+	if p.collector != nil {
+		p.collector.DialogOpened(string(d.Type))
+	}
+	p.log(LevelInfo, "dialog opened", "type", d.Type, "message", d.Message, "url", d.URL)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.dialogLimit <= 0 || len(p.dialogs) < p.dialogLimit {
+		p.dialogs = append(p.dialogs, d)
+		return
+	}
+	p.dialogs[p.dialogHead] = d
+	p.dialogHead = (p.dialogHead + 1) % len(p.dialogs)
+}
+
+// ClearDialogs removes all dialogs from the history of this page.
+func (p *Page) ClearDialogs() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dialogs = make([]*proto.PageJavascriptDialogOpening, 0)
+	p.dialogHead = 0
+}
+
+// SetDialogLimit sets the maximum number of dialogs kept in the history of this page, keeping the latest ones.
+// Zero or negative limit lets the history grow unbounded. The limit defaults to 100.
+func (p *Page) SetDialogLimit(limit int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	dialogs := p.orderedDialogs()
+	if limit > 0 && len(dialogs) > limit {
+		dialogs = dialogs[len(dialogs)-limit:]
+	}
+	p.dialogs, p.dialogHead, p.dialogLimit = dialogs, 0, limit
 }
 
 // TryNavigate is a safe-guarding method of navigation with indefinite retry.
 // Need of this navigation arose when navigation is succeeded with 2XX with blank HTML response.
 // Logic to determine whether the navigation succeeded or not depends on Predicate for given Page.
+// The wait between attempts begins with the backoff, and grows by the backoff on each failed attempt.
 func (p *Page) TryNavigate(url string, predicate Predicate[*Page], backoff time.Duration) error {
-	eChan := make(chan error, 1)
-	go func() {
-		defer func() {
-			if pe := recover(); isError(pe) {
-				err, _ := pe.(error)
-				eChan <- replaceAbortedError(err)
-			}
-			defer close(eChan)
-		}()
+	return p.TryNavigateContext(p.GetContext(), url, predicate, backoff)
+}
 
-		delay := backoff
-
-	tryNavigate:
-		wait := p.MustWaitNavigation()
-		done := make(chan struct{}, 1)
-		go func() { defer close(done); wait(); done <- struct{}{} }()
-		p.MustNavigate(url)
-		if !predicate(p) {
-			delay += backoff
-			time.Sleep(delay)
-			goto tryNavigate
+// TryNavigateContext is TryNavigate that also stops once ctx is done.
+// The returned error tells the URL and the number of attempts made, while wrapping the error of the last attempt.
+func (p *Page) TryNavigateContext(ctx context.Context, url string, predicate Predicate[*Page], backoff time.Duration) (err error) {
+	step := Step{Action: ActionNavigate, URL: url}
+	defer p.record(step, time.Now(), &err)
+	ctx, end := p.startSpan(ctx, step)
+	defer end(&err)
+	for attempt, delay := 1, backoff; ; attempt, delay = attempt+1, delay+backoff {
+		if err = p.navigate(ctx, url); err != nil {
+			return attemptError(err, url, attempt)
 		}
-	}()
+		ok, err := examine(p, predicate)
+		if err != nil {
+			return attemptError(err, url, attempt)
+		} else if ok {
+			return nil
+		}
+		p.retried(ActionNavigate, attempt, PredicateFailed)
+		if err = p.sleep(ctx, delay); err != nil {
+			return attemptError(err, url, attempt)
+		}
+	}
+}
+
+// examine runs the predicate against the item, recovering a panic with an error, such as the one from Must functions,
+// into err.
+func examine[T any](item T, predicate Predicate[T]) (ok bool, err error) {
+	defer recoverError(&err)
+	return predicate(item), nil
+}
 
-	return <-eChan
+// attemptError returns an Error of navigation to the URL, telling the number of attempts made.
+func attemptError(err error, url string, attempt int) error {
+	return &Error{Op: ActionNavigate, URL: url, Attempt: attempt, Err: replaceAbortedError(err)}
+}
+
+// navigate navigates this page to the url, within ctx and the default timeout of this page.
+// It returns an error wrapping BadStatus if the page fails on HTTP errors and the document responds with one.
+func (p *Page) navigate(ctx context.Context, url string) error {
+	res, err := p.navigateResult(ctx, url, p.failsOnHTTPError())
+	if err != nil {
+		return err
+	} else if res.Status >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %d %s", BadStatus, res.Status, res.StatusText)
+	}
+	return nil
+}
+
+// sleep pauses for given duration, or returns the error of the context when either of ctx or this page's context
+// is done meanwhile.
+func (p *Page) sleep(ctx context.Context, d time.Duration) error {
+	ctx, cancel := p.mergeContext(ctx)
+	defer cancel()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return replaceTimeoutError(ctx.Err())
+	}
+}
+
+// recoverError recovers a panic with an error, such as the one from Must functions of a predicate, into given err.
+// Panics with anything other than an error are propagated.
+func recoverError(err *error) {
+	if pe := recover(); pe != nil {
+		if !isError(pe) {
+			panic(pe)
+		}
+		*err = replaceTimeoutError(replaceAbortedError(pe.(error)))
+	}
 }
 
 func isError(item any) bool {
@@ -75,67 +365,167 @@ func isError(item any) bool {
 	return res
 }
 
-// TryInput is a conjunction of Page.WaitVisibleElement and *rod.Element's Input function.
+// TryInput is a conjunction of Page.HasElement and *rod.Element's Input function, which replaces existing text.
 // It will propagate any error from subsequent actions by immediately returning that non-nil error.
 // It will return error as nil if the action has been successfully executed.
 func (p *Page) TryInput(selector, text string) error {
-	eChan := make(chan error, 1)
-	go func() {
-		defer func() {
-			if pe := recover(); isError(pe) {
-				err, _ := pe.(error)
-				eChan <- replaceAbortedError(err)
-			}
-			close(eChan)
-		}()
-		element, err := p.HasElement(selector)
-		if err != nil {
-			eChan <- err
-			return
-		}
-		element.MustSelectAllText().MustInput(text)
-	}()
-	return replaceAbortedError(<-eChan)
+	return p.TryInputContext(p.GetContext(), selector, text)
+}
+
+// TryInputContext is TryInput that also stops once ctx is done.
+func (p *Page) TryInputContext(ctx context.Context, selector, text string) error {
+	return p.tryInput(ctx, Step{Action: ActionInput, Selector: selector, Text: text}, text)
+}
+
+// redactedText is recorded in place of the text of TryInputSecret.
+const redactedText = "[redacted]"
+
+// TryInputSecret is TryInput for credentials such as passwords and one-time passwords, whose text is recorded as
+// "[redacted]" in the history, the recorder and the artifacts of this page, rather than as-is.
+// Note that a replay of such a step types the placeholder, thus the credential has to be typed by the replaying job.
+func (p *Page) TryInputSecret(selector, secret string) error {
+	return p.TryInputSecretContext(p.GetContext(), selector, secret)
+}
+
+// TryInputSecretContext is TryInputSecret that also stops once ctx is done.
+func (p *Page) TryInputSecretContext(ctx context.Context, selector, secret string) error {
+	return p.tryInput(ctx, Step{Action: ActionInput, Selector: selector, Text: redactedText}, secret)
+}
+
+// tryInput types the text into the element of the selector, recording the step in place of the text.
+func (p *Page) tryInput(ctx context.Context, step Step, text string) (err error) {
+	defer p.record(step, time.Now(), &err)
+	ctx, end := p.startSpan(ctx, step)
+	defer end(&err)
+	element, err := p.HasElementContext(ctx, step.Selector)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	element = element.Context(ctx)
+	if err = element.SelectAllText(); err == nil {
+		err = element.Input(text)
+	}
+	if err != nil {
+		return elementError(ActionInput, step.Selector, classify(InputFailed, err))
+	}
+	return nil
 }
 
 // HasElement checks if any element matching the given selector.
 // If exists, will return an element with no error, or vise versa.
+// The selector may be of any kind of Selector, such as "xpath=//a" or "text=Sign in", as with other helpers of Page.
 func (p *Page) HasElement(selector string) (*rod.Element, error) {
-	found, element, err := p.Has(selector)
+	return p.HasElementContext(p.GetContext(), selector)
+}
+
+// HasElementContext is HasElement that also stops once ctx is done.
+// The element is bound to the context of this page rather than ctx.
+func (p *Page) HasElementContext(ctx context.Context, selector string) (*rod.Element, error) {
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	found, element, err := has(p.Context(ctx), selector)
 	if err != nil {
-		return nil, err
+		return nil, elementError(opFind, selector, classify(ElementMissing, err))
 	} else if !found {
-		return nil, wrap(ElementMissing, selector)
+		return nil, elementError(opFind, selector, ElementMissing)
 	}
-	return element, nil
+	return element.Context(p.GetContext()), nil
+}
+
+// HasElementDeep is HasElement that looks into open shadow roots as well, such that elements rendered inside
+// web components can be found. It is a shortcut of HasElement with ByDeep, which is also accepted by TryInput,
+// ClickNavigate and other helpers of Page.
+func (p *Page) HasElementDeep(selector string) (*rod.Element, error) {
+	return p.HasElement(ByDeep(selector))
 }
 
 // WaitVisibleElement is a shortcut for search and wait for element to be visible (i.e. interact-ready)
 // Any failure from child action will be propagated.
 // Will return an element with no error on success, otherwise will return nil with error for failing reason.
-func (p *Page) WaitVisibleElement(selector string) (el *rod.Element, err error) {
-	if el, err = p.HasElement(selector); err != nil {
+func (p *Page) WaitVisibleElement(selector string) (*rod.Element, error) {
+	return p.WaitVisibleElementContext(p.GetContext(), selector)
+}
+
+// WaitVisibleElementContext is WaitVisibleElement that also stops once ctx is done.
+func (p *Page) WaitVisibleElementContext(ctx context.Context, selector string) (el *rod.Element, err error) {
+	defer p.record(Step{Action: ActionWait, Selector: selector}, time.Now(), &err)
+	return p.waitVisibleElement(ctx, selector)
+}
+
+// waitVisibleElement is an implementation of WaitVisibleElementContext, which is not recorded.
+func (p *Page) waitVisibleElement(ctx context.Context, selector string) (el *rod.Element, err error) {
+	if el, err = p.HasElementContext(ctx, selector); err != nil {
 		return nil, err
-	} else if err = el.WaitVisible(); err != nil {
-		return nil, wrap(WaitFailed, selector)
+	}
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	timed := el.Context(ctx)
+	_, err = Await(ctx, func() (bool, bool, error) {
+		visible, err := timed.Visible()
+		return visible, visible, err
+	}, p.pollInterval(), 0)
+	if errors.Is(err, TaskTimeout) {
+		return nil, elementError(ActionWait, selector, TaskTimeout)
+	} else if err != nil {
+		return nil, elementError(ActionWait, selector, WaitFailed)
 	}
 	return el, nil
 }
 
+// WaitElementFor polls for an element matching the selector by the poll interval of this page, until it appears.
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping ElementMissing if no element appears in time.
+func (p *Page) WaitElementFor(selector string, timeout time.Duration) (el *rod.Element, err error) {
+	defer p.record(Step{Action: ActionWait, Selector: selector}, time.Now(), &err)
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	el, err = Await(ctx, func() (*rod.Element, bool, error) {
+		found, el, err := has(page, selector)
+		return el, found, err
+	}, p.pollInterval(), 0)
+	if errors.Is(err, TaskTimeout) {
+		return nil, elementError(ActionWait, selector, ElementMissing)
+	} else if err != nil {
+		return nil, err
+	}
+	return el.Context(p.GetContext()), nil
+}
+
 // ClickNavigate clicks an element that is matching the given selector as criteria.
+// The element is clicked like a human if this page is set so by SetHumanInput.
 func (p *Page) ClickNavigate(selector string, timeout time.Duration) error {
-	el, err := p.WaitVisibleElement(selector)
+	return p.ClickNavigateContext(p.GetContext(), selector, timeout)
+}
+
+// ClickNavigateContext is ClickNavigate that also stops once ctx is done.
+func (p *Page) ClickNavigateContext(ctx context.Context, selector string, timeout time.Duration) (err error) {
+	step := Step{Action: ActionClick, Selector: selector, Timeout: Duration(timeout)}
+	defer p.record(step, time.Now(), &err)
+	ctx, end := p.startSpan(ctx, step)
+	defer end(&err)
+	el, err := p.waitVisibleElement(ctx, selector)
 	if err != nil {
 		return err
 	}
 
-	waitFunc := p.MustWaitNavigation()
+	ctx, cancel := p.mergeContext(ctx)
+	defer cancel()
+	rateCtx, cancelRate := context.WithTimeout(ctx, timeout)
+	err = p.waitRateOfPage(rateCtx)
+	cancelRate()
+	if err != nil {
+		return elementError(ActionClick, selector, err)
+	}
+	waitFunc := p.Context(ctx).WaitNavigation(proto.PageLifecycleEventNameNetworkAlmostIdle)
 	waitDone, clickFail := make(chan struct{}, 1), make(chan error, 1)
 
 	go func(elem *rod.Element) {
 		defer close(clickFail)
-		if clickErr := elem.Click(proto.InputMouseButtonLeft); clickErr != nil {
-			clickFail <- wrap(ClickFailed, selector)
+		if clickErr := p.click(elem.Context(ctx)); clickErr != nil {
+			clickFail <- elementError(ActionClick, selector, classify(ClickFailed, clickErr))
 		}
 	}(el)
 
@@ -146,80 +536,78 @@ func (p *Page) ClickNavigate(selector string, timeout time.Duration) error {
 		}()
 		waitFunc()
 	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 	for {
 		select {
 		case <-waitDone:
+			if ctx.Err() != nil {
+				return elementError(ActionClick, selector, replaceTimeoutError(ctx.Err()))
+			}
 			return nil
 		case e := <-clickFail:
 			if e != nil {
 				return e
 			}
-		case <-time.After(timeout):
-			return TaskTimeout
+		case <-timer.C:
+			return elementError(ActionClick, selector, TaskTimeout)
 		}
 	}
 }
 
 // WaitJSObjectFor enforces this page to await for specified JavaScript Object to be loaded to given page,
-// for specified time duration. It will wait for the item by each depth for the name by dot delimiter.
+// for specified time duration. It will wait until every depth of the name by dot delimiter is defined.
+// The object is examined by the poll interval of this page, such as by SetPollInterval, and a navigation meanwhile
+// does not fail the wait, as the object is examined in the next document instead.
+// Each depth of the name must be a JavaScript identifier, such as "window.app.ready", otherwise it returns WaitFailed.
 func (p *Page) WaitJSObjectFor(objName string, until time.Duration) error {
+	return p.WaitJSObjectForContext(p.GetContext(), objName, until)
+}
+
+// WaitJSObjectForContext is WaitJSObjectFor that also stops once ctx is done.
+func (p *Page) WaitJSObjectForContext(ctx context.Context, objName string, until time.Duration) (err error) {
+	step := Step{Action: ActionWaitJS, Text: objName, Timeout: Duration(until)}
+	defer p.record(step, time.Now(), &err)
+	ctx, end := p.startSpan(ctx, step)
+	defer end(&err)
 	if len(objName) == 0 {
 		return nil
-	} else if until == 0 {
-		return TaskTimeout
+	} else if until <= 0 {
+		return elementError(ActionWaitJS, objName, TaskTimeout)
 	}
-
-	timer, errChan, doneChan := time.After(until), make(chan error, 1), make(chan struct{}, 1)
-
-	go func() {
-		defer close(doneChan)
-		defer close(errChan)
-		begin := time.Now()
-		items := strings.Split(objName, ".")
-		for i := range items { // check each depth as well as checking due on each retry attempt
-			if i > 0 {
-				items[i] = items[i-1] + "." + items[i] // only refer last item if not the first item
-			}
-			script := fmt.Sprintf(`() => typeof %+v !== 'undefined'`, items[i]) // run through console
-			for {
-				if time.Since(begin) > until { // in case of until, we do not send doneChan signal
-					return
-				}
-				obj, err := p.Eval(script)
-				if err != nil {
-					errChan <- err
-					return
-				}
-				if obj.Value.Bool() { // found
-					time.Sleep(time.Millisecond * 100)
-					break
-				}
-			}
-		}
-		doneChan <- struct{}{} // success
-	}()
-
-	// evaluate which one comes first
-	for {
-		select {
-		case err := <-errChan:
-			if err != nil {
-				return err
-			}
-		case <-timer: // on failure
-			return TaskTimeout
-		case <-doneChan: // on success
-			return nil
+	if !jsObjectNamePattern.MatchString(objName) {
+		return elementError(ActionWaitJS, objName, fmt.Errorf("%w: invalid object name", WaitFailed))
+	}
+	ctx, cancel := p.mergeContext(ctx)
+	defer cancel()
+	ctx, cancelTimeout := context.WithTimeout(ctx, until)
+	defer cancelTimeout()
+	page := p.Context(ctx)
+	script := fmt.Sprintf(`() => { try { return typeof %s !== 'undefined' } catch (e) { return false } }`, objName)
+	_, err = Await(ctx, func() (bool, bool, error) {
+		obj, err := page.Eval(script)
+		if err != nil && isContextLost(err) {
+			return false, false, nil // the page is navigating, hence the object is examined in the next document.
+		} else if err != nil {
+			return false, false, err
 		}
+		return true, obj.Value.Bool(), nil
+	}, p.pollInterval(), 0)
+	if err != nil {
+		return elementError(ActionWaitJS, objName, classify(WaitFailed, err))
 	}
+	return nil
 }
 
 // newPage returns a page,
 func newPage(p *rod.Page, done func()) *Page {
-	return &Page{
-		Page:    p,
-		done:    done,
-		once:    &sync.Once{},
-		dialogs: make([]*proto.PageJavascriptDialogOpening, 0),
-	}
+	return &Page{Page: p, pageShared: &pageShared{
+		done:         done,
+		once:         &sync.Once{},
+		mu:           &sync.RWMutex{},
+		dialogs:      make([]*proto.PageJavascriptDialogOpening, 0),
+		dialogLimit:  defaultDialogLimit,
+		history:      make([]ActionRecord, 0),
+		historyLimit: defaultHistoryLimit,
+	}}
 }
@@ -0,0 +1,96 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"time"
+)
+
+// DialogPolicy decides how a page handles JavaScript dialogs, such as alert, confirm and prompt.
+type DialogPolicy int
+
+const (
+	// DialogManual leaves dialogs to be handled by the caller, such as via HandleDialog.
+	DialogManual DialogPolicy = iota
+	// DialogAccept saves each dialog to the history of the page, then accepts it.
+	DialogAccept
+	// DialogDismiss saves each dialog to the history of the page, then dismisses it.
+	DialogDismiss
+)
+
+// PageOption configures a Page on NewPage.
+type PageOption func(o *pageOptions)
+
+// pageOptions holds configurations that are set by PageOption.
+type pageOptions struct {
+	viewport *Viewport
+	timeout  time.Duration
+	interval time.Duration
+	dialogs  DialogPolicy
+	limit    int
+	done     func()
+}
+
+// WithViewport sets the viewport of the page.
+func WithViewport(v Viewport) PageOption {
+	return func(o *pageOptions) { o.viewport = &v }
+}
+
+// WithDefaultTimeout sets the default timeout of the page, as SetDefaultTimeout does.
+func WithDefaultTimeout(d time.Duration) PageOption {
+	return func(o *pageOptions) { o.timeout = d }
+}
+
+// WithPollInterval sets the interval by which helpers of the page poll for a condition, as SetPollInterval does.
+func WithPollInterval(d time.Duration) PageOption {
+	return func(o *pageOptions) { o.interval = d }
+}
+
+// WithDialogPolicy sets how the page handles JavaScript dialogs. Handled dialogs are saved to Dialogs of the page.
+func WithDialogPolicy(policy DialogPolicy) PageOption {
+	return func(o *pageOptions) { o.dialogs = policy }
+}
+
+// WithDialogLimit sets the maximum number of dialogs kept in the history of the page, as SetDialogLimit does.
+func WithDialogLimit(limit int) PageOption {
+	return func(o *pageOptions) { o.limit = limit }
+}
+
+// WithDoneCallback sets a callback that is called once, on the first CleanUp of the page.
+func WithDoneCallback(done func()) PageOption {
+	return func(o *pageOptions) { o.done = done }
+}
+
+// NewPage wraps a page that is created outside of the page pool, such as popups or external targets,
+// so that the helpers of this package can be used with it.
+// The page is not a part of any page pool, thus it is required for a caller to close it via its CleanUp.
+func NewPage(page *rod.Page, opts ...PageOption) (*Page, error) {
+	o := &pageOptions{limit: defaultDialogLimit, done: func() {}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	p := newPage(page, o.done)
+	p.timeout = o.timeout
+	p.interval = o.interval
+	p.dialogLimit = o.limit
+	if v := o.viewport; v != nil {
+		metrics := &proto.EmulationSetDeviceMetricsOverride{Width: v.Width, Height: v.Height, DeviceScaleFactor: v.DeviceScaleFactor, Mobile: v.Mobile}
+		if err := page.SetViewport(metrics); err != nil {
+			return nil, replaceAbortedError(err)
+		}
+	}
+	if o.dialogs != DialogManual {
+		go p.handleDialogs(o.dialogs == DialogAccept)
+	}
+	return p, nil
+}
+
+// handleDialogs saves then accepts or dismisses each dialog, until the page is closed.
+// The tab is captured once, such that answering a dialog never reads the page while it is being recycled.
+func (p *Page) handleDialogs(accept bool) {
+	page := p.Page
+	page.EachEvent(func(e *proto.PageJavascriptDialogOpening) {
+		p.SaveDialog(e)
+		go func() { _ = proto.PageHandleJavaScriptDialog{Accept: accept, PromptText: e.DefaultPrompt}.Call(page) }()
+	})()
+}
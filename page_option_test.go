@@ -0,0 +1,48 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_NewPage_Applies_Default_Timeout_And_Done_Callback(t *testing.T) {
+	count := 0
+	p, err := NewPage(nil, WithDefaultTimeout(time.Second), WithDoneCallback(func() { count++ }))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, p.defaultTimeout())
+	p.once.Do(p.done)
+	p.once.Do(p.done)
+	assert.Equal(t, 1, count)
+}
+
+func Test_NewPage_Applies_Dialog_Limit(t *testing.T) {
+	p, err := NewPage(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultDialogLimit, p.dialogLimit)
+	p, err = NewPage(nil, WithDialogLimit(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, p.dialogLimit)
+}
+
+func Test_NewPage_Applies_Viewport(t *testing.T) {
+	b, _, s := setup(t, testfile.BlankHTML)
+	p, err := NewPage(b.MustPage(), WithViewport(Viewport{Width: 640, Height: 480, DeviceScaleFactor: 1}))
+	assert.NoError(t, err)
+	t.Cleanup(p.CleanUp)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.Equal(t, 640, p.MustEval(`() => window.innerWidth`).Int())
+}
+
+func Test_NewPage_Accepts_Dialogs_By_Policy(t *testing.T) {
+	b, _, s := setup(t, testfile.AlertHTML)
+	p, err := NewPage(b.MustPage(), WithDialogPolicy(DialogAccept))
+	assert.NoError(t, err)
+	t.Cleanup(p.CleanUp)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.NoError(t, p.MustElement("button").Click(proto.InputMouseButtonLeft))
+	assert.Eventually(t, func() bool { return len(p.Dialogs()) == 1 }, time.Second*5, time.Millisecond*10)
+	assert.Contains(t, p.Dialogs()[0].Message, "test")
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/state303/chromium/internal/test/testserver"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -135,7 +137,7 @@ func Test_Dialogs_Must_Contain_Previous_Alert(t *testing.T) {
 
 	dialogs := p.Dialogs()
 	if assert.Len(t, dialogs, 1, "expected exactly 1 dialog") {
-		dialog := p.dialogs[0]
+		dialog := dialogs[0]
 		assert.NotNil(t, dialog, "expected dialog not to be nil")
 		assert.Contains(t, dialog.Message, "test", "expected dialog to preserve message")
 	}
@@ -180,6 +182,95 @@ func Test_GetVisibleElement_Waits_Element_Visible(t *testing.T) {
 	assert.NotNil(t, el)
 }
 
+func Test_SetDefaultTimeout_Applies_To_WaitVisibleElement(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL)
+	p.MustElement("body").MustEval("() => this.setAttribute('hidden', 'true')")
+	p.SetDefaultTimeout(time.Millisecond * 50)
+	el, err := p.WaitVisibleElement("body")
+	assert.Nil(t, el)
+	assert.ErrorIs(t, err, TaskTimeout)
+	assert.ErrorContains(t, err, "body")
+}
+
+func Test_SetDefaultTimeout_Does_Not_Bind_Returned_Element(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL)
+	p.SetDefaultTimeout(time.Millisecond * 50)
+	el, err := p.HasElement("li")
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 100)
+	_, err = el.Text()
+	assert.NoError(t, err, "expected returned element to outlive the default timeout")
+}
+
+func Test_SetDefaultTimeout_Applies_To_WaitJSObject(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL)
+	p.SetDefaultTimeout(time.Millisecond * 50)
+	begin := time.Now()
+	assert.ErrorIs(t, p.WaitJSObject("test"), TaskTimeout)
+	assert.Less(t, time.Since(begin), time.Second)
+}
+
+func Test_Duplicate_Opens_Same_URL_Sharing_Cookies(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval("() => document.cookie = 'session=test'")
+	p.SetDefaultTimeout(time.Second)
+
+	dup, err := p.Duplicate()
+	assert.NoError(t, err)
+	t.Cleanup(dup.CleanUp)
+	dup.MustWaitLoad()
+	assert.Equal(t, p.MustInfo().URL, dup.MustInfo().URL)
+	assert.NotEqual(t, p.TargetID, dup.TargetID)
+	assert.Contains(t, dup.MustEval("() => document.cookie").String(), "session=test")
+	assert.Equal(t, p.defaultTimeout(), dup.defaultTimeout())
+}
+
+func Test_Duplicate_Carries_Headers_And_Init_Scripts(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	assert.NoError(t, p.SetExtraHeaders(map[string]string{"X-Test": "dup"}))
+	_, err := p.AddInitScript("window.initialized = true")
+	assert.NoError(t, err)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	dup, err := p.Duplicate()
+	assert.NoError(t, err)
+	t.Cleanup(dup.CleanUp)
+	dup.MustWaitLoad()
+	assert.True(t, dup.MustEval("() => window.initialized === true").Bool())
+	requests := s.Requests()
+	assert.Equal(t, "dup", requests[len(requests)-1].Header.Get("X-Test"))
+}
+
+func Test_copySettings_Copies_Settings_Of_Page(t *testing.T) {
+	p, dup := detachedPage(context.Background()), detachedPage(context.Background())
+	p.SetDefaultTimeout(time.Second)
+	p.SetPollInterval(time.Millisecond * 10)
+	p.SetHumanInput(true)
+	p.SetFailOnHTTPError(true)
+	p.SetHistoryLimit(5)
+	p.SetRateLimiter(NewHostRateLimiter(1, 1))
+	assert.NoError(t, p.copySettings(dup))
+	assert.Equal(t, time.Second, dup.defaultTimeout())
+	assert.Equal(t, time.Millisecond*10, dup.pollInterval())
+	assert.True(t, dup.human)
+	assert.True(t, dup.failsOnHTTPError())
+	assert.Equal(t, 5, dup.historyLimit)
+	assert.Equal(t, p.rateLimiter(), dup.rateLimiter())
+	assert.Same(t, p.authenticator(), dup.authenticator())
+}
+
+func Test_Duplicate_Returns_Err_When_Page_Closed(t *testing.T) {
+	_, p, _ := setup(t)
+	p.CleanUp()
+	dup, err := p.Duplicate()
+	assert.Nil(t, dup)
+	assert.Error(t, err)
+}
+
 func Test_ClickNavigate_Returns_Err_When_Fail_Wait_Visible(t *testing.T) {
 	_, p, _ := setup(t)
 	p.CleanUp()
@@ -251,6 +342,30 @@ func Test_WaitJSObjectFor_Returns_Err_When_Timeout(t *testing.T) {
 	assert.ErrorIs(t, err, TaskTimeout)
 }
 
+func Test_WaitJSObjectFor_Returns_WaitFailed_When_ObjName_Is_Invalid(t *testing.T) {
+	p := newPage(nil, func() {})
+	for _, name := range []string{"a.", "window['a']", "a; alert(1)", "1a"} {
+		assert.ErrorIs(t, p.WaitJSObjectForContext(context.Background(), name, time.Second), WaitFailed, name)
+	}
+}
+
+func Test_WaitJSObjectFor_Polls_Dotted_Path(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetPollInterval(10 * time.Millisecond)
+	p.MustEval(`() => { window.app = {}; setTimeout(() => { window.app.ready = true }, 100) }`)
+	begin := time.Now()
+	assert.NoError(t, p.WaitJSObjectFor("window.app.ready", time.Second))
+	assert.GreaterOrEqual(t, time.Since(begin), 90*time.Millisecond)
+}
+
+func Test_WaitJSObjectFor_Survives_Navigation(t *testing.T) {
+	_, p, s := setup(t, []byte(`<html><script>if (location.search === '?next') { var config = {loaded: true} }</script></html>`))
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`url => setTimeout(() => { location.href = url }, 50)`, s.URL+"?next")
+	assert.NoError(t, p.WaitJSObjectFor("config.loaded", 3*time.Second))
+}
+
 func Test_WaitJSObjectFor_Returns_No_Err_When_ObjName_Is_Empty(t *testing.T) {
 	_, p, _ := setup(t, testfile.BlankHTML)
 	assert.NoError(t, p.WaitJSObjectFor("", 0))
@@ -331,3 +446,228 @@ func getInterval(d time.Duration, n int) time.Duration {
 	}
 	return time.Duration(d.Nanoseconds() / int64(n))
 }
+
+func Benchmark_TryNavigate(b *testing.B) {
+	p, s := setupBenchmark(b, testfile.ItemsHTML)
+	pred := func(p *Page) bool { return true }
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := p.TryNavigate(s.URL, pred, time.Millisecond); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(runtime.NumGoroutine()), "goroutines")
+}
+
+func Benchmark_TryInput(b *testing.B) {
+	p, s := setupBenchmark(b, testfile.InputTestHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := p.TryInput("#item0", "hello world"); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(runtime.NumGoroutine()), "goroutines")
+}
+
+func Test_recoverError_Recovers_Error_Panic(t *testing.T) {
+	err := func() (err error) {
+		defer recoverError(&err)
+		panic(context.DeadlineExceeded)
+	}()
+	assert.ErrorIs(t, err, TaskTimeout)
+}
+
+func Test_recoverError_Propagates_Non_Error_Panic(t *testing.T) {
+	assert.PanicsWithValue(t, "boom", func() {
+		var err error
+		defer recoverError(&err)
+		panic("boom")
+	})
+}
+
+func Test_Page_States_Are_Safe_For_Concurrent_Access(t *testing.T) {
+	p := newPage(nil, func() {})
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: fmt.Sprint(i)})
+			p.SetDefaultTimeout(time.Duration(i) * time.Millisecond)
+			p.Record(NewRecorder())
+			_ = p.Dialogs()
+			_ = p.defaultTimeout()
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, p.Dialogs(), 10)
+}
+
+func Test_Dialogs_Returns_Copy(t *testing.T) {
+	p := newPage(nil, func() {})
+	p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: "first"})
+	dialogs := p.Dialogs()
+	dialogs[0] = nil
+	assert.NotNil(t, p.Dialogs()[0])
+}
+
+func Test_TryNavigateContext_Returns_Err_When_Context_Done(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	err := p.TryNavigateContext(ctx, s.URL, func(p *Page) bool { return false }, time.Millisecond*10)
+	assert.ErrorIs(t, err, TaskTimeout)
+	assert.NoError(t, p.GetContext().Err(), "expected page to be intact")
+}
+
+func Test_WaitVisibleElementContext_Returns_Err_When_Context_Canceled(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := p.WaitVisibleElementContext(ctx, "li")
+	assert.Error(t, err)
+}
+
+func Test_WaitJSObjectForContext_Returns_Err_When_Context_Canceled(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond*50, cancel)
+	err := p.WaitJSObjectForContext(ctx, "test", time.Second*5)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_TryInputContext_Inputs_Text(t *testing.T) {
+	_, p, s := setup(t, testfile.InputTestHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	assert.NoError(t, p.TryInputContext(ctx, "#item0", "hello"))
+	assert.Equal(t, "hello", p.MustElement("#item0").MustText())
+}
+
+func Test_SaveDialog_Overwrites_Oldest_Once_Limit_Reached(t *testing.T) {
+	p := newPage(nil, func() {})
+	p.SetDialogLimit(2)
+	for _, message := range []string{"first", "second", "third", "fourth", "fifth"} {
+		p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: message})
+	}
+	dialogs := p.Dialogs()
+	if assert.Len(t, dialogs, 2) {
+		assert.Equal(t, "fourth", dialogs[0].Message)
+		assert.Equal(t, "fifth", dialogs[1].Message)
+	}
+}
+
+func Test_SetDialogLimit_Keeps_Latest_Dialogs(t *testing.T) {
+	p := newPage(nil, func() {})
+	p.SetDialogLimit(3)
+	for _, message := range []string{"first", "second", "third", "fourth"} {
+		p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: message})
+	}
+	p.SetDialogLimit(2)
+	dialogs := p.Dialogs()
+	if assert.Len(t, dialogs, 2) {
+		assert.Equal(t, "third", dialogs[0].Message)
+		assert.Equal(t, "fourth", dialogs[1].Message)
+	}
+	p.SetDialogLimit(0)
+	for i := 0; i < defaultDialogLimit*2; i++ {
+		p.SaveDialog(&proto.PageJavascriptDialogOpening{})
+	}
+	assert.Len(t, p.Dialogs(), defaultDialogLimit*2+2)
+}
+
+func Test_ClearDialogs_Removes_All_Dialogs(t *testing.T) {
+	p := newPage(nil, func() {})
+	p.SetDialogLimit(1)
+	p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: "first"})
+	p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: "second"})
+	p.ClearDialogs()
+	assert.Empty(t, p.Dialogs())
+	p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: "third"})
+	assert.Equal(t, "third", p.Dialogs()[0].Message)
+}
+
+func Test_SetPollInterval_Falls_Back_To_Default(t *testing.T) {
+	p := newPage(nil, func() {})
+	assert.Equal(t, defaultPollInterval, p.pollInterval())
+	p.SetPollInterval(time.Millisecond)
+	assert.Equal(t, time.Millisecond, p.pollInterval())
+	p.SetPollInterval(0)
+	assert.Equal(t, defaultPollInterval, p.pollInterval())
+}
+
+func Test_WaitElementFor_Returns_Element_Once_Appeared(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetPollInterval(time.Millisecond * 10)
+	p.MustEval(`() => setTimeout(() => document.body.appendChild(document.createElement("article")), 100)`)
+	el, err := p.WaitElementFor("article", time.Second*5)
+	assert.NoError(t, err)
+	if assert.NotNil(t, el) {
+		assert.Equal(t, "ARTICLE", el.MustEval(`() => this.tagName`).Str())
+	}
+}
+
+func Test_WaitElementFor_Returns_ElementMissing_On_Timeout(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	_, err := p.WaitElementFor("#never", time.Millisecond*100)
+	assert.ErrorIs(t, err, ElementMissing)
+}
+
+func Test_examine_Recovers_Error_From_Predicate(t *testing.T) {
+	ok, err := examine(1, func(i int) bool { return i == 1 })
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	ok, err = examine(1, func(i int) bool { panic(elementError(opFind, "li", ElementMissing)) })
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, ElementMissing)
+}
+
+func Test_attemptError_Tells_URL_And_Attempt(t *testing.T) {
+	err := attemptError(context.Canceled, "http://test.local", 3)
+	assert.ErrorIs(t, err, context.Canceled)
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, ActionNavigate, e.Op)
+		assert.Equal(t, "http://test.local", e.URL)
+		assert.Equal(t, 3, e.Attempt)
+	}
+}
+
+func Test_TryNavigate_Returns_Err_From_Predicate_With_Attempts(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	attempts := 0
+	err := p.TryNavigate(s.URL, func(p *Page) bool {
+		if attempts++; attempts < 2 {
+			return false
+		}
+		p.Timeout(time.Millisecond * 10).MustElement("#never")
+		return true
+	}, time.Millisecond)
+	assert.ErrorIs(t, err, TaskTimeout)
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, 2, e.Attempt)
+	}
+}
+
+func Test_TryInputSecret_Records_Placeholder(t *testing.T) {
+	_, p, s := setup(t, testfile.InputTestHTML)
+	p.MustNavigate(s.URL)
+	assert.NoError(t, p.TryInputSecret("#item0", "secret"))
+	assert.Equal(t, "secret", p.MustElement("#item0").MustProperty("value").Str())
+	history := p.History()
+	if assert.NotEmpty(t, history) {
+		assert.Equal(t, redactedText, history[len(history)-1].Text)
+	}
+}
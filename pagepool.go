@@ -1,5 +1,7 @@
 package chromium
 
+import "context"
+
 type PagePool chan *Page
 
 func (p PagePool) CleanUp() {
@@ -13,6 +15,27 @@ func (p PagePool) Get() *Page {
 	return <-p
 }
 
+// GetContext returns a page from the pool, or an error once the context is done before a page is available.
+// TaskTimeout is returned when the deadline of the context has exceeded.
+func (p PagePool) GetContext(ctx context.Context) (*Page, error) {
+	select {
+	case page := <-p:
+		return page, nil
+	case <-ctx.Done():
+		return nil, replaceTimeoutError(ctx.Err())
+	}
+}
+
+// TryGet returns a page from the pool without blocking, reporting false when no page is available.
+func (p PagePool) TryGet() (*Page, bool) {
+	select {
+	case page := <-p:
+		return page, true
+	default:
+		return nil, false
+	}
+}
+
 func (p PagePool) Put(page *Page) {
 	p <- page
 }
@@ -1,8 +1,10 @@
 package chromium
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestPagePool_CleanUp(t *testing.T) {
@@ -13,7 +15,9 @@ func TestPagePool_CleanUp(t *testing.T) {
 
 	count := 0
 	for i := 0; i < cap(pool); i++ {
-		pool <- newPage(b.MustPage(), func() { count++ })
+		p, err := NewPage(b.MustPage(), WithDoneCallback(func() { count++ }))
+		assert.NoError(t, err)
+		pool <- p
 	}
 
 	assert.NotPanics(t, pool.CleanUp)
@@ -24,7 +28,7 @@ func TestPagePool_MustReflectQueuePoll(t *testing.T) {
 	pool := make(PagePool, 5)
 	pages := make([]*Page, 5)
 	for i := 0; i < 5; i++ {
-		p := &Page{}
+		p := newPage(nil, func() {})
 		pages[i] = p
 		pool.Put(p)
 	}
@@ -32,3 +36,27 @@ func TestPagePool_MustReflectQueuePoll(t *testing.T) {
 		assert.Equal(t, pages[i], pool.Get())
 	}
 }
+
+func TestPagePool_TryGet_Returns_False_When_Empty(t *testing.T) {
+	pool := make(PagePool, 1)
+	_, ok := pool.TryGet()
+	assert.False(t, ok)
+	page := newPage(nil, func() {})
+	pool.Put(page)
+	got, ok := pool.TryGet()
+	assert.True(t, ok)
+	assert.Equal(t, page, got)
+}
+
+func TestPagePool_GetContext_Returns_Err_When_Context_Done(t *testing.T) {
+	pool := make(PagePool, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	_, err := pool.GetContext(ctx)
+	assert.ErrorIs(t, err, TaskTimeout)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = pool.GetContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
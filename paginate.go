@@ -0,0 +1,90 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxPaginateBackoff caps the backoff between examinations of the predicate.
+const maxPaginateBackoff = 2 * time.Second
+
+// nextDisabledJS tells whether the element of the next button is disabled, natively or by ARIA.
+const nextDisabledJS = `function() { return this.disabled === true || this.getAttribute('aria-disabled') === 'true' }`
+
+// Paginate calls fn with the current state of the page as index 0, then clicks the element matching nextSel and waits
+// for the predicate to match before calling fn with the next index, until the next element is missing or disabled,
+// or fn has been called limit times. Zero or negative limit paginates until the last page.
+// The predicate must tell that the next page has been shown, such as by its page number, as it is examined right
+// after the click. It is examined again with a backoff growing by the poll interval while it does not match
+// or fails, such as during a navigation, until the default timeout of the page elapses.
+// The next selector may be of any kind of Selector, such as "text=Next".
+// An error from fn stops the pagination and is returned as is.
+func (p *Page) Paginate(nextSel string, pred Predicate[*Page], limit int, fn func(index int, p *Page) error) error {
+	return p.PaginateContext(p.GetContext(), nextSel, pred, limit, fn)
+}
+
+// PaginateContext is Paginate that also stops once ctx is done.
+// It returns an error wrapping ClickFailed if the next element cannot be clicked, or TaskTimeout if the predicate
+// does not match in time, telling the last error of the predicate if any.
+func (p *Page) PaginateContext(ctx context.Context, nextSel string, pred Predicate[*Page], limit int, fn func(index int, p *Page) error) error {
+	for index := 0; limit <= 0 || index < limit; index++ {
+		if index > 0 {
+			ok, err := p.nextPage(ctx, nextSel, pred)
+			if err != nil {
+				return &Error{Op: opPaginate, Selector: nextSel, Err: err}
+			} else if !ok {
+				return nil
+			}
+		}
+		if err := fn(index, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextPage clicks the next element, then waits for the predicate to match. It reports false if there is no next page.
+func (p *Page) nextPage(ctx context.Context, nextSel string, pred Predicate[*Page]) (bool, error) {
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	ok, el, err := has(p.Context(ctx), nextSel)
+	if err != nil {
+		return false, replaceTimeoutError(replaceAbortedError(err))
+	} else if !ok {
+		return false, nil
+	}
+	if disabled, err := el.Eval(nextDisabledJS); err != nil {
+		return false, replaceTimeoutError(replaceAbortedError(err))
+	} else if disabled.Value.Bool() {
+		return false, nil
+	}
+	if err = p.click(el); err != nil {
+		return false, classify(ClickFailed, err)
+	}
+	return true, p.awaitPredicate(ctx, pred)
+}
+
+// awaitPredicate examines the predicate with a backoff growing by the poll interval up to maxPaginateBackoff,
+// until it matches or ctx is done.
+func (p *Page) awaitPredicate(ctx context.Context, pred Predicate[*Page]) error {
+	interval := p.pollInterval()
+	var last error
+	for delay := interval; ; delay += interval {
+		if delay > maxPaginateBackoff {
+			delay = maxPaginateBackoff
+		}
+		ok, err := examine(p, pred)
+		if ok && err == nil {
+			return nil
+		}
+		last = err
+		if err = p.sleep(ctx, delay); err != nil {
+			if last != nil && errors.Is(err, TaskTimeout) {
+				return fmt.Errorf("%w: %v", TaskTimeout, last)
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package chromium
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const paginateHTML = `<html><body>
+<p id="page">1</p>
+<button id="next">next</button>
+<script>
+	let page = 1;
+	document.getElementById('next').addEventListener('click', () => setTimeout(() => {
+		page++;
+		document.getElementById('page').textContent = String(page);
+		document.getElementById('next').disabled = page === 3;
+	}, 50));
+</script>
+</body></html>`
+
+// pageNumber returns a predicate that matches once the page shows the number next to the last one seen.
+func pageNumber(seen *int) Predicate[*Page] {
+	return func(p *Page) bool {
+		return p.MustElement("#page").MustText() == strconv.Itoa(*seen+1)
+	}
+}
+
+func Test_Paginate_Visits_Pages_Until_Next_Is_Disabled(t *testing.T) {
+	_, p, s := setup(t, []byte(paginateHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	seen, indices := 0, make([]int, 0)
+	err := p.Paginate("#next", pageNumber(&seen), 0, func(index int, p *Page) error {
+		seen, _ = strconv.Atoi(p.MustElement("#page").MustText())
+		indices = append(indices, index)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, indices)
+	assert.Equal(t, 3, seen)
+}
+
+func Test_Paginate_Accepts_Selector_Kinds(t *testing.T) {
+	_, p, s := setup(t, []byte(paginateHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	seen, calls := 0, 0
+	err := p.Paginate(ByText("next"), pageNumber(&seen), 0, func(index int, p *Page) error {
+		seen, _ = strconv.Atoi(p.MustElement("#page").MustText())
+		calls++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func Test_Paginate_Stops_By_Limit_And_Error(t *testing.T) {
+	_, p, s := setup(t, []byte(paginateHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	seen, calls := 0, 0
+	err := p.Paginate("#next", pageNumber(&seen), 2, func(index int, p *Page) error {
+		seen, _ = strconv.Atoi(p.MustElement("#page").MustText())
+		calls++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	stop := errors.New("stop")
+	err = p.Paginate("#next", pageNumber(&seen), 0, func(index int, p *Page) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+}
+
+func Test_Paginate_Times_Out_If_Predicate_Never_Matches(t *testing.T) {
+	_, p, s := setup(t, []byte(paginateHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetDefaultTimeout(300 * time.Millisecond)
+
+	err := p.Paginate("#next", func(p *Page) bool { return false }, 0, func(index int, p *Page) error { return nil })
+	assert.True(t, errors.Is(err, TaskTimeout))
+}
@@ -0,0 +1,84 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/gson"
+	"io"
+)
+
+// PaperSize is a size of paper in inches.
+type PaperSize struct {
+	Width  float64
+	Height float64
+}
+
+var (
+	PaperLetter = PaperSize{Width: 8.5, Height: 11}
+	PaperLegal  = PaperSize{Width: 8.5, Height: 14}
+	PaperA3     = PaperSize{Width: 11.69, Height: 16.54}
+	PaperA4     = PaperSize{Width: 8.27, Height: 11.69}
+	PaperA5     = PaperSize{Width: 5.83, Height: 8.27}
+)
+
+// Margins are margins of a printed page in inches.
+type Margins struct {
+	Top    float64
+	Bottom float64
+	Left   float64
+	Right  float64
+}
+
+// PDFOptions configures PDF. Zero values are left to the defaults of the browser,
+// which prints on Letter paper with margins of about 0.4 inches.
+type PDFOptions struct {
+	Paper     PaperSize
+	Margins   *Margins
+	Landscape bool
+	// Scale of the rendering, ranging from 0.1 to 2.
+	Scale float64
+	// PrintBackground prints background graphics.
+	PrintBackground bool
+	// PageRanges such as "1-5, 8" prints only given pages.
+	PageRanges string
+	// HeaderTemplate and FooterTemplate are HTML printed on each page, which may contain elements of classes
+	// date, title, url, pageNumber and totalPages to be filled with their values.
+	// Either of them being set displays both header and footer.
+	HeaderTemplate string
+	FooterTemplate string
+	// PreferCSSPageSize lets @page size of the document take precedence over Paper.
+	PreferCSSPageSize bool
+}
+
+// PDF prints this page by the options, then returns the reader of the document.
+// Note that it shadows PDF of rod.Page, which is still available via the embedded page.
+func (p *Page) PDF(opts PDFOptions) (io.Reader, error) {
+	r, err := p.Page.PDF(printRequest(opts))
+	if err != nil {
+		return nil, replaceTimeoutError(replaceAbortedError(err))
+	}
+	return r, nil
+}
+
+// printRequest converts the options into the request of DevTools protocol.
+func printRequest(opts PDFOptions) *proto.PagePrintToPDF {
+	req := &proto.PagePrintToPDF{
+		Landscape:         opts.Landscape,
+		PrintBackground:   opts.PrintBackground,
+		PageRanges:        opts.PageRanges,
+		HeaderTemplate:    opts.HeaderTemplate,
+		FooterTemplate:    opts.FooterTemplate,
+		PreferCSSPageSize: opts.PreferCSSPageSize,
+	}
+	req.DisplayHeaderFooter = len(opts.HeaderTemplate) > 0 || len(opts.FooterTemplate) > 0
+	if opts.Scale > 0 {
+		req.Scale = gson.Num(opts.Scale)
+	}
+	if opts.Paper.Width > 0 && opts.Paper.Height > 0 {
+		req.PaperWidth, req.PaperHeight = gson.Num(opts.Paper.Width), gson.Num(opts.Paper.Height)
+	}
+	if m := opts.Margins; m != nil {
+		req.MarginTop, req.MarginBottom = gson.Num(m.Top), gson.Num(m.Bottom)
+		req.MarginLeft, req.MarginRight = gson.Num(m.Left), gson.Num(m.Right)
+	}
+	return req
+}
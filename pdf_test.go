@@ -0,0 +1,43 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_printRequest_Leaves_Defaults_For_Zero_Options(t *testing.T) {
+	req := printRequest(PDFOptions{})
+	assert.Nil(t, req.Scale)
+	assert.Nil(t, req.PaperWidth)
+	assert.Nil(t, req.MarginTop)
+	assert.False(t, req.DisplayHeaderFooter)
+}
+
+func Test_printRequest_Converts_Options(t *testing.T) {
+	req := printRequest(PDFOptions{
+		Paper:          PaperA4,
+		Margins:        &Margins{Top: 1, Bottom: 2, Left: 3, Right: 4},
+		Landscape:      true,
+		Scale:          0.5,
+		FooterTemplate: `<span class="pageNumber"></span>`,
+	})
+	assert.True(t, req.Landscape)
+	assert.True(t, req.DisplayHeaderFooter)
+	assert.Equal(t, 8.27, *req.PaperWidth)
+	assert.Equal(t, 11.69, *req.PaperHeight)
+	assert.Equal(t, 1.0, *req.MarginTop)
+	assert.Equal(t, 4.0, *req.MarginRight)
+	assert.Equal(t, 0.5, *req.Scale)
+}
+
+func Test_PDF_Prints_Document(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	r, err := p.PDF(PDFOptions{Paper: PaperA4, Landscape: true, HeaderTemplate: `<span class="title"></span>`})
+	assert.NoError(t, err)
+	data, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF", string(data[:4]))
+}
@@ -0,0 +1,128 @@
+package chromium
+
+import (
+	"time"
+)
+
+// NavigationTiming is the timing of the latest navigation of a page, as of the Navigation Timing and Paint Timing
+// APIs of the page. Durations of phases tell how long each phase took, whereas the others tell when each event occurred
+// since the navigation began. Timings that have not occurred yet, such as load before the page is loaded, are zero,
+// and so are timings of phases that have been skipped, such as DNS lookup of a cached host.
+type NavigationTiming struct {
+	URL string // URL of the document.
+
+	DNS      time.Duration // duration of the DNS lookup.
+	Connect  time.Duration // duration of the connection, including TLS.
+	TLS      time.Duration // duration of the TLS handshake.
+	Request  time.Duration // duration from sending the request until the first byte of the response.
+	Response time.Duration // duration of downloading the response.
+
+	TTFB                   time.Duration // time to the first byte of the response.
+	DOMInteractive         time.Duration // time that the document has been parsed.
+	DOMContentLoaded       time.Duration // time that DOMContentLoaded handlers have finished.
+	Load                   time.Duration // time that load handlers have finished.
+	FirstPaint             time.Duration // time of the first paint.
+	FirstContentfulPaint   time.Duration // time of the first paint of any content, such as text or an image.
+	LargestContentfulPaint time.Duration // time of the paint of the largest content so far.
+
+	TransferSize int64 // size of the response including its headers, which is zero if served from the cache.
+}
+
+// navigationTimingJS reads the timings in milliseconds, waiting a frame for buffered LCP entries to be delivered.
+const navigationTimingJS = `() => new Promise(resolve => {
+	const nav = performance.getEntriesByType('navigation')[0] || {};
+	const paint = name => (performance.getEntriesByName(name, 'paint')[0] || {}).startTime || 0;
+	const phase = (start, end) => (nav[start] > 0 && nav[end] >= nav[start]) ? nav[end] - nav[start] : 0;
+	const timing = {
+		url: nav.name || location.href,
+		dns: phase('domainLookupStart', 'domainLookupEnd'),
+		connect: phase('connectStart', 'connectEnd'),
+		tls: phase('secureConnectionStart', 'connectEnd'),
+		request: phase('requestStart', 'responseStart'),
+		response: phase('responseStart', 'responseEnd'),
+		ttfb: nav.responseStart || 0,
+		domInteractive: nav.domInteractive || 0,
+		domContentLoaded: nav.domContentLoadedEventEnd || 0,
+		load: nav.loadEventEnd || 0,
+		firstPaint: paint('first-paint'),
+		firstContentfulPaint: paint('first-contentful-paint'),
+		largestContentfulPaint: 0,
+		transferSize: nav.transferSize || 0,
+	};
+	if (!PerformanceObserver.supportedEntryTypes || !PerformanceObserver.supportedEntryTypes.includes('largest-contentful-paint')) {
+		resolve(timing);
+		return;
+	}
+	const observer = new PerformanceObserver(list => {
+		const entries = list.getEntries();
+		if (entries.length > 0) {
+			timing.largestContentfulPaint = entries[entries.length - 1].startTime;
+		}
+	});
+	observer.observe({type: 'largest-contentful-paint', buffered: true});
+	requestAnimationFrame(() => setTimeout(() => {
+		observer.takeRecords().forEach(e => timing.largestContentfulPaint = e.startTime);
+		observer.disconnect();
+		resolve(timing);
+	}, 0));
+})`
+
+// navigationTimingMillis is the result of navigationTimingJS.
+type navigationTimingMillis struct {
+	URL                    string  `json:"url"`
+	DNS                    float64 `json:"dns"`
+	Connect                float64 `json:"connect"`
+	TLS                    float64 `json:"tls"`
+	Request                float64 `json:"request"`
+	Response               float64 `json:"response"`
+	TTFB                   float64 `json:"ttfb"`
+	DOMInteractive         float64 `json:"domInteractive"`
+	DOMContentLoaded       float64 `json:"domContentLoaded"`
+	Load                   float64 `json:"load"`
+	FirstPaint             float64 `json:"firstPaint"`
+	FirstContentfulPaint   float64 `json:"firstContentfulPaint"`
+	LargestContentfulPaint float64 `json:"largestContentfulPaint"`
+	TransferSize           int64   `json:"transferSize"`
+}
+
+// Metrics returns the timing of the latest navigation of this page, such that the package can double as a synthetic
+// monitoring tool. Read it once the page is loaded, such as after WaitLoad, for the timings of load to be present.
+// Note that the largest contentful paint stops being updated once the user interacts with the page.
+func (p *Page) Metrics() (NavigationTiming, error) {
+	ctx, cancel := p.timeoutContext()
+	defer cancel()
+	obj, err := p.Context(ctx).Eval(navigationTimingJS)
+	if err != nil {
+		return NavigationTiming{}, replaceTimeoutError(replaceAbortedError(err))
+	}
+	var ms navigationTimingMillis
+	if err = decodeJSON(obj, &ms); err != nil {
+		return NavigationTiming{}, classify(DecodeFailed, err)
+	}
+	return ms.timing(), nil
+}
+
+// timing converts the timings in milliseconds to NavigationTiming.
+func (ms navigationTimingMillis) timing() NavigationTiming {
+	return NavigationTiming{
+		URL:                    ms.URL,
+		DNS:                    millis(ms.DNS),
+		Connect:                millis(ms.Connect),
+		TLS:                    millis(ms.TLS),
+		Request:                millis(ms.Request),
+		Response:               millis(ms.Response),
+		TTFB:                   millis(ms.TTFB),
+		DOMInteractive:         millis(ms.DOMInteractive),
+		DOMContentLoaded:       millis(ms.DOMContentLoaded),
+		Load:                   millis(ms.Load),
+		FirstPaint:             millis(ms.FirstPaint),
+		FirstContentfulPaint:   millis(ms.FirstContentfulPaint),
+		LargestContentfulPaint: millis(ms.LargestContentfulPaint),
+		TransferSize:           ms.TransferSize,
+	}
+}
+
+// millis converts the milliseconds of the Performance API, which may be fractional, to a duration.
+func millis(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
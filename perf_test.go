@@ -0,0 +1,33 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_navigationTimingMillis_Converts_To_Durations(t *testing.T) {
+	timing := navigationTimingMillis{URL: "https://example.com/", DNS: 1.5, TTFB: 20, Load: 120.25, TransferSize: 512}.timing()
+	assert.Equal(t, NavigationTiming{
+		URL:          "https://example.com/",
+		DNS:          1500 * time.Microsecond,
+		TTFB:         20 * time.Millisecond,
+		Load:         120250 * time.Microsecond,
+		TransferSize: 512,
+	}, timing)
+}
+
+func Test_Metrics_Returns_Navigation_Timing(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	timing, err := p.Metrics()
+	assert.NoError(t, err)
+	assert.Equal(t, s.URL+"/", timing.URL)
+	assert.Positive(t, timing.TTFB)
+	assert.GreaterOrEqual(t, timing.DOMContentLoaded, timing.DOMInteractive)
+	assert.GreaterOrEqual(t, timing.Load, timing.DOMContentLoaded)
+	assert.Positive(t, timing.FirstContentfulPaint)
+	assert.Positive(t, timing.TransferSize)
+}
@@ -0,0 +1,40 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"net/url"
+)
+
+// GrantPermissions grants the permissions to the origin, such as geolocation, notifications, clipboard access by
+// proto.BrowserPermissionTypeClipboardReadWrite, or camera and microphone by proto.BrowserPermissionTypeVideoCapture
+// and proto.BrowserPermissionTypeAudioCapture, such that permission prompts never block automation.
+// The origin may be a URL such as "https://example.com/path", of which only the origin is taken, and empty origin
+// grants the permissions to all origins. Permissions that are not granted are left as they are, including the ones
+// granted by previous calls.
+// Note that the permissions are granted to the browser context of this page, thus shared with the pages of the same
+// browser context, which are all the pages that are not isolated. They are kept until ResetPermissions is called.
+func (p *Page) GrantPermissions(origin string, perms ...proto.BrowserPermissionType) error {
+	if len(perms) == 0 {
+		return nil
+	}
+	return replaceAbortedError(proto.BrowserGrantPermissions{
+		Permissions:      perms,
+		Origin:           originOf(origin),
+		BrowserContextID: p.Browser().BrowserContextID,
+	}.Call(p.Browser()))
+}
+
+// ResetPermissions resets the permissions of the browser context of this page, as granted by GrantPermissions.
+func (p *Page) ResetPermissions() error {
+	return replaceAbortedError(proto.BrowserResetPermissions{BrowserContextID: p.Browser().BrowserContextID}.Call(p.Browser()))
+}
+
+// originOf returns the origin of the URL, such as "https://example.com" of "https://example.com/path",
+// or the URL as is if it has no origin.
+func originOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
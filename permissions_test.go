@@ -0,0 +1,37 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// geolocationStateJS tells the state of the permission of geolocation on the current origin.
+const geolocationStateJS = `() => navigator.permissions.query({name: 'geolocation'}).then(s => s.state)`
+
+func Test_originOf_Takes_Origin_Of_URL(t *testing.T) {
+	assert.Equal(t, "https://example.com:8443", originOf("https://example.com:8443/path?q=1"))
+	assert.Equal(t, "", originOf(""))
+	assert.Equal(t, "example.com", originOf("example.com"))
+}
+
+func Test_GrantPermissions_Grants_To_Origin_Of_URL(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.GrantPermissions(s.URL+"/path", proto.BrowserPermissionTypeGeolocation))
+	assert.Equal(t, "granted", p.MustEval(geolocationStateJS).String())
+}
+
+func Test_GrantPermissions_Accepts_No_Permission(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.GrantPermissions(s.URL))
+}
+
+func Test_ResetPermissions_Reverts_Grants(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.GrantPermissions(s.URL, proto.BrowserPermissionTypeGeolocation))
+	assert.NoError(t, p.ResetPermissions())
+	assert.Equal(t, "prompt", p.MustEval(geolocationStateJS).String())
+}
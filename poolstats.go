@@ -0,0 +1,86 @@
+package chromium
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// PoolStats is a snapshot of the page pool of a Browser.
+type PoolStats struct {
+	InUse        int           // number of pages checked out of the pool.
+	Idle         int           // number of pages waiting in the pool.
+	Waiting      int           // number of callers blocked until a page is available.
+	Acquisitions int64         // number of pages handed out so far.
+	AverageHold  time.Duration // average duration pages were held until put back.
+	Replaced     int           // number of dead pages that have been replaced by fresh ones.
+}
+
+// poolUsage tracks how pages of the pool are used, which is guarded by the mutex of the browser.
+type poolUsage struct {
+	checkouts    map[*Page]*checkout // pages checked out of the pool.
+	acquisitions int64
+	releases     int64
+	held         time.Duration // sum of durations of released pages.
+	waiting      int
+	replaced     int
+}
+
+// checkout is a record of a page checked out of the pool.
+type checkout struct {
+	at       time.Time
+	stack    []byte // stack of the borrower, which is captured only if leak detection is enabled.
+	reported bool   // whether the checkout has been reported as a leak.
+}
+
+// PoolStats returns the statistics of the page pool of this browser.
+// Pages used by tasks of Submit are counted as well.
+func (b *Browser) PoolStats() PoolStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := PoolStats{
+		InUse:        len(b.usage.checkouts),
+		Idle:         len(b.pagePool),
+		Waiting:      b.usage.waiting,
+		Acquisitions: b.usage.acquisitions,
+		Replaced:     b.usage.replaced,
+	}
+	if b.usage.releases > 0 {
+		stats.AverageHold = b.usage.held / time.Duration(b.usage.releases)
+	}
+	return stats
+}
+
+// acquired records the checkout of the page.
+func (b *Browser) acquired(p *Page) {
+	c := &checkout{at: time.Now()}
+	if b.leakThreshold > 0 {
+		c.stack = debug.Stack()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.usage.checkouts == nil {
+		b.usage.checkouts = make(map[*Page]*checkout)
+	}
+	b.usage.checkouts[p] = c
+	b.usage.acquisitions++
+}
+
+// released records the return of the page, which is ignored if the page has not been checked out.
+func (b *Browser) released(p *Page) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.usage.checkouts[p]
+	if !ok {
+		return
+	}
+	delete(b.usage.checkouts, p)
+	b.usage.releases++
+	b.usage.held += time.Since(c.at)
+}
+
+// wait adds delta to the number of callers waiting for a page.
+func (b *Browser) wait(delta int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.usage.waiting += delta
+}
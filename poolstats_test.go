@@ -0,0 +1,51 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_PoolStats_Tracks_Checkouts(t *testing.T) {
+	b := newTaskBrowser(2, 1)
+	assert.Equal(t, PoolStats{Idle: 2}, b.PoolStats())
+
+	p := b.GetPage()
+	stats := b.PoolStats()
+	assert.Equal(t, 1, stats.InUse)
+	assert.Equal(t, 1, stats.Idle)
+	assert.Equal(t, int64(1), stats.Acquisitions)
+
+	time.Sleep(time.Millisecond * 10)
+	b.PutPage(p)
+	stats = b.PoolStats()
+	assert.Zero(t, stats.InUse)
+	assert.Equal(t, 2, stats.Idle)
+	assert.GreaterOrEqual(t, stats.AverageHold, time.Millisecond*10)
+}
+
+func Test_PoolStats_Counts_Waiting_Callers(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	p := b.GetPage()
+	got := make(chan *Page)
+	go func() { got <- b.GetPage() }()
+	assert.Eventually(t, func() bool { return b.PoolStats().Waiting == 1 }, time.Second, time.Millisecond)
+	b.PutPage(p)
+	assert.Equal(t, p, <-got)
+	assert.Zero(t, b.PoolStats().Waiting)
+}
+
+func Test_PoolStats_Counts_Tasks(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	assert.NoError(t, b.Submit(func(p *Page) error {
+		assert.Equal(t, 1, b.PoolStats().InUse)
+		return nil
+	}).Wait())
+	assert.Equal(t, int64(1), b.PoolStats().Acquisitions)
+}
+
+func Test_released_Ignores_Unknown_Page(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	b.released(newPage(nil, func() {}))
+	assert.Zero(t, b.PoolStats().AverageHold)
+}
@@ -1,4 +1,77 @@
 package chromium
 
+import (
+	"regexp"
+	"strings"
+)
+
 // Predicate is a generic function that examines an item of type T matches or not, mainly to delegate logic for switch.
 type Predicate[T any] func(item T) bool
+
+// And returns a predicate that matches an item only if all of the predicates match it, examined in order.
+// It matches any item if no predicate is given.
+func And[T any](predicates ...Predicate[T]) Predicate[T] {
+	return func(item T) bool {
+		for _, predicate := range predicates {
+			if !predicate(item) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate that matches an item if any of the predicates matches it, examined in order.
+// It matches no item if no predicate is given.
+func Or[T any](predicates ...Predicate[T]) Predicate[T] {
+	return func(item T) bool {
+		for _, predicate := range predicates {
+			if predicate(item) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate that matches an item only if the predicate does not.
+func Not[T any](predicate Predicate[T]) Predicate[T] {
+	return func(item T) bool {
+		return !predicate(item)
+	}
+}
+
+// Predicates of pages below do not match a page once they fail to examine it, such as on a closed page.
+
+// HasSelector matches a page that has any element matching the selector.
+func HasSelector(selector string) Predicate[*Page] {
+	return func(p *Page) bool {
+		found, _, err := has(p.Page, selector)
+		return err == nil && found
+	}
+}
+
+// TitleContains matches a page whose title contains the text.
+func TitleContains(text string) Predicate[*Page] {
+	return func(p *Page) bool {
+		info, err := p.Info()
+		return err == nil && strings.Contains(info.Title, text)
+	}
+}
+
+// URLMatches matches a page whose URL matches the regular expression.
+func URLMatches(re *regexp.Regexp) Predicate[*Page] {
+	return func(p *Page) bool {
+		info, err := p.Info()
+		return err == nil && re.MatchString(info.URL)
+	}
+}
+
+// BodyLongerThan matches a page whose body has more than n characters of text,
+// such that blank responses with 2XX status are rejected.
+func BodyLongerThan(n int) Predicate[*Page] {
+	return func(p *Page) bool {
+		obj, err := p.Eval(`() => document.body ? document.body.innerText.length : 0`)
+		return err == nil && obj.Value.Int() > n
+	}
+}
@@ -0,0 +1,54 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func Test_And_Or_Not_Combine_Predicates(t *testing.T) {
+	positive := Predicate[int](func(i int) bool { return i > 0 })
+	even := Predicate[int](func(i int) bool { return i%2 == 0 })
+
+	assert.True(t, And(positive, even)(2))
+	assert.False(t, And(positive, even)(1))
+	assert.True(t, And[int]()(1))
+
+	assert.True(t, Or(positive, even)(-2))
+	assert.False(t, Or(positive, even)(-1))
+	assert.False(t, Or[int]()(1))
+
+	assert.True(t, Not(positive)(-1))
+	assert.False(t, Not(positive)(1))
+}
+
+func Test_And_Stops_On_First_Mismatch(t *testing.T) {
+	count := 0
+	counted := Predicate[int](func(int) bool { count++; return true })
+	assert.False(t, And(Predicate[int](func(int) bool { return false }), counted)(0))
+	assert.Zero(t, count)
+}
+
+func Test_Page_Predicates_Examine_Page(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => document.title = "Items"`)
+
+	assert.True(t, HasSelector("li")(p))
+	assert.False(t, HasSelector("#never")(p))
+	assert.True(t, TitleContains("Item")(p))
+	assert.False(t, TitleContains("never")(p))
+	assert.True(t, URLMatches(regexp.MustCompile(`^http://`))(p))
+	assert.True(t, BodyLongerThan(0)(p))
+	assert.False(t, BodyLongerThan(1<<20)(p))
+}
+
+func Test_TryNavigate_Accepts_Combined_Predicates(t *testing.T) {
+	items := makeItems(testfile.BlankHTML, testfile.ItemsHTML, 2)
+	_, p, s := setup(t, items...)
+	err := p.TryNavigate(s.URL, And(HasSelector("li"), Not(HasSelector("#never"))), time.Millisecond)
+	assert.NoError(t, err)
+	requestCountMustBeAsExpected(t, s, 3)
+}
@@ -0,0 +1,105 @@
+package chromium
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/time/rate"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RateLimiter paces navigations of pages by the host they navigate to, such that a website is not requested faster
+// than it allows. Wait blocks until a navigation to the host may begin, or returns an error once ctx is done.
+type RateLimiter interface {
+	Wait(ctx context.Context, host string) error
+}
+
+// NewHostRateLimiter returns a RateLimiter that allows rps navigations per second to each host, along with bursts
+// of the size, by token buckets of golang.org/x/time/rate. Burst lower than 1 is treated as 1.
+func NewHostRateLimiter(rps float64, burst int) RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &hostRateLimiter{limit: rate.Limit(rps), burst: burst, mu: &sync.Mutex{}, hosts: make(map[string]*rate.Limiter)}
+}
+
+// hostRateLimiter is a RateLimiter with a token bucket per host.
+type hostRateLimiter struct {
+	limit rate.Limit
+	burst int
+	mu    *sync.Mutex
+	hosts map[string]*rate.Limiter
+}
+
+func (l *hostRateLimiter) Wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	limiter, ok := l.hosts[host]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.hosts[host] = limiter
+	}
+	l.mu.Unlock()
+	return limiter.Wait(ctx)
+}
+
+// WithRateLimiter makes every page of the browser wait for the limiter before TryNavigate, TryNavigateWithPolicy and
+// ClickNavigate navigate, such that pages of the browser share its budget per host, as NewHostRateLimiter provides.
+// Pages out of the pool, such as of NewPageWithProxy and Pages, share the limiter as well.
+func WithRateLimiter(limiter RateLimiter) BrowserOption {
+	return func(o *browserOptions) {
+		o.limiter = limiter
+	}
+}
+
+// SetRateLimiter sets the limiter that this page waits for before TryNavigate, TryNavigateWithPolicy and ClickNavigate
+// navigate. Nil removes the limiter.
+func (p *Page) SetRateLimiter(limiter RateLimiter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limiter = limiter
+}
+
+// rateLimiter returns the limiter of this page, or nil if none.
+func (p *Page) rateLimiter() RateLimiter {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.limiter
+}
+
+// waitRate waits for the limiter of this page to allow a navigation to the URL, if any.
+// It returns TaskTimeout if ctx would be done before then, telling the error of the limiter.
+func (p *Page) waitRate(ctx context.Context, rawURL string) error {
+	limiter := p.rateLimiter()
+	if limiter == nil {
+		return nil
+	}
+	if err := limiter.Wait(ctx, hostOf(rawURL)); err != nil {
+		if ctx.Err() != nil {
+			return replaceTimeoutError(ctx.Err())
+		}
+		return fmt.Errorf("%w: %v", TaskTimeout, err)
+	}
+	return nil
+}
+
+// waitRateOfPage waits for the limiter of this page to allow a navigation to the host of the current URL, if any,
+// which is where a click is likely to navigate to.
+func (p *Page) waitRateOfPage(ctx context.Context) error {
+	if p.rateLimiter() == nil {
+		return nil
+	}
+	info, err := p.Context(ctx).Info()
+	if err != nil {
+		return replaceTimeoutError(replaceAbortedError(err))
+	}
+	return p.waitRate(ctx, info.URL)
+}
+
+// hostOf returns the lower-cased host of the URL, or the URL itself if it has none.
+func hostOf(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && len(u.Host) > 0 {
+		return strings.ToLower(u.Host)
+	}
+	return rawURL
+}
@@ -0,0 +1,58 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_HostRateLimiter_Paces_Each_Host(t *testing.T) {
+	l := NewHostRateLimiter(20, 1)
+	begin := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, l.Wait(context.Background(), "example.com"))
+	}
+	assert.GreaterOrEqual(t, time.Since(begin), 90*time.Millisecond)
+
+	begin = time.Now()
+	assert.NoError(t, l.Wait(context.Background(), "other.example.com"))
+	assert.Less(t, time.Since(begin), 50*time.Millisecond)
+}
+
+func Test_waitRate_Times_Out_Before_Deadline(t *testing.T) {
+	p := newPage(nil, func() {})
+	assert.NoError(t, p.waitRate(context.Background(), "https://example.com/"))
+
+	p.SetRateLimiter(NewHostRateLimiter(0.1, 1))
+	assert.NoError(t, p.waitRate(context.Background(), "https://example.com/a"))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.True(t, errors.Is(p.waitRate(ctx, "https://EXAMPLE.com/b"), TaskTimeout))
+	assert.NoError(t, p.waitRate(context.Background(), "https://other.example.com/"))
+}
+
+func Test_hostOf_Lowers_Host(t *testing.T) {
+	assert.Equal(t, "example.com:8080", hostOf("https://Example.COM:8080/path"))
+	assert.Equal(t, "about:blank", hostOf("about:blank"))
+}
+
+func Test_WithRateLimiter_Paces_Navigations(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithRateLimiter(NewHostRateLimiter(4, 1)))
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+	p := b.GetPage()
+	defer b.PutPage(p)
+
+	begin := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, p.TryNavigate(s.URL, HasSelector("body"), time.Millisecond))
+	}
+	assert.GreaterOrEqual(t, time.Since(begin), 450*time.Millisecond)
+}
@@ -0,0 +1,113 @@
+package chromium
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// actions of Step, each of which refers to a helper of Page.
+const (
+	ActionNavigate = "navigate" // TryNavigate, replayed as a plain navigation.
+	ActionClick    = "click"    // ClickNavigate, or TryClick if Timeout is zero.
+	ActionInput    = "input"    // TryInput.
+	ActionSelect   = "select"   // TrySelect, with the selected values as Values.
+	ActionCheck    = "check"    // TryCheck, with the state as Checked.
+	ActionWait     = "wait"     // WaitVisibleElement and WaitElementFor.
+	ActionWaitJS   = "waitJS"   // WaitJSObjectFor, with the object name as Text.
+	ActionExtract  = "extract"  // text, or Attribute if any, of every element matching Selector; only for RunScript and RunStep.
+)
+
+// Step is a single operation performed on a Page, which is serializable as JSON or YAML.
+type Step struct {
+	Action    string   `json:"action" yaml:"action"`
+	Selector  string   `json:"selector,omitempty" yaml:"selector,omitempty"`
+	URL       string   `json:"url,omitempty" yaml:"url,omitempty"`
+	Text      string   `json:"text,omitempty" yaml:"text,omitempty"`
+	Values    []string `json:"values,omitempty" yaml:"values,omitempty"`
+	Checked   bool     `json:"checked,omitempty" yaml:"checked,omitempty"`
+	Attribute string   `json:"attribute,omitempty" yaml:"attribute,omitempty"`
+	Name      string   `json:"name,omitempty" yaml:"name,omitempty"` // key of extracted values, which defaults to Selector.
+	Timeout   Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Offset    Duration `json:"offset,omitempty" yaml:"offset,omitempty"`   // time since the recording began, until this step began.
+	Elapsed   Duration `json:"elapsed,omitempty" yaml:"elapsed,omitempty"` // time taken by this step.
+	Error     string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Script is a sequence of steps, which is recorded by Recorder and executed by Replay or RunScript.
+type Script struct {
+	Steps []Step `json:"steps" yaml:"steps"`
+}
+
+// Recorder captures steps performed on pages that are recording with it.
+// Note that the text of input steps is captured as-is, including any credential typed into the page by TryInput,
+// while TryInputSecret is captured with a placeholder instead.
+type Recorder struct {
+	mu    *sync.Mutex
+	begin time.Time
+	steps []Step
+}
+
+// NewRecorder returns a recorder, whose offsets of steps are measured from now.
+func NewRecorder() *Recorder {
+	return &Recorder{mu: &sync.Mutex{}, begin: time.Now(), steps: make([]Step, 0)}
+}
+
+// Script returns a copy of steps that are captured so far.
+func (r *Recorder) Script() Script {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	steps := make([]Step, len(r.steps))
+	copy(steps, r.steps)
+	return Script{Steps: steps}
+}
+
+// add appends the step with its timings from given beginning.
+func (r *Recorder) add(step Step, begin time.Time, err error) {
+	step.Offset, step.Elapsed = Duration(begin.Sub(r.begin)), Duration(time.Since(begin))
+	if err != nil {
+		step.Error = err.Error()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+// Record lets the recorder capture subsequent operations of this page. Given nil stops recording.
+func (p *Page) Record(r *Recorder) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.recorder = r
+}
+
+// record appends the step to the history of this page, and to the recorder of this page if any,
+// with the error that given pointer refers to. Artifacts of this page are captured if the error is a failure.
+func (p *Page) record(step Step, begin time.Time, err *error) {
+	p.addHistory(step, begin, *err)
+	p.mu.RLock()
+	recorder := p.recorder
+	p.mu.RUnlock()
+	if recorder != nil {
+		recorder.add(step, begin, *err)
+	}
+	p.captureOnFailure(*err)
+}
+
+// Replay executes steps of the script against the page, keeping the pace of the recording by offsets of steps.
+// Steps that failed while being recorded are skipped, as they have not taken effect on the recorded page.
+// It stops at the first failing step, then returns its error along with the index of the step.
+func Replay(p *Page, script Script) error {
+	begin := time.Now()
+	for i, step := range script.Steps {
+		if len(step.Error) > 0 {
+			continue
+		}
+		if wait := time.Duration(step.Offset) - time.Since(begin); wait > 0 {
+			time.Sleep(wait)
+		}
+		if _, err := RunStep(p, step); err != nil {
+			return fmt.Errorf("step %d, %+v: %w", i, step.Action, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package chromium
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Recorder_Captures_Steps_With_Timings(t *testing.T) {
+	r := NewRecorder()
+	begin := time.Now()
+	time.Sleep(time.Millisecond)
+	r.add(Step{Action: ActionWait, Selector: "li"}, begin, errors.New("test error"))
+
+	steps := r.Script().Steps
+	if assert.Len(t, steps, 1) {
+		assert.Equal(t, "li", steps[0].Selector)
+		assert.Equal(t, "test error", steps[0].Error)
+		assert.Greater(t, steps[0].Offset, Duration(0))
+		assert.Greater(t, steps[0].Elapsed, Duration(0))
+	}
+}
+
+func Test_Recorder_Script_Returns_Copy(t *testing.T) {
+	r := NewRecorder()
+	r.add(Step{Action: ActionWait}, time.Now(), nil)
+	script := r.Script()
+	script.Steps[0].Action = ActionClick
+	assert.Equal(t, ActionWait, r.Script().Steps[0].Action)
+}
+
+func Test_Script_Is_Serializable(t *testing.T) {
+	script := Script{Steps: []Step{{Action: ActionInput, Selector: "#item0", Text: "test", Offset: Duration(time.Second)}}}
+	data, err := json.Marshal(script)
+	assert.NoError(t, err)
+	var got Script
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, script, got)
+}
+
+func Test_Replay_Returns_Err_On_Unknown_Action(t *testing.T) {
+	err := Replay(newPage(nil, func() {}), Script{Steps: []Step{{Action: "unknown"}}})
+	assert.ErrorContains(t, err, "step 0")
+	assert.ErrorContains(t, err, "unknown")
+}
+
+func Test_Replay_Skips_Steps_That_Failed_While_Recorded(t *testing.T) {
+	err := Replay(newPage(nil, func() {}), Script{Steps: []Step{{Action: "unknown", Error: "element missing"}, {Action: "other"}}})
+	assert.ErrorContains(t, err, "step 1")
+}
+
+func Test_Replay_Executes_Recorded_Steps_On_Fresh_Page(t *testing.T) {
+	b, p, s := setup(t, testfile.InputTestHTML)
+	r := NewRecorder()
+	p.Record(r)
+	assert.NoError(t, p.TryNavigate(s.URL, func(p *Page) bool { return true }, time.Millisecond))
+	assert.NoError(t, p.TryInput("#item0", "hello world"))
+	p.Record(nil)
+
+	script := r.Script()
+	if assert.Len(t, script.Steps, 2) {
+		assert.Equal(t, ActionNavigate, script.Steps[0].Action)
+		assert.Equal(t, ActionInput, script.Steps[1].Action)
+	}
+
+	fresh := newPage(b.MustPage(), func() {})
+	t.Cleanup(fresh.CleanUp)
+	assert.NoError(t, Replay(fresh, script))
+	assert.Equal(t, "hello world", fresh.MustElement("#item0").MustText())
+}
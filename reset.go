@@ -0,0 +1,70 @@
+package chromium
+
+// ResetPolicy decides which state of a page is wiped before the page is handed to the next caller.
+type ResetPolicy struct {
+	// Storage clears localStorage and sessionStorage of the current origin.
+	Storage bool
+	// Cookies deletes cookies that are visible to the current URL.
+	Cookies bool
+	// Blank navigates the page to about:blank.
+	Blank bool
+	// Dialogs clears the history of dialogs.
+	Dialogs bool
+	// History clears the history of helpers, as of Page.History.
+	History bool
+}
+
+// FullReset wipes all state that ResetPolicy covers.
+var FullReset = ResetPolicy{Storage: true, Cookies: true, Blank: true, Dialogs: true, History: true}
+
+// clearStorageJS clears storages of the current origin, which may throw on opaque origins such as about:blank.
+const clearStorageJS = `() => {
+	try { localStorage.clear(); sessionStorage.clear(); } catch (e) {}
+}`
+
+// WithPageReset resets each page by the policy once it is put back to the page pool,
+// such that cookies, storages, and the last URL of a job do not leak to the next one.
+// If the reset fails, the tab of the page is replaced by a new blank tab instead.
+func WithPageReset(policy ResetPolicy) BrowserOption {
+	return func(o *browserOptions) {
+		o.reset = &policy
+	}
+}
+
+// Reset wipes the state of this page by the policy.
+// Storages and cookies are cleared before navigating to blank, as both are bound to the current URL.
+// Note that storages and cookies are shared by pages of the same browser context.
+func (p *Page) Reset(policy ResetPolicy) error {
+	if policy.Storage {
+		if _, err := p.Eval(clearStorageJS); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	if policy.Cookies {
+		if err := p.ClearCookies(); err != nil {
+			return err
+		}
+	}
+	if policy.Blank {
+		if err := p.Navigate(blankURL); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	if policy.Dialogs {
+		p.ClearDialogs()
+	}
+	if policy.History {
+		p.ClearHistory()
+	}
+	return nil
+}
+
+// resetPage resets the page by the policy of this browser if any, falling back to recycle the page on failure.
+func (b *Browser) resetPage(p *Page) {
+	if b.reset == nil || p == nil {
+		return
+	}
+	if err := p.Reset(*b.reset); err != nil {
+		_ = b.recyclePage(p)
+	}
+}
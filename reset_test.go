@@ -0,0 +1,42 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_WithPageReset_Sets_Policy(t *testing.T) {
+	o := &browserOptions{}
+	WithPageReset(FullReset)(o)
+	if assert.NotNil(t, o.reset) {
+		assert.Equal(t, FullReset, *o.reset)
+	}
+}
+
+func Test_resetPage_Does_Nothing_Without_Policy(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	p := b.GetPage()
+	p.SaveDialog(&proto.PageJavascriptDialogOpening{Message: "test"})
+	assert.NotPanics(t, func() { b.PutPage(p) })
+	assert.Len(t, b.GetPage().Dialogs(), 1)
+}
+
+func Test_Reset_Clears_Cookies_Storage_And_URL(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.WaitLoad())
+	assert.NoError(t, p.SetCookies([]*http.Cookie{{Name: "session", Value: "1"}}))
+	p.MustEval(`() => localStorage.setItem("key", "value")`)
+
+	assert.NoError(t, p.Reset(ResetPolicy{Storage: true, Cookies: true}))
+	cookies, err := p.GetCookies()
+	assert.NoError(t, err)
+	assert.Empty(t, cookies)
+	assert.True(t, p.MustEval(`() => localStorage.getItem("key") === null`).Bool())
+
+	assert.NoError(t, p.Reset(FullReset))
+	assert.Equal(t, blankURL, p.MustInfo().URL)
+}
@@ -0,0 +1,260 @@
+package chromium
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CapturedRequest is a request of a page that OnResponse has captured along with its response.
+type CapturedRequest struct {
+	Method string
+	URL    string
+	Header http.Header // headers as sent, including cookies once the browser reports them.
+	Body   []byte
+}
+
+// AsCurl returns a curl command that sends the request again, with its method, headers including cookies, and body.
+// Pseudo headers of HTTP/2 such as ":authority" are left out, as curl derives them from the URL.
+func (r *CapturedRequest) AsCurl() string {
+	b := &strings.Builder{}
+	b.WriteString("curl")
+	if r.Method != http.MethodGet {
+		b.WriteString(" -X " + shellQuote(r.Method))
+	}
+	b.WriteString(" " + shellQuote(r.URL))
+	keys := make([]string, 0, len(r.Header))
+	for key := range r.Header {
+		if !strings.HasPrefix(key, ":") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range r.Header[key] {
+			b.WriteString(" -H " + shellQuote(key+": "+value))
+		}
+	}
+	if len(r.Header.Get("Accept-Encoding")) > 0 {
+		b.WriteString(" --compressed")
+	}
+	if len(r.Body) > 0 {
+		b.WriteString(" --data-raw " + shellQuote(string(r.Body)))
+	}
+	return b.String()
+}
+
+// AsHTTPRequest returns a request of net/http that sends the request again, with its method, headers including
+// cookies, and body. Pseudo headers of HTTP/2 such as ":authority" are left out.
+func (r *CapturedRequest) AsHTTPRequest() (*http.Request, error) {
+	req, err := http.NewRequest(r.Method, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range r.Header {
+		if strings.HasPrefix(key, ":") {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	return req, nil
+}
+
+// shellQuote quotes the text by single quotes for POSIX shells.
+func shellQuote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
+
+// CapturedResponse is a response to a request of a page whose URL matches the pattern of OnResponse.
+type CapturedResponse struct {
+	Request      *CapturedRequest
+	URL          string
+	Status       int
+	Header       http.Header
+	MIMEType     string
+	ResourceType proto.NetworkResourceType
+	Body         []byte // nil if the browser has not kept the body, such as of a redirect.
+	raw          bool   // whether the body is as served, rather than text that the browser has decoded into UTF-8.
+}
+
+// Text returns the body of the response as a UTF-8 string, decoding the body as served by DecodeContent,
+// such that text of legacy charsets such as EUC-KR and Shift_JIS is returned without mojibake.
+func (r *CapturedResponse) Text() (string, error) {
+	if !r.raw {
+		return string(r.Body), nil
+	}
+	return DecodeContent(r.Body, r.Header.Get("Content-Type"))
+}
+
+// JSON decodes the body of the response as JSON into v.
+func (r *CapturedResponse) JSON(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// responseCapture pairs requests of a page with their responses, until they finish loading.
+type responseCapture struct {
+	pattern *regexp.Regexp
+	handler func(*CapturedResponse)
+	page    *rod.Page
+
+	mu        *sync.Mutex
+	responses map[proto.NetworkRequestID]*CapturedResponse
+	sent      map[proto.NetworkRequestID]http.Header // headers as sent, which may be reported before the request.
+	handlers  *sync.WaitGroup
+}
+
+// OnResponse calls the handler with each response to a request of this page whose URL matches the pattern,
+// once its body has been loaded. The pattern is a wildcard as of Intercept, such as "*/api/items?*".
+// Handlers are called from background goroutines, in the order that responses finish loading.
+// Returned stop ends the capture, waiting for the handlers in progress to return, thus it must not be called by the handler.
+func (p *Page) OnResponse(pattern string, handler func(*CapturedResponse)) (stop func(), err error) {
+	reg, err := regexp.Compile(proto.PatternToReg(pattern))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(p.GetContext())
+	c := &responseCapture{
+		pattern:   reg,
+		handler:   handler,
+		page:      p.Context(ctx),
+		mu:        &sync.Mutex{},
+		responses: make(map[proto.NetworkRequestID]*CapturedResponse),
+		sent:      make(map[proto.NetworkRequestID]http.Header),
+		handlers:  &sync.WaitGroup{},
+	}
+	wait := c.page.EachEvent(c.requestWillBeSent, c.requestExtraInfo, c.responseReceived, c.loadingFinished, c.loadingFailed)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait()
+	}()
+	once := &sync.Once{}
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-done
+			c.handlers.Wait()
+		})
+	}, nil
+}
+
+func (c *responseCapture) requestWillBeSent(e *proto.NetworkRequestWillBeSent) {
+	url := e.Request.URL + e.Request.URLFragment
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.pattern.MatchString(url) {
+		delete(c.responses, e.RequestID)
+		return
+	}
+	req := &CapturedRequest{Method: e.Request.Method, URL: url, Header: httpHeader(e.Request.Headers), Body: []byte(e.Request.PostData)}
+	if sent, ok := c.sent[e.RequestID]; ok && e.RedirectResponse == nil {
+		req.Header = sent
+	}
+	c.responses[e.RequestID] = &CapturedResponse{Request: req, URL: url}
+}
+
+func (c *responseCapture) requestExtraInfo(e *proto.NetworkRequestWillBeSentExtraInfo) {
+	header := httpHeader(e.Headers)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if res, ok := c.responses[e.RequestID]; ok {
+		res.Request.Header = header
+	} else {
+		c.sent[e.RequestID] = header
+	}
+}
+
+func (c *responseCapture) responseReceived(e *proto.NetworkResponseReceived) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if res, ok := c.responses[e.RequestID]; ok {
+		res.URL = e.Response.URL
+		res.Status = e.Response.Status
+		res.Header = httpHeader(e.Response.Headers)
+		res.MIMEType = e.Response.MIMEType
+		res.ResourceType = e.Type
+	}
+}
+
+// loadingFinished reads the body of the response in background, then passes the response to the handler.
+func (c *responseCapture) loadingFinished(e *proto.NetworkLoadingFinished) {
+	c.mu.Lock()
+	res, ok := c.responses[e.RequestID]
+	delete(c.responses, e.RequestID)
+	delete(c.sent, e.RequestID)
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	c.handlers.Add(1)
+	go func() {
+		defer c.handlers.Done()
+		if body, err := (proto.NetworkGetResponseBody{RequestID: e.RequestID}).Call(c.page); err == nil {
+			res.Body, res.raw = []byte(body.Body), body.Base64Encoded
+			if body.Base64Encoded {
+				res.Body, _ = base64.StdEncoding.DecodeString(body.Body)
+			}
+		}
+		c.handler(res)
+	}()
+}
+
+func (c *responseCapture) loadingFailed(e *proto.NetworkLoadingFailed) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.responses, e.RequestID)
+	delete(c.sent, e.RequestID)
+}
+
+// httpHeader converts the headers of DevTools protocol, whose values of the same key are joined by newlines.
+func httpHeader(headers proto.NetworkHeaders) http.Header {
+	header := make(http.Header, len(headers))
+	for key, value := range headers {
+		for _, v := range strings.Split(value.Str(), "\n") {
+			header.Add(key, v)
+		}
+	}
+	return header
+}
+
+// WaitResponseJSON waits for a response to a request of the page whose URL matches the pattern as of OnResponse,
+// then decodes its body as JSON into T, such that the API behind the page can be read instead of its DOM.
+// Only responses that begin after the call are waited for, thus call it before the action that triggers the request,
+// such as from another goroutine. Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping TaskTimeout if no response arrives in time, or WaitFailed if the body is not JSON of T.
+func WaitResponseJSON[T any](p *Page, pattern string, timeout time.Duration) (T, error) {
+	var value T
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	responses := make(chan *CapturedResponse, 1)
+	stop, err := p.OnResponse(pattern, func(res *CapturedResponse) {
+		select {
+		case responses <- res:
+		default:
+		}
+	})
+	if err != nil {
+		return value, err
+	}
+	defer stop()
+	select {
+	case res := <-responses:
+		if err = res.JSON(&value); err != nil {
+			return value, &Error{Op: opWaitResponse, URL: res.URL, Err: classify(WaitFailed, err)}
+		}
+		return value, nil
+	case <-ctx.Done():
+		return value, &Error{Op: opWaitResponse, URL: pattern, Err: replaceTimeoutError(ctx.Err())}
+	}
+}
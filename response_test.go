@@ -0,0 +1,112 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysmood/gson"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newAPIServer returns a server that serves JSON of items under /api, and a page that fetches them after the delay.
+func newAPIServer(t *testing.T, delay time.Duration) *testserver.TestServer {
+	s := testserver.NewServer(func(_ []*testserver.HttpRequest, w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items": ["a", "b"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`<html><body><script>
+setTimeout(() => fetch('/api/items?page=1', {method: 'POST', body: 'q=1'}), ` + strconv.FormatInt(delay.Milliseconds(), 10) + `)
+</script></body></html>`))
+	})
+	t.Cleanup(s.Close)
+	return s
+}
+
+type items struct {
+	Items []string `json:"items"`
+}
+
+func Test_OnResponse_Captures_Matching_Responses(t *testing.T) {
+	_, p, _ := setup(t)
+	s := newAPIServer(t, time.Millisecond*10)
+	captured := make(chan *CapturedResponse, 10)
+	stop, err := p.OnResponse("*/api/*", func(res *CapturedResponse) { captured <- res })
+	assert.NoError(t, err)
+	defer stop()
+
+	p.MustNavigate(s.URL).MustWaitLoad()
+	select {
+	case res := <-captured:
+		assert.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "application/json", res.MIMEType)
+		assert.Equal(t, "POST", res.Request.Method)
+		assert.Equal(t, "q=1", string(res.Request.Body))
+		assert.True(t, strings.HasSuffix(res.Request.URL, "/api/items?page=1"))
+		var got items
+		assert.NoError(t, res.JSON(&got))
+		assert.Equal(t, []string{"a", "b"}, got.Items)
+	case <-time.After(time.Second * 5):
+		t.Fatal("no response captured")
+	}
+	assert.Empty(t, captured)
+}
+
+func Test_WaitResponseJSON_Decodes_Response(t *testing.T) {
+	_, p, _ := setup(t)
+	s := newAPIServer(t, time.Millisecond*500)
+	p.MustNavigate(s.URL)
+	got, err := WaitResponseJSON[items](p, "*/api/items?*", time.Second*5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, got.Items)
+
+	_, err = WaitResponseJSON[items](p, "*/never", time.Millisecond*100)
+	assert.ErrorIs(t, err, TaskTimeout)
+	_, err = WaitResponseJSON[int](p, "*/api/items?*", time.Millisecond*100)
+	assert.ErrorIs(t, err, TaskTimeout)
+}
+
+func Test_httpHeader_Splits_Joined_Values(t *testing.T) {
+	header := httpHeader(proto.NetworkHeaders{"Set-Cookie": gson.New("a=1\nb=2"), "Content-Type": gson.New("text/html")})
+	assert.Equal(t, []string{"a=1", "b=2"}, header.Values("Set-Cookie"))
+	assert.Equal(t, "text/html", header.Get("Content-Type"))
+}
+
+func Test_CapturedRequest_AsCurl_Quotes_Arguments(t *testing.T) {
+	req := &CapturedRequest{
+		Method: http.MethodPost,
+		URL:    "https://example.com/api/items?q=it's",
+		Header: http.Header{"Cookie": {"sid=1"}, "Content-Type": {"application/json"}, ":authority": {"example.com"}},
+		Body:   []byte(`{"name":"a'b"}`),
+	}
+	assert.Equal(t, `curl -X 'POST' 'https://example.com/api/items?q=it'\''s' -H 'Content-Type: application/json' `+
+		`-H 'Cookie: sid=1' --data-raw '{"name":"a'\''b"}'`, req.AsCurl())
+	assert.Equal(t, `curl 'https://example.com/'`, (&CapturedRequest{Method: http.MethodGet, URL: "https://example.com/"}).AsCurl())
+}
+
+func Test_CapturedRequest_AsHTTPRequest_Copies_Request(t *testing.T) {
+	req := &CapturedRequest{
+		Method: http.MethodPost,
+		URL:    "https://example.com/api/items",
+		Header: http.Header{"Cookie": {"sid=1"}, ":method": {"POST"}},
+		Body:   []byte("a=1"),
+	}
+	r, err := req.AsHTTPRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "https://example.com/api/items", r.URL.String())
+	assert.Equal(t, "sid=1", r.Header.Get("Cookie"))
+	assert.Empty(t, r.Header.Values(":method"))
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "a=1", string(body))
+
+	_, err = (&CapturedRequest{Method: "BAD METHOD", URL: "https://example.com/"}).AsHTTPRequest()
+	assert.Error(t, err)
+}
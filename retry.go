@@ -0,0 +1,160 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// RetryPolicy describes how Retry and TryNavigateWithPolicy handle a failing operation.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one. Values lower than 1 are treated as 1.
+	MaxAttempts int
+	// Backoff is the delay before the second attempt, which grows linearly on each attempt alike TryNavigate,
+	// unless Multiplier is set.
+	Backoff time.Duration
+	// Multiplier grows the delay exponentially by itself on each attempt, if greater than 1.
+	Multiplier float64
+	// MaxBackoff caps the delay between attempts, if positive.
+	MaxBackoff time.Duration
+	// Jitter adds a random duration up to itself to each delay, such that concurrent retries spread out.
+	Jitter time.Duration
+	// Recycle navigates the page to blank between attempts, such that each attempt begins with a fresh document.
+	Recycle bool
+	// Retryable decides whether an error is worth another attempt. If nil, IsRetryable is used.
+	Retryable Predicate[error]
+}
+
+// ErrRetriesExhausted is returned once a policy runs out of attempts while the operation keeps failing.
+type ErrRetriesExhausted struct {
+	Attempts int   // number of attempts made.
+	Last     error // error of the last attempt.
+}
+
+func (e *ErrRetriesExhausted) Error() string {
+	return fmt.Sprintf("retries exhausted after %d attempts: %v", e.Attempts, e.Last)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *ErrRetriesExhausted) Unwrap() error {
+	return e.Last
+}
+
+// Retry runs given operation against the page until it succeeds or the policy gives up.
+// It returns nil on success, the error as is if it is not retryable, or ErrRetriesExhausted that wraps the error
+// from the last attempt if the policy runs out of attempts after retrying. The wait between attempts stops once the page is closed,
+// returning the error of its context.
+func Retry(p *Page, policy RetryPolicy, op func(*Page) error) error {
+	retryable := policy.retryable()
+	for attempt := 1; ; attempt++ {
+		err := op(p)
+		if err == nil || !retryable(err) {
+			return err
+		} else if attempt >= policy.MaxAttempts {
+			return exhausted(attempt, err)
+		}
+		p.retried(opRetry, attempt, err)
+		if err = p.sleep(p.GetContext(), policy.delay(attempt)); err != nil {
+			return err
+		}
+		if policy.Recycle {
+			if err = p.Navigate(blankURL); err != nil {
+				return replaceAbortedError(err)
+			}
+		}
+	}
+}
+
+// exhausted returns ErrRetriesExhausted that wraps the error of the last attempt,
+// or the error as is if no retry has taken place, such as by a policy of a single attempt.
+func exhausted(attempts int, err error) error {
+	if attempts <= 1 {
+		return err
+	}
+	return &ErrRetriesExhausted{Attempts: attempts, Last: err}
+}
+
+// retryable returns the Retryable of the policy, or IsRetryable if not set.
+func (policy RetryPolicy) retryable() Predicate[error] {
+	if policy.Retryable == nil {
+		return IsRetryable
+	}
+	return policy.Retryable
+}
+
+// delay returns the duration to wait after given attempt, which begins from 1.
+func (policy RetryPolicy) delay(attempt int) time.Duration {
+	d := policy.Backoff * time.Duration(attempt)
+	if policy.Multiplier > 1 {
+		d = time.Duration(float64(policy.Backoff) * math.Pow(policy.Multiplier, float64(attempt-1)))
+	}
+	if policy.MaxBackoff > 0 && (d > policy.MaxBackoff || d < 0) {
+		d = policy.MaxBackoff
+	}
+	if policy.Jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(policy.Jitter)))
+	}
+	return d
+}
+
+// TryNavigateWithPolicy is TryNavigate that gives up by the policy.
+// A rejection by the predicate is always retried, whereas navigation errors are retried only if retryable by the policy.
+// Once the policy runs out of attempts after retrying, it returns ErrRetriesExhausted that wraps the last error,
+// which is PredicateFailed if the predicate rejected the last attempt, whereas a policy of a single attempt returns
+// the error as is. Recycle of the policy is ignored.
+func (p *Page) TryNavigateWithPolicy(url string, predicate Predicate[*Page], policy RetryPolicy) (err error) {
+	step := Step{Action: ActionNavigate, URL: url}
+	defer p.record(step, time.Now(), &err)
+	ctx, end := p.startSpan(p.GetContext(), step)
+	defer end(&err)
+	retryable := policy.retryable()
+	for attempt := 1; ; attempt++ {
+		if err = p.navigate(ctx, url); err == nil {
+			var ok bool
+			if ok, err = examine(p, predicate); err == nil && !ok {
+				err = attemptError(PredicateFailed, url, attempt)
+			}
+		}
+		if err == nil {
+			return nil
+		} else if !errors.Is(err, PredicateFailed) && !retryable(err) {
+			return err
+		} else if attempt >= policy.MaxAttempts {
+			return exhausted(attempt, err)
+		}
+		p.retried(ActionNavigate, attempt, err)
+		if err = p.sleep(ctx, policy.delay(attempt)); err != nil {
+			return attemptError(err, url, attempt)
+		}
+	}
+}
+
+// IsRetryable classifies the error by its kind.
+// Missing elements, failed waits, timeouts and transient network errors are retryable,
+// whereas cancellation, disconnection and unknown errors are not, as another attempt would fail alike.
+func IsRetryable(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled), errors.Is(err, Disconnected):
+		return false
+	case errors.Is(err, ElementMissing),
+		errors.Is(err, WaitFailed),
+		errors.Is(err, InputFailed),
+		errors.Is(err, ClickFailed),
+		errors.Is(err, TaskTimeout),
+		errors.Is(err, context.DeadlineExceeded):
+		return true
+	}
+	return isNetworkError(err)
+}
+
+// isNetworkError checks if the error is a network error reported by the browser, other than an aborted one.
+func isNetworkError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, networkErrorPrefix) && !strings.Contains(msg, abortedError)
+}
@@ -0,0 +1,142 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Retry_Returns_Nil_On_First_Success(t *testing.T) {
+	count := 0
+	err := Retry(detachedPage(context.Background()), RetryPolicy{MaxAttempts: 3}, func(p *Page) error { count++; return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func Test_Retry_Retries_Until_MaxAttempts(t *testing.T) {
+	count := 0
+	err := Retry(detachedPage(context.Background()), RetryPolicy{MaxAttempts: 3}, func(p *Page) error { count++; return elementError(opFind, "li", ElementMissing) })
+	assert.ErrorIs(t, err, ElementMissing)
+	assert.Equal(t, 3, count)
+	var exhausted *ErrRetriesExhausted
+	if assert.ErrorAs(t, err, &exhausted) {
+		assert.Equal(t, 3, exhausted.Attempts)
+	}
+}
+
+func Test_Retry_Runs_Once_When_MaxAttempts_Is_Not_Positive(t *testing.T) {
+	count := 0
+	err := Retry(detachedPage(context.Background()), RetryPolicy{}, func(p *Page) error { count++; return TaskTimeout })
+	assert.ErrorIs(t, err, TaskTimeout)
+	assert.Equal(t, 1, count)
+	var exhausted *ErrRetriesExhausted
+	assert.False(t, errors.As(err, &exhausted))
+}
+
+func Test_Retry_Does_Not_Retry_When_Context_Canceled(t *testing.T) {
+	count := 0
+	err := Retry(detachedPage(context.Background()), RetryPolicy{MaxAttempts: 5}, func(p *Page) error { count++; return context.Canceled })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, count)
+}
+
+func Test_Retry_Waits_With_Growing_Backoff(t *testing.T) {
+	backoff := time.Millisecond * 10
+	begin := time.Now()
+	_ = Retry(detachedPage(context.Background()), RetryPolicy{MaxAttempts: 3, Backoff: backoff}, func(p *Page) error { return TaskTimeout })
+	assert.GreaterOrEqual(t, time.Since(begin), backoff*3)
+}
+
+func Test_Retry_Stops_Waiting_Once_Page_Is_Closed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	begin := time.Now()
+	err := Retry(detachedPage(ctx), RetryPolicy{MaxAttempts: 3, Backoff: time.Hour}, func(p *Page) error { return TaskTimeout })
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(begin), time.Second)
+}
+
+func Test_Retry_Uses_Given_Retryable(t *testing.T) {
+	count, target := 0, errors.New("custom")
+	policy := RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool { return errors.Is(err, target) }}
+	assert.ErrorIs(t, Retry(detachedPage(context.Background()), policy, func(p *Page) error { count++; return target }), target)
+	assert.Equal(t, 3, count)
+}
+
+func Test_Retry_Recycles_Page_Between_Attempts(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	urls := make([]string, 0)
+	policy := RetryPolicy{MaxAttempts: 2, Recycle: true}
+	err := Retry(p, policy, func(p *Page) error {
+		urls = append(urls, p.MustInfo().URL)
+		p.MustNavigate(s.URL)
+		return ElementMissing
+	})
+	assert.ErrorIs(t, err, ElementMissing)
+	if assert.Len(t, urls, 2) {
+		assert.Equal(t, blankURL, urls[1])
+	}
+}
+
+func Test_IsRetryable_Classifies_Errors(t *testing.T) {
+	assert.False(t, IsRetryable(nil))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(Disconnected))
+	assert.False(t, IsRetryable(errors.New("unknown")))
+	assert.False(t, IsRetryable(errors.New(abortedError)))
+	assert.True(t, IsRetryable(elementError(opFind, "a", ElementMissing)))
+	assert.True(t, IsRetryable(TaskTimeout))
+	assert.True(t, IsRetryable(errors.New("net::ERR_CONNECTION_RESET")))
+}
+
+func Test_Retry_Returns_Err_As_Is_When_Not_Retryable(t *testing.T) {
+	target := errors.New("unknown")
+	err := Retry(detachedPage(context.Background()), RetryPolicy{MaxAttempts: 3}, func(p *Page) error { return target })
+	assert.Equal(t, target, err)
+}
+
+func Test_RetryPolicy_delay_Grows_Linearly_By_Default(t *testing.T) {
+	policy := RetryPolicy{Backoff: time.Millisecond}
+	assert.Equal(t, time.Millisecond, policy.delay(1))
+	assert.Equal(t, time.Millisecond*3, policy.delay(3))
+}
+
+func Test_RetryPolicy_delay_Grows_Exponentially_With_Multiplier(t *testing.T) {
+	policy := RetryPolicy{Backoff: time.Millisecond, Multiplier: 2, MaxBackoff: time.Millisecond * 5}
+	assert.Equal(t, time.Millisecond, policy.delay(1))
+	assert.Equal(t, time.Millisecond*2, policy.delay(2))
+	assert.Equal(t, time.Millisecond*4, policy.delay(3))
+	assert.Equal(t, time.Millisecond*5, policy.delay(4))
+	assert.Equal(t, time.Millisecond*5, policy.delay(100))
+}
+
+func Test_RetryPolicy_delay_Adds_Jitter_Within_Cap(t *testing.T) {
+	policy := RetryPolicy{Backoff: time.Millisecond, Jitter: time.Millisecond}
+	for i := 0; i < 100; i++ {
+		d := policy.delay(1)
+		assert.GreaterOrEqual(t, d, time.Millisecond)
+		assert.Less(t, d, time.Millisecond*2)
+	}
+}
+
+func Test_TryNavigateWithPolicy_Returns_ErrRetriesExhausted(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	err := p.TryNavigateWithPolicy(s.URL, func(p *Page) bool { return false }, RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+	var exhausted *ErrRetriesExhausted
+	if assert.ErrorAs(t, err, &exhausted) {
+		assert.Equal(t, 3, exhausted.Attempts)
+		assert.ErrorIs(t, exhausted.Last, PredicateFailed)
+	}
+	requestCountMustBeAsExpected(t, s, 3)
+}
+
+func Test_TryNavigateWithPolicy_Returns_Nil_Once_Predicate_Passes(t *testing.T) {
+	items := makeItems(testfile.BlankHTML, testfile.ItemsHTML, 2)
+	_, p, s := setup(t, items...)
+	err := p.TryNavigateWithPolicy(s.URL, func(p *Page) bool { return p.MustHas("li") }, RetryPolicy{MaxAttempts: 5, Backoff: time.Millisecond})
+	assert.NoError(t, err)
+	requestCountMustBeAsExpected(t, s, 3)
+}
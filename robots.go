@@ -0,0 +1,221 @@
+package chromium
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// robotsTTL is how long robots.txt of a host is cached once fetched.
+	robotsTTL = 24 * time.Hour
+	// robotsFetchTimeout bounds the fetch of robots.txt, which is shared by every navigation to its host.
+	robotsFetchTimeout = 30 * time.Second
+	// maxRobotsSize caps the size of robots.txt to be parsed, which is 500KiB as of RFC 9309.
+	maxRobotsSize = 500 << 10
+)
+
+// WithRobotsPolicy makes every page of the browser obey robots.txt of each host for the user agent, such that
+// TryNavigate and TryNavigateWithPolicy return an error wrapping DisallowedByRobots for disallowed paths, without
+// navigating. Robots.txt is fetched by net/http along with the user agent, then cached per host for a day.
+// Following RFC 9309, a missing robots.txt allows every path, while an unreachable one disallows every path until fetched.
+func WithRobotsPolicy(agent string) BrowserOption {
+	return func(o *browserOptions) {
+		o.robots = agent
+	}
+}
+
+// robotsPolicy fetches and caches robots.txt by host, then tells whether the user agent may visit URLs.
+type robotsPolicy struct {
+	agent  string
+	client *http.Client
+	mu     *sync.Mutex
+	hosts  map[string]*robotsEntry // by scheme and host.
+}
+
+// robotsEntry is robots.txt of a host, which is ready once fetched.
+type robotsEntry struct {
+	ready   chan struct{}
+	rules   *robotsRules // nil if robots.txt cannot be fetched.
+	expires time.Time
+}
+
+func newRobotsPolicy(agent string) *robotsPolicy {
+	return &robotsPolicy{agent: agent, client: http.DefaultClient, mu: &sync.Mutex{}, hosts: make(map[string]*robotsEntry)}
+}
+
+// check returns an error wrapping DisallowedByRobots if the URL is disallowed, fetching robots.txt of its host
+// if not cached. URLs other than http and https are always allowed.
+func (r *robotsPolicy) check(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+	entry := r.entry(u.Scheme + "://" + u.Host)
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		return replaceTimeoutError(ctx.Err())
+	}
+	if entry.rules == nil {
+		return fmt.Errorf("%w: robots.txt of %s is unreachable", DisallowedByRobots, u.Host)
+	}
+	path := u.EscapedPath()
+	if len(path) == 0 {
+		path = "/"
+	}
+	if len(u.RawQuery) > 0 {
+		path += "?" + u.RawQuery
+	}
+	if !entry.rules.allows(path) {
+		return fmt.Errorf("%w: %s", DisallowedByRobots, rawURL)
+	}
+	return nil
+}
+
+// entry returns the cached entry of the origin, fetching robots.txt in background if missing or expired.
+// An entry of unreachable robots.txt is replaced on the next call, such that robots.txt is fetched again.
+func (r *robotsPolicy) entry(origin string) *robotsEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.hosts[origin]
+	if ok {
+		select {
+		case <-entry.ready:
+			if entry.rules != nil && time.Now().Before(entry.expires) {
+				return entry
+			}
+		default:
+			return entry // being fetched.
+		}
+	}
+	entry = &robotsEntry{ready: make(chan struct{})}
+	r.hosts[origin] = entry
+	go func() {
+		defer close(entry.ready)
+		entry.rules = r.fetch(origin)
+		entry.expires = time.Now().Add(robotsTTL)
+	}()
+	return entry
+}
+
+// fetch returns the rules of robots.txt of the origin for the user agent, or nil if it is unreachable.
+// A robots.txt that is missing or forbidden allows every path.
+func (r *robotsPolicy) fetch(origin string) *robotsRules {
+	ctx, cancel := context.WithTimeout(context.Background(), robotsFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", r.agent)
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = res.Body.Close() }()
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return parseRobots(io.LimitReader(res.Body, maxRobotsSize), r.agent)
+	case res.StatusCode >= 400 && res.StatusCode < 500:
+		return &robotsRules{}
+	}
+	return nil
+}
+
+// robotsRule is an allow or disallow rule of a group of robots.txt.
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// robotsRules are the rules of the group of robots.txt that applies to a user agent.
+type robotsRules struct {
+	rules []robotsRule
+}
+
+// allows tells whether the path with its query is allowed, by the longest matching rule, preferring allow on a tie.
+func (r *robotsRules) allows(path string) bool {
+	allow, longest := true, -1
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			allow, longest = rule.allow, len(rule.pattern)
+		}
+	}
+	return allow
+}
+
+// parseRobots parses robots.txt, returning the rules of the groups for the most specific user agent that matches
+// the agent, or of the groups for "*" if none matches, as of RFC 9309.
+func parseRobots(r io.Reader, agent string) *robotsRules {
+	agent = strings.ToLower(agent)
+	groups := make(map[string][]robotsRule)
+	var agents []string
+	inRules := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			if inRules {
+				agents, inRules = nil, false
+			}
+			a := strings.ToLower(value)
+			agents = append(agents, a)
+			groups[a] = groups[a] // a group without rules still applies to its user agents.
+		case "allow", "disallow":
+			inRules = true
+			if len(value) == 0 {
+				continue // empty disallow allows every path.
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value, re: robotsPattern(value)}
+			for _, a := range agents {
+				groups[a] = append(groups[a], rule)
+			}
+		}
+	}
+	matched := ""
+	for a := range groups {
+		if a != "*" && strings.Contains(agent, a) && len(a) > len(matched) {
+			matched = a
+		}
+	}
+	if len(matched) == 0 {
+		matched = "*"
+	}
+	return &robotsRules{rules: groups[matched]}
+}
+
+// robotsPattern compiles the path pattern of a rule, where "*" matches any characters and a trailing "$" anchors the end.
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
@@ -0,0 +1,88 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const robotsTXT = `# comment
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+
+User-agent: GoodBot
+User-agent: OtherBot
+Disallow:
+
+User-agent: BadBot
+Disallow: /
+`
+
+func Test_parseRobots_Selects_Group_Of_Agent(t *testing.T) {
+	rules := parseRobots(strings.NewReader(robotsTXT), "Mozilla/5.0 (compatible; SomeBot/1.0)")
+	assert.True(t, rules.allows("/"))
+	assert.False(t, rules.allows("/private/page"))
+	assert.True(t, rules.allows("/private/public/page"))
+	assert.False(t, rules.allows("/docs/file.pdf"))
+	assert.True(t, rules.allows("/docs/file.pdf?download=1"))
+
+	assert.True(t, parseRobots(strings.NewReader(robotsTXT), "goodbot/2.0").allows("/private"))
+	assert.False(t, parseRobots(strings.NewReader(robotsTXT), "BadBot").allows("/"))
+}
+
+func Test_robotsRules_Prefers_Allow_On_Tie(t *testing.T) {
+	rules := parseRobots(strings.NewReader("User-agent: *\nDisallow: /page\nAllow: /page\n"), "bot")
+	assert.True(t, rules.allows("/page"))
+}
+
+func Test_robotsPolicy_Fetches_And_Caches_By_Host(t *testing.T) {
+	var fetches, status int32 = 0, http.StatusOK
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		assert.Equal(t, "BadBot", r.UserAgent())
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+		_, _ = w.Write([]byte(robotsTXT))
+	}))
+	defer s.Close()
+
+	r := newRobotsPolicy("BadBot")
+	assert.True(t, errors.Is(r.check(context.Background(), s.URL+"/page"), DisallowedByRobots))
+	assert.True(t, errors.Is(r.check(context.Background(), s.URL+"/other"), DisallowedByRobots))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+	assert.NoError(t, r.check(context.Background(), "about:blank"))
+
+	atomic.StoreInt32(&status, http.StatusNotFound)
+	r = newRobotsPolicy("BadBot")
+	assert.NoError(t, r.check(context.Background(), s.URL+"/page"))
+
+	atomic.StoreInt32(&status, http.StatusServiceUnavailable)
+	r = newRobotsPolicy("BadBot")
+	assert.True(t, errors.Is(r.check(context.Background(), s.URL+"/page"), DisallowedByRobots))
+	atomic.StoreInt32(&status, http.StatusNotFound)
+	assert.NoError(t, r.check(context.Background(), s.URL+"/page"))
+}
+
+func Test_WithRobotsPolicy_Blocks_TryNavigate(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithRobotsPolicy("BadBot"))
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(robotsTXT))
+	}))
+	t.Cleanup(s.Close)
+	p := b.GetPage()
+	defer b.PutPage(p)
+
+	err = p.TryNavigate(s.URL+"/page", HasSelector("body"), time.Millisecond)
+	assert.True(t, errors.Is(err, DisallowedByRobots))
+	assert.Equal(t, "about:blank", p.MustInfo().URL)
+}
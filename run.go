@@ -0,0 +1,85 @@
+package chromium
+
+import (
+	"fmt"
+	"golang.org/x/sync/errgroup"
+)
+
+// Result is an outcome of a single input of RunAll.
+type Result[T any] struct {
+	Value    T
+	Err      error
+	Attempts int
+}
+
+// RunOption configures RunAll.
+type RunOption func(o *runOptions)
+
+// runOptions holds configurations that are set by RunOption.
+type runOptions struct {
+	concurrency int
+	retry       RetryPolicy
+}
+
+// WithConcurrency sets the maximum number of inputs to be processed at once.
+// It is bounded by the page pool anyway, thus the pool size is used for zero or negative value.
+func WithConcurrency(n int) RunOption {
+	return func(o *runOptions) { o.concurrency = n }
+}
+
+// WithRetryPolicy sets the policy to retry each input with.
+func WithRetryPolicy(policy RetryPolicy) RunOption {
+	return func(o *runOptions) { o.retry = policy }
+}
+
+// RunAll fans given inputs across the page pool of the browser, then returns results in the order of inputs.
+// Each input is run against a page from the pool, which is put back to the pool once the input is processed.
+// Inputs that are left when the browser shuts down result in BrowserClosed.
+// A panic of fn is recovered into the error of its input, such that the page is put back and other inputs go on.
+// The returned error is the first error in the order of inputs, while every result carries its own error.
+func RunAll[I, T any](b *Browser, inputs []I, fn func(*Page, I) (T, error), opts ...RunOption) ([]Result[T], error) {
+	o := &runOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.concurrency <= 0 || o.concurrency > cap(b.pagePool) {
+		o.concurrency = cap(b.pagePool)
+	}
+
+	results := make([]Result[T], len(inputs))
+	g := new(errgroup.Group)
+	g.SetLimit(o.concurrency)
+	for i := range inputs {
+		i := i
+		g.Go(func() error {
+			r := &results[i]
+			p := b.GetPage()
+			if p == nil {
+				r.Err = BrowserClosed
+				return nil
+			}
+			defer b.PutPage(p)
+			r.Err = Retry(p, o.retry, func(p *Page) (err error) {
+				r.Attempts++
+				defer func() {
+					if pe := recover(); isError(pe) {
+						err, _ = pe.(error)
+					} else if pe != nil {
+						err = fmt.Errorf("input panicked: %+v", pe)
+					}
+				}()
+				r.Value, err = fn(p, inputs[i])
+				return err
+			})
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	for _, r := range results {
+		if r.Err != nil {
+			return results, r.Err
+		}
+	}
+	return results, nil
+}
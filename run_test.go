@@ -0,0 +1,98 @@
+package chromium
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_RunAll_Returns_Results_In_Order_Of_Inputs(t *testing.T) {
+	b := newTaskBrowser(3, 0)
+	inputs := []int{5, 1, 4, 2, 3}
+	results, err := RunAll(b, inputs, func(p *Page, in int) (string, error) {
+		time.Sleep(time.Millisecond * time.Duration(in))
+		return fmt.Sprint(in), nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, results, len(inputs)) {
+		for i, in := range inputs {
+			assert.Equal(t, fmt.Sprint(in), results[i].Value)
+			assert.Equal(t, 1, results[i].Attempts)
+		}
+	}
+}
+
+func Test_RunAll_Returns_First_Error_In_Order_Of_Inputs(t *testing.T) {
+	b := newTaskBrowser(2, 0)
+	results, err := RunAll(b, []int{0, 1, 2}, func(p *Page, in int) (int, error) {
+		if in == 0 {
+			return in, nil
+		}
+		return in, fmt.Errorf("input %d", in)
+	})
+	assert.EqualError(t, err, "input 1")
+	assert.NoError(t, results[0].Err)
+	assert.EqualError(t, results[2].Err, "input 2")
+}
+
+func Test_RunAll_Retries_Each_Input_With_Policy(t *testing.T) {
+	b := newTaskBrowser(1, 0)
+	count := 0
+	results, err := RunAll(b, []int{0}, func(p *Page, in int) (int, error) {
+		count++
+		if count < 3 {
+			return 0, ElementMissing
+		}
+		return count, nil
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 5}))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, results[0].Value)
+	assert.Equal(t, 3, results[0].Attempts)
+}
+
+func Test_RunAll_Bounds_Concurrency(t *testing.T) {
+	b := newTaskBrowser(4, 0)
+	lock, running, max := &sync.Mutex{}, 0, 0
+	inputs := make([]int, 20)
+	_, err := RunAll(b, inputs, func(p *Page, in int) (int, error) {
+		lock.Lock()
+		if running++; running > max {
+			max = running
+		}
+		lock.Unlock()
+		time.Sleep(time.Millisecond)
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return in, nil
+	}, WithConcurrency(2))
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, max, 2)
+}
+
+func Test_RunAll_Returns_Empty_Results_When_No_Inputs(t *testing.T) {
+	b := newTaskBrowser(1, 0)
+	results, err := RunAll(b, []int{}, func(p *Page, in int) (int, error) { return 0, errors.New("unreachable") })
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func Test_RunAll_Recovers_Panic_Into_Error_Of_Input(t *testing.T) {
+	b := newTaskBrowser(1, 0)
+	results, err := RunAll(b, []int{0, 1, 2}, func(p *Page, in int) (int, error) {
+		if in == 1 {
+			panic("boom")
+		}
+		if in == 2 {
+			panic(ElementMissing)
+		}
+		return in, nil
+	})
+	assert.EqualError(t, err, "input panicked: boom")
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[2].Err, ElementMissing)
+	assert.Len(t, b.pagePool, 1)
+}
@@ -0,0 +1,163 @@
+package chromium
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// JobResult is an outcome of a single run of a scheduled job.
+type JobResult struct {
+	Spec    string // cron expression of the job.
+	Started time.Time
+	Elapsed time.Duration
+	Err     error
+	Skipped bool // true if the run was skipped as the previous run was still running.
+}
+
+// JobOption configures a job of Scheduler.
+type JobOption func(j *job)
+
+// WithJobTimeout bounds each run of the job by given timeout.
+func WithJobTimeout(d time.Duration) JobOption {
+	return func(j *job) { j.timeout = d }
+}
+
+// WithJobCallback sets a callback that receives the result of each run, including skipped ones.
+func WithJobCallback(callback func(JobResult)) JobOption {
+	return func(j *job) { j.callback = callback }
+}
+
+// job is a recurring function that runs against pages from the pool.
+type job struct {
+	spec     string
+	schedule schedule
+	run      func(*Page) error
+	timeout  time.Duration
+	callback func(JobResult)
+	running  bool
+}
+
+// Scheduler runs recurring jobs against the page pool of a browser.
+// Runs of the same job never overlap; a run is skipped if the previous one is still running.
+type Scheduler struct {
+	browser *Browser
+	mu      *sync.Mutex
+	jobs    []*job
+	ctx     context.Context // done once the scheduler stops, which abandons runs waiting for a page.
+	cancel  context.CancelFunc
+	wg      *sync.WaitGroup
+	started bool
+	now     func() time.Time
+}
+
+// NewScheduler returns a scheduler that runs jobs against the pages of given browser.
+func NewScheduler(b *Browser) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		browser: b,
+		mu:      &sync.Mutex{},
+		ctx:     ctx,
+		cancel:  cancel,
+		wg:      &sync.WaitGroup{},
+		now:     time.Now,
+	}
+}
+
+// Add schedules the job by the cron expression, which is either of five fields, a descriptor such as @hourly,
+// or @every with a duration such as "@every 30s". The job starts to run once the scheduler is started.
+func (s *Scheduler) Add(cron string, run func(*Page) error, opts ...JobOption) error {
+	sch, err := parseCron(cron)
+	if err != nil {
+		return err
+	}
+	j := &job{spec: cron, schedule: sch, run: run}
+	for _, opt := range opts {
+		opt(j)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, j)
+	if s.started {
+		s.loop(j)
+	}
+	return nil
+}
+
+// Start begins to run jobs by their schedules. It is a no-op if already started.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+	for _, j := range s.jobs {
+		s.loop(j)
+	}
+}
+
+// Stop stops scheduling jobs, then waits for running jobs to finish.
+// Runs that are still waiting for a page of the pool are abandoned with context.Canceled.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// loop spawns a goroutine that fires the job by its schedule until the scheduler stops.
+func (s *Scheduler) loop(j *job) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			next := j.schedule.next(s.now())
+			if next.IsZero() {
+				return
+			}
+			timer := time.NewTimer(next.Sub(s.now()))
+			select {
+			case <-s.ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				s.fire(j)
+			}
+		}
+	}()
+}
+
+// fire runs the job in background, unless the previous run is still running.
+func (s *Scheduler) fire(j *job) {
+	s.mu.Lock()
+	if j.running {
+		s.mu.Unlock()
+		j.report(JobResult{Spec: j.spec, Started: s.now(), Skipped: true})
+		return
+	}
+	j.running = true
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		res := JobResult{Spec: j.spec, Started: s.now()}
+		if p, err := s.browser.GetPageContext(s.ctx); err != nil {
+			res.Err = err
+		} else {
+			res.Err = runTask(p, j.timeout, j.run)
+			s.browser.PutPage(p)
+		}
+		res.Elapsed = s.now().Sub(res.Started)
+
+		s.mu.Lock()
+		j.running = false
+		s.mu.Unlock()
+		j.report(res)
+	}()
+}
+
+func (j *job) report(res JobResult) {
+	if j.callback != nil {
+		j.callback(res)
+	}
+}
@@ -0,0 +1,139 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_parseCron_Returns_Err_When_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "a * * * *", "*/0 * * * *", "5-1 * * * *", "@every x", "@every -1s"} {
+		_, err := parseCron(spec)
+		assert.Error(t, err, "expected error for %+v", spec)
+	}
+}
+
+func Test_parseCron_Computes_Next_Time(t *testing.T) {
+	base := time.Date(2022, 9, 30, 10, 17, 30, 0, time.UTC) // friday
+	cases := map[string]time.Time{
+		"* * * * *":     time.Date(2022, 9, 30, 10, 18, 0, 0, time.UTC),
+		"*/15 * * * *":  time.Date(2022, 9, 30, 10, 30, 0, 0, time.UTC),
+		"5 * * * *":     time.Date(2022, 9, 30, 11, 5, 0, 0, time.UTC),
+		"0 9-11 * * *":  time.Date(2022, 9, 30, 11, 0, 0, 0, time.UTC),
+		"30 8 * * 1":    time.Date(2022, 10, 3, 8, 30, 0, 0, time.UTC),
+		"0 0 * * 7":     time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC),
+		"0 0 1 * *":     time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 31 2 *":    {},
+		"0 0 15 * 6":    time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), // either day field matches
+		"0,45 10 * * *": time.Date(2022, 9, 30, 10, 45, 0, 0, time.UTC),
+		"@daily":        time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		"@every 1h":     base.Add(time.Hour),
+	}
+	for spec, expected := range cases {
+		s, err := parseCron(spec)
+		if assert.NoError(t, err, spec) {
+			assert.Equal(t, expected, s.next(base), spec)
+		}
+	}
+}
+
+func Test_Scheduler_Add_Returns_Err_When_Cron_Is_Invalid(t *testing.T) {
+	s := NewScheduler(newTaskBrowser(1, 0))
+	assert.Error(t, s.Add("invalid", func(p *Page) error { return nil }))
+}
+
+func Test_Scheduler_Runs_Job_And_Reports_Result(t *testing.T) {
+	s := NewScheduler(newTaskBrowser(1, 0))
+	expected := errors.New("test error")
+	results := make(chan JobResult, 10)
+	assert.NoError(t, s.Add("@every 10ms", func(p *Page) error { return expected }, WithJobCallback(func(r JobResult) { results <- r })))
+	s.Start()
+	defer s.Stop()
+	select {
+	case r := <-results:
+		assert.ErrorIs(t, r.Err, expected)
+		assert.Equal(t, "@every 10ms", r.Spec)
+		assert.False(t, r.Skipped)
+	case <-time.After(time.Second):
+		t.Fatal("expected job to run")
+	}
+}
+
+func Test_Scheduler_Skips_Overlapping_Runs(t *testing.T) {
+	s := NewScheduler(newTaskBrowser(2, 0))
+	lock, skipped, runs := &sync.Mutex{}, 0, 0
+	block := make(chan struct{})
+	callback := func(r JobResult) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.Skipped {
+			skipped++
+		}
+	}
+	run := func(p *Page) error {
+		lock.Lock()
+		runs++
+		lock.Unlock()
+		<-block
+		return nil
+	}
+	assert.NoError(t, s.Add("@every 5ms", run, WithJobCallback(callback)))
+	s.Start()
+	time.Sleep(time.Millisecond * 50)
+	lock.Lock()
+	assert.Equal(t, 1, runs) // later runs may begin once the block is released, before the scheduler stops.
+	lock.Unlock()
+	close(block)
+	s.Stop()
+	assert.Greater(t, skipped, 0)
+}
+
+func Test_Scheduler_Stop_Stops_Scheduling(t *testing.T) {
+	s := NewScheduler(newTaskBrowser(1, 0))
+	lock, runs := &sync.Mutex{}, 0
+	assert.NoError(t, s.Add("@every 5ms", func(p *Page) error { lock.Lock(); runs++; lock.Unlock(); return nil }))
+	s.Start()
+	time.Sleep(time.Millisecond * 30)
+	s.Stop()
+	lock.Lock()
+	count := runs
+	lock.Unlock()
+	time.Sleep(time.Millisecond * 30)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, count, runs)
+}
+
+func Test_Scheduler_Stop_Abandons_Runs_Waiting_For_Page(t *testing.T) {
+	b := newTaskBrowser(1, 0)
+	p := b.GetPage()
+	defer b.PutPage(p)
+	s := NewScheduler(b)
+	results := make(chan JobResult, 10)
+	assert.NoError(t, s.Add("@every 10ms", func(p *Page) error { return nil }, WithJobCallback(func(r JobResult) { results <- r })))
+	s.Start()
+	time.Sleep(50 * time.Millisecond)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected stop to return while the pool is exhausted")
+	}
+	close(results)
+	canceled := 0
+	for r := range results {
+		if !r.Skipped {
+			assert.ErrorIs(t, r.Err, context.Canceled)
+			canceled++
+		}
+	}
+	assert.Positive(t, canceled)
+}
@@ -0,0 +1,145 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/gson"
+	"io"
+	"sync"
+	"time"
+)
+
+// ScreencastOptions configures StartScreencast.
+type ScreencastOptions struct {
+	// Quality of JPEG frames, ranging from 1 to 100. Zero leaves it to the browser.
+	Quality int
+	// MaxWidth and MaxHeight bound the size of frames, which are scaled down to fit. Zero leaves them unbounded.
+	MaxWidth, MaxHeight int
+	// EveryNthFrame skips frames of the browser, keeping one in each n of them. Zero or 1 keeps every frame.
+	EveryNthFrame int
+	// FrameRate writes the latest frame at the rate per second, such that the stream played at the rate keeps the pace
+	// of the session. Zero writes each frame once as the browser sends it, which is only when the page changes,
+	// hence the stream is shorter than the session.
+	FrameRate int
+}
+
+// screencast is a screencast in progress.
+type screencast struct {
+	stop func()
+	done chan struct{}
+	mu   *sync.Mutex
+	w    io.Writer
+	last []byte // latest frame, which is written by the ticker of FrameRate.
+	err  error  // first error of writes.
+}
+
+// StartScreencast begins to write frames of this page to w as a Motion JPEG stream, which is a sequence of JPEG
+// images, until StopScreencast is called or the page is closed, such that CI failures and long scrapes can be reviewed
+// visually. The stream can be played or converted by ffmpeg, such as "ffmpeg -f mjpeg -r 10 -i cast.mjpeg cast.webm"
+// for FrameRate of 10, as encoding WebM takes a video encoder that this package does not bundle.
+// Frames are captured only while the page is shown, such as being the active tab or in headless mode.
+// It returns an error if a screencast of this page is already in progress.
+func (p *Page) StartScreencast(w io.Writer, opts ScreencastOptions) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.screencast != nil {
+		return errors.New("screencast already in progress")
+	}
+	req := proto.PageStartScreencast{Format: proto.PageStartScreencastFormatJpeg}
+	if opts.Quality > 0 {
+		req.Quality = gson.Int(opts.Quality)
+	}
+	if opts.MaxWidth > 0 {
+		req.MaxWidth = gson.Int(opts.MaxWidth)
+	}
+	if opts.MaxHeight > 0 {
+		req.MaxHeight = gson.Int(opts.MaxHeight)
+	}
+	if opts.EveryNthFrame > 1 {
+		req.EveryNthFrame = gson.Int(opts.EveryNthFrame)
+	}
+	sc := &screencast{done: make(chan struct{}), mu: &sync.Mutex{}, w: w}
+	ctx, cancel := context.WithCancel(p.GetContext())
+	wait := p.Context(ctx).EachEvent(func(e *proto.PageScreencastFrame) {
+		go func() { _ = proto.PageScreencastFrameAck{SessionID: e.SessionID}.Call(p) }()
+		sc.frame(e.Data, opts.FrameRate <= 0)
+	})
+	ticked := make(chan struct{})
+	go func() {
+		defer close(ticked)
+		if opts.FrameRate > 0 {
+			sc.tick(ctx, time.Second/time.Duration(opts.FrameRate))
+		}
+	}()
+	go func() {
+		defer close(sc.done)
+		wait()
+		<-ticked
+	}()
+	if err := req.Call(p); err != nil {
+		cancel()
+		<-sc.done
+		return replaceAbortedError(err)
+	}
+	sc.stop = cancel
+	p.screencast = sc
+	return nil
+}
+
+// StopScreencast stops the screencast of this page, then returns the first error of writes to its writer, if any.
+// It does nothing if no screencast is in progress.
+func (p *Page) StopScreencast() error {
+	p.mu.Lock()
+	sc := p.screencast
+	p.screencast = nil
+	p.mu.Unlock()
+	if sc == nil {
+		return nil
+	}
+	err := proto.PageStopScreencast{}.Call(p)
+	sc.stop()
+	<-sc.done
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.err != nil {
+		return sc.err
+	}
+	return replaceAbortedError(err)
+}
+
+// frame keeps the frame as the latest one, writing it if write is set.
+func (sc *screencast) frame(data []byte, write bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.last = data
+	if write {
+		sc.write(data)
+	}
+}
+
+// tick writes the latest frame at each interval, until ctx is done.
+func (sc *screencast) tick(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sc.mu.Lock()
+			if sc.last != nil {
+				sc.write(sc.last)
+			}
+			sc.mu.Unlock()
+		}
+	}
+}
+
+// write writes the frame unless a write has failed, which must be called under the lock.
+func (sc *screencast) write(data []byte) {
+	if sc.err != nil {
+		return
+	}
+	_, sc.err = sc.w.Write(data)
+}
@@ -0,0 +1,78 @@
+package chromium
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func Test_screencast_Writes_Frames_As_They_Arrive(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sc := &screencast{mu: &sync.Mutex{}, w: buf}
+	sc.frame([]byte("a"), true)
+	sc.frame([]byte("b"), true)
+	assert.Equal(t, "ab", buf.String())
+
+	sc = &screencast{mu: &sync.Mutex{}, w: failingWriter{}}
+	sc.frame([]byte("a"), true)
+	sc.frame([]byte("b"), true)
+	assert.EqualError(t, sc.err, "disk full")
+}
+
+func Test_screencast_Repeats_Latest_Frame_At_Rate(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sc := &screencast{mu: &sync.Mutex{}, w: buf}
+	sc.frame([]byte("a"), false)
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+	sc.tick(ctx, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, buf.Len(), 3)
+	assert.Equal(t, bytes.Repeat([]byte("a"), buf.Len()), buf.Bytes())
+}
+
+// syncBuffer is a buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(data)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+func Test_StartScreencast_Writes_JPEG_Frames(t *testing.T) {
+	_, p, s := setup(t)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	buf := &syncBuffer{}
+
+	assert.NoError(t, p.StartScreencast(buf, ScreencastOptions{Quality: 50, FrameRate: 20}))
+	assert.Error(t, p.StartScreencast(buf, ScreencastOptions{}))
+	p.MustEval(`() => { let n = 0; setInterval(() => { document.body.textContent = String(n++) }, 20) }`)
+	time.Sleep(500 * time.Millisecond)
+	assert.NoError(t, p.StopScreencast())
+	assert.NoError(t, p.StopScreencast())
+
+	data := buf.Bytes()
+	if assert.NotEmpty(t, data) {
+		assert.Equal(t, []byte{0xFF, 0xD8}, data[:2], "expected JPEG frames")
+	}
+}
@@ -0,0 +1,84 @@
+package chromium
+
+import (
+	"errors"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/gson"
+)
+
+// ImageFormat is an encoding of a screenshot.
+type ImageFormat string
+
+const (
+	ImagePNG  ImageFormat = "png"
+	ImageJPEG ImageFormat = "jpeg"
+	ImageWebP ImageFormat = "webp"
+)
+
+// ScreenshotOptions configures Screenshot.
+type ScreenshotOptions struct {
+	// FullPage captures the whole scrollable document rather than the viewport.
+	FullPage bool
+	// Selector clips the capture to the first element matching it, even if it lies beyond the viewport.
+	Selector string
+	// Format of the image, which defaults to PNG.
+	Format ImageFormat
+	// Quality of JPEG or WebP image, ranging from 1 to 100. Zero leaves it to the browser.
+	Quality int
+}
+
+// elementBoxJS returns the bounding box of the element relative to the viewport, along with the scroll offset of the window.
+const elementBoxJS = `() => {
+	const rect = this.getBoundingClientRect();
+	return {x: rect.left, y: rect.top, width: rect.width, height: rect.height, scrollX: window.scrollX, scrollY: window.scrollY};
+}`
+
+// elementBox is the result of elementBoxJS.
+type elementBox struct {
+	proto.DOMRect
+	ScrollX float64 `json:"scrollX"`
+	ScrollY float64 `json:"scrollY"`
+}
+
+// Screenshot captures this page by the options, then returns the encoded image.
+// Note that it shadows Screenshot of rod.Page, which is still available via the embedded page.
+func (p *Page) Screenshot(opts ScreenshotOptions) ([]byte, error) {
+	if len(opts.Format) == 0 {
+		opts.Format = ImagePNG
+	}
+	req := &proto.PageCaptureScreenshot{Format: proto.PageCaptureScreenshotFormat(opts.Format)}
+	if opts.Quality > 0 && opts.Format != ImagePNG {
+		req.Quality = gson.Int(opts.Quality)
+	}
+	if len(opts.Selector) > 0 {
+		clip, err := p.elementClip(opts.Selector)
+		if err != nil {
+			return nil, err
+		}
+		req.Clip, req.CaptureBeyondViewport = clip, true
+	}
+	data, err := p.Page.Screenshot(opts.FullPage, req)
+	if err != nil {
+		return nil, replaceTimeoutError(replaceAbortedError(err))
+	}
+	return data, nil
+}
+
+// elementClip returns the area of the first element matching the selector, in coordinates of the document.
+func (p *Page) elementClip(selector string) (*proto.PageViewport, error) {
+	el, err := p.HasElement(selector)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := el.Eval(elementBoxJS)
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	var box elementBox
+	if err = decodeJSON(obj, &box); err != nil {
+		return nil, err
+	} else if box.Width <= 0 || box.Height <= 0 {
+		return nil, elementError(opScreenshot, selector, errors.New("element is not rendered"))
+	}
+	return &proto.PageViewport{X: box.X + box.ScrollX, Y: box.Y + box.ScrollY, Width: box.Width, Height: box.Height, Scale: 1}, nil
+}
@@ -0,0 +1,65 @@
+package chromium
+
+import (
+	"bytes"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func Test_Screenshot_Encodes_By_Format(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	data, err := p.Screenshot(ScreenshotOptions{})
+	assert.NoError(t, err)
+	_, err = png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err, "expected PNG by default")
+
+	data, err = p.Screenshot(ScreenshotOptions{Format: ImageJPEG, Quality: 50})
+	assert.NoError(t, err)
+	_, format, err := image.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+
+	data, err = p.Screenshot(ScreenshotOptions{Format: ImageWebP})
+	assert.NoError(t, err)
+	assert.Equal(t, "WEBP", string(data[8:12]))
+}
+
+func Test_Screenshot_Clips_To_Element(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => { const el = document.querySelector('#item0'); el.style.display = 'block'; el.style.width = '120px'; el.style.height = '40px'; }`)
+
+	data, err := p.Screenshot(ScreenshotOptions{Selector: "#item0"})
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 120, img.Bounds().Dx())
+		assert.Equal(t, 40, img.Bounds().Dy())
+	}
+}
+
+func Test_Screenshot_Returns_Err_When_Element_Missing(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	_, err := p.Screenshot(ScreenshotOptions{Selector: "#missing"})
+	assert.ErrorIs(t, err, ElementMissing)
+}
+
+func Test_Screenshot_Captures_Full_Page(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => document.body.style.height = '5000px'`)
+
+	data, err := p.Screenshot(ScreenshotOptions{FullPage: true})
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	if assert.NoError(t, err) {
+		assert.GreaterOrEqual(t, img.Bounds().Dy(), 5000)
+	}
+}
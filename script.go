@@ -0,0 +1,156 @@
+package chromium
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"time"
+)
+
+// Duration is a time.Duration that is serialized as a string such as "1.5s".
+// It can be deserialized from either a string or nanoseconds as a number.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return d.set(v)
+}
+
+func (d Duration) MarshalYAML() (any, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+	return d.set(v)
+}
+
+// set parses either a duration string or a number of nanoseconds.
+func (d *Duration) set(v any) error {
+	switch value := v.(type) {
+	case string:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(value)
+	case int:
+		*d = Duration(value)
+	default:
+		return fmt.Errorf("invalid duration: %+v", v)
+	}
+	return nil
+}
+
+// ScriptResult is an outcome of RunScript.
+type ScriptResult struct {
+	// Steps are executed steps, along with their timings and errors.
+	Steps []Step `json:"steps" yaml:"steps"`
+	// Extracted holds values of extract steps by their names.
+	Extracted map[string][]string `json:"extracted" yaml:"extracted"`
+}
+
+// ParseScript decodes a script from a YAML or JSON document.
+func ParseScript(data []byte) (Script, error) {
+	script := Script{}
+	if err := yaml.Unmarshal(data, &script); err != nil {
+		return script, fmt.Errorf("failed to parse script: %w", err)
+	}
+	return script, nil
+}
+
+// RunScript executes steps of the script against the page in order, without pauses between them.
+// It stops at the first failing step, then returns the result so far with the error along with the index of the step.
+func RunScript(p *Page, script Script) (*ScriptResult, error) {
+	res := &ScriptResult{Steps: make([]Step, 0, len(script.Steps)), Extracted: make(map[string][]string)}
+	begin := time.Now()
+	for i, step := range script.Steps {
+		started := time.Now()
+		values, err := RunStep(p, step)
+		step.Offset, step.Elapsed = Duration(started.Sub(begin)), Duration(time.Since(started))
+		if err != nil {
+			step.Error = err.Error()
+			res.Steps = append(res.Steps, step)
+			return res, fmt.Errorf("step %d, %+v: %w", i, step.Action, err)
+		}
+		res.Steps = append(res.Steps, step)
+		if step.Action == ActionExtract {
+			name := step.Name
+			if len(name) == 0 {
+				name = step.Selector
+			}
+			res.Extracted[name] = append(res.Extracted[name], values...)
+		}
+	}
+	return res, nil
+}
+
+// RunStep executes a single step against the page, then returns extracted values if the step is an extraction.
+// It is the executor of RunScript and Replay, such that tools with their own script format execute steps alike.
+func RunStep(p *Page, step Step) ([]string, error) {
+	switch step.Action {
+	case ActionNavigate:
+		return nil, p.TryNavigate(step.URL, func(*Page) bool { return true }, 0)
+	case ActionClick:
+		if step.Timeout <= 0 {
+			return nil, p.TryClick(step.Selector)
+		}
+		return nil, p.ClickNavigate(step.Selector, time.Duration(step.Timeout))
+	case ActionInput:
+		return nil, p.TryInput(step.Selector, step.Text)
+	case ActionSelect:
+		return nil, p.TrySelect(step.Selector, step.Values...)
+	case ActionCheck:
+		return nil, p.TryCheck(step.Selector, step.Checked)
+	case ActionWait:
+		_, err := p.WaitVisibleElement(step.Selector)
+		return nil, err
+	case ActionWaitJS:
+		if step.Timeout <= 0 {
+			return nil, p.WaitJSObject(step.Text)
+		}
+		return nil, p.WaitJSObjectFor(step.Text, time.Duration(step.Timeout))
+	case ActionExtract:
+		return extract(p, step.Selector, step.Attribute)
+	}
+	return nil, fmt.Errorf("unknown action: %+v", step.Action)
+}
+
+// extract returns text, or the attribute if not empty, of every element matching the selector of any kind.
+// Elements without the attribute are skipped.
+func extract(p *Page, selector, attribute string) ([]string, error) {
+	found, err := elements(p.Page, selector)
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	values := make([]string, 0, len(found))
+	for _, el := range found {
+		if len(attribute) == 0 {
+			text, err := el.Text()
+			if err != nil {
+				return nil, replaceAbortedError(err)
+			}
+			values = append(values, text)
+			continue
+		}
+		attr, err := el.Attribute(attribute)
+		if err != nil {
+			return nil, replaceAbortedError(err)
+		} else if attr != nil {
+			values = append(values, *attr)
+		}
+	}
+	return values, nil
+}
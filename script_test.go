@@ -0,0 +1,112 @@
+package chromium
+
+import (
+	"encoding/json"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+	"time"
+)
+
+func Test_Duration_Is_Serialized_As_String(t *testing.T) {
+	data, err := json.Marshal(Duration(time.Millisecond * 1500))
+	assert.NoError(t, err)
+	assert.Equal(t, `"1.5s"`, string(data))
+	data, err = yaml.Marshal(Duration(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, "1s\n", string(data))
+}
+
+func Test_Duration_Is_Deserialized_From_String_Or_Number(t *testing.T) {
+	var d Duration
+	assert.NoError(t, json.Unmarshal([]byte(`"2s"`), &d))
+	assert.Equal(t, Duration(time.Second*2), d)
+	assert.NoError(t, json.Unmarshal([]byte(`1000`), &d))
+	assert.Equal(t, Duration(1000), d)
+	assert.NoError(t, yaml.Unmarshal([]byte(`3m`), &d))
+	assert.Equal(t, Duration(time.Minute*3), d)
+	assert.NoError(t, yaml.Unmarshal([]byte(`42`), &d))
+	assert.Equal(t, Duration(42), d)
+	assert.Error(t, json.Unmarshal([]byte(`"soon"`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &d))
+}
+
+func Test_ParseScript_Parses_YAML(t *testing.T) {
+	script, err := ParseScript([]byte(`
+steps:
+  - action: navigate
+    url: https://example.com
+  - action: waitJS
+    text: window.app
+    timeout: 5s
+  - action: extract
+    selector: a
+    attribute: href
+    name: links
+`))
+	assert.NoError(t, err)
+	if assert.Len(t, script.Steps, 3) {
+		assert.Equal(t, "https://example.com", script.Steps[0].URL)
+		assert.Equal(t, Duration(time.Second*5), script.Steps[1].Timeout)
+		assert.Equal(t, "links", script.Steps[2].Name)
+	}
+}
+
+func Test_ParseScript_Parses_JSON(t *testing.T) {
+	script, err := ParseScript([]byte(`{"steps": [{"action": "input", "selector": "#item0", "text": "test", "timeout": "1s"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Step{{Action: ActionInput, Selector: "#item0", Text: "test", Timeout: Duration(time.Second)}}, script.Steps)
+}
+
+func Test_ParseScript_Returns_Err_When_Invalid(t *testing.T) {
+	_, err := ParseScript([]byte(`steps: {`))
+	assert.ErrorContains(t, err, "script")
+}
+
+func Test_RunScript_Returns_Result_So_Far_On_Failure(t *testing.T) {
+	res, err := RunScript(newPage(nil, func() {}), Script{Steps: []Step{{Action: "unknown"}}})
+	assert.ErrorContains(t, err, "step 0")
+	if assert.Len(t, res.Steps, 1) {
+		assert.Contains(t, res.Steps[0].Error, "unknown")
+	}
+}
+
+func Test_RunScript_Extracts_Values_By_Name(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	script := Script{Steps: []Step{
+		{Action: ActionNavigate, URL: s.URL},
+		{Action: ActionWait, Selector: "ul"},
+		{Action: ActionExtract, Selector: "li"},
+		{Action: ActionExtract, Selector: "li", Attribute: "id", Name: "ids"},
+	}}
+	res, err := RunScript(p, script)
+	assert.NoError(t, err)
+	assert.Len(t, res.Steps, 4)
+	assert.Equal(t, []string{"item0", "item1", "item2", "item3", "item4"}, res.Extracted["li"])
+	assert.Equal(t, []string{"item0", "item1", "item2", "item3", "item4"}, res.Extracted["ids"])
+}
+
+func Test_RunScript_Waits_JS_With_Default_Timeout(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	script := Script{Steps: []Step{
+		{Action: ActionNavigate, URL: s.URL},
+		{Action: ActionWaitJS, Text: "document"},
+	}}
+	_, err := RunScript(p, script)
+	assert.NoError(t, err)
+}
+
+func Test_RunScript_Extracts_By_Selector_Kinds(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	script := Script{Steps: []Step{
+		{Action: ActionNavigate, URL: s.URL},
+		{Action: ActionWait, Selector: "ul"},
+		{Action: ActionExtract, Selector: ByXPath("//li"), Attribute: "id", Name: "xpath"},
+		{Action: ActionExtract, Selector: ByDeep("li"), Attribute: "id", Name: "deep"},
+	}}
+	res, err := RunScript(p, script)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"item0", "item1", "item2", "item3", "item4"}, res.Extracted["xpath"])
+	assert.Equal(t, []string{"item0", "item1", "item2", "item3", "item4"}, res.Extracted["deep"])
+}
@@ -0,0 +1,125 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ScrollOptions configures ScrollToEnd.
+type ScrollOptions struct {
+	// ItemSelector matches items of the feed, whose count tells whether new content has appeared, and whose last one
+	// is scrolled into view, such that feeds in scrollable containers load as well.
+	// If empty, the height of the document tells new content instead, and the window is scrolled to its bottom.
+	ItemSelector string
+	// MaxScrolls caps the number of scrolls, which defaults to 50.
+	MaxScrolls int
+	// IdleFor is how long the network must be quiet after a scroll before new content is counted,
+	// which defaults to 500 milliseconds.
+	IdleFor time.Duration
+	// IdleTimeout caps each wait for the network to be quiet, which defaults to ten times IdleFor.
+	// Scrolling stops once a wait reaches it, as the feed keeps a connection busy, such as by long polling.
+	IdleTimeout time.Duration
+	// StallLimit is the number of consecutive scrolls without new content to stop at, which defaults to 2,
+	// such that a slow response does not end the scrolling early.
+	StallLimit int
+}
+
+const (
+	defaultMaxScrolls  = 50
+	defaultScrollIdle  = 500 * time.Millisecond
+	scrollIdleTimeouts = 10 // default IdleTimeout in multiples of IdleFor.
+	defaultStallLimit  = 2
+)
+
+// scrollToEndJS scrolls the last item into view, or the window to its bottom if no item selector is given.
+const scrollToEndJS = `(selector) => {
+	if (!selector) {
+		window.scrollTo(0, (document.scrollingElement || document.documentElement).scrollHeight);
+		return;
+	}
+	const items = document.querySelectorAll(selector);
+	if (items.length > 0) {
+		items[items.length - 1].scrollIntoView({block: 'end'});
+	}
+}`
+
+// contentSizeJS returns the number of items, or the height of the document if no item selector is given.
+const contentSizeJS = `(selector) => selector
+	? document.querySelectorAll(selector).length
+	: (document.scrollingElement || document.documentElement).scrollHeight`
+
+// ScrollToEnd scrolls the page repeatedly to load more content of an infinite feed, waiting for the network to be idle
+// after each scroll, until no new content appears for StallLimit consecutive scrolls or MaxScrolls is reached.
+// Each wait for the network is bound to IdleTimeout and the default timeout of the page, after which scrolling stops
+// without an error, as some feeds keep connections busy.
+func (p *Page) ScrollToEnd(opts ScrollOptions) error {
+	return p.ScrollToEndContext(p.GetContext(), opts)
+}
+
+// ScrollToEndContext is ScrollToEnd that also stops once ctx is done.
+func (p *Page) ScrollToEndContext(ctx context.Context, opts ScrollOptions) error {
+	if opts.MaxScrolls <= 0 {
+		opts.MaxScrolls = defaultMaxScrolls
+	}
+	if opts.IdleFor <= 0 {
+		opts.IdleFor = defaultScrollIdle
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = scrollIdleTimeouts * opts.IdleFor
+	}
+	if opts.StallLimit <= 0 {
+		opts.StallLimit = defaultStallLimit
+	}
+	ctx, cancel := p.mergeContext(ctx)
+	defer cancel()
+	size, err := p.contentSize(ctx, opts.ItemSelector)
+	if err != nil {
+		return err
+	}
+	for scroll, stalls := 0, 0; scroll < opts.MaxScrolls && stalls < opts.StallLimit; scroll++ {
+		if idle, err := p.scrollOnce(ctx, opts); err != nil || !idle {
+			return err
+		}
+		next, err := p.contentSize(ctx, opts.ItemSelector)
+		if err != nil {
+			return err
+		}
+		if next > size {
+			stalls = 0
+		} else {
+			stalls++
+		}
+		size = next
+	}
+	return nil
+}
+
+// scrollOnce scrolls to the end of the content, then waits for the network to be idle within IdleTimeout and
+// the default timeout. It reports false if the network has not been idle in time.
+func (p *Page) scrollOnce(ctx context.Context, opts ScrollOptions) (bool, error) {
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	idleCtx, cancelIdle := context.WithTimeout(ctx, opts.IdleTimeout)
+	defer cancelIdle()
+	wait := p.Context(idleCtx).WaitRequestIdle(opts.IdleFor, nil, nil)
+	if _, err := p.Context(ctx).Eval(scrollToEndJS, opts.ItemSelector); err != nil {
+		return false, replaceTimeoutError(replaceAbortedError(err))
+	}
+	wait()
+	if err := replaceTimeoutError(ctx.Err()); err != nil && !errors.Is(err, TaskTimeout) {
+		return false, err
+	}
+	return idleCtx.Err() == nil, nil
+}
+
+// contentSize returns the size of the content that tells new content, by the rules of ScrollOptions.
+func (p *Page) contentSize(ctx context.Context, selector string) (int, error) {
+	ctx, cancel := p.timeoutContextOf(ctx)
+	defer cancel()
+	obj, err := p.Context(ctx).Eval(contentSizeJS, selector)
+	if err != nil {
+		return 0, replaceTimeoutError(replaceAbortedError(err))
+	}
+	return obj.Value.Int(), nil
+}
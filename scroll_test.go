@@ -0,0 +1,68 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// feedHTML appends five items whenever the window is scrolled near its bottom, up to twenty items.
+const feedHTML = `<html><body>
+<div id="feed"></div>
+<script>
+	const feed = document.getElementById('feed');
+	const more = () => {
+		for (let i = 0; i < 5 && feed.children.length < 20; i++) {
+			const item = document.createElement('div');
+			item.className = 'item';
+			item.style.height = '400px';
+			item.textContent = String(feed.children.length);
+			feed.append(item);
+		}
+	};
+	more();
+	window.addEventListener('scroll', () => {
+		if (window.innerHeight + window.scrollY >= document.body.scrollHeight - 10) {
+			setTimeout(more, 50);
+		}
+	});
+</script>
+</body></html>`
+
+func Test_ScrollToEnd_Loads_All_Items(t *testing.T) {
+	_, p, s := setup(t, []byte(feedHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	assert.NoError(t, p.ScrollToEnd(ScrollOptions{ItemSelector: ".item", IdleFor: 200 * time.Millisecond}))
+	assert.Len(t, p.MustElements(".item"), 20)
+}
+
+func Test_ScrollToEnd_Stops_At_MaxScrolls(t *testing.T) {
+	_, p, s := setup(t, []byte(feedHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	assert.NoError(t, p.ScrollToEnd(ScrollOptions{MaxScrolls: 1, IdleFor: 200 * time.Millisecond}))
+	assert.Len(t, p.MustElements(".item"), 10)
+}
+
+func Test_ScrollToEnd_Stops_Once_Network_Stays_Busy(t *testing.T) {
+	_, p, _ := setup(t)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/poll" {
+			<-r.Context().Done() // a long poll that never answers.
+			return
+		}
+		_, _ = w.Write([]byte(strings.Replace(feedHTML, "more();", `more(); fetch("/poll");`, 1)))
+	}))
+	t.Cleanup(s.Close)
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	begin := time.Now()
+	opts := ScrollOptions{ItemSelector: ".item", IdleFor: 100 * time.Millisecond, IdleTimeout: 300 * time.Millisecond}
+	assert.NoError(t, p.ScrollToEnd(opts))
+	assert.Less(t, time.Since(begin), 2*time.Second)
+	assert.Len(t, p.MustElements(".item"), 10)
+}
@@ -0,0 +1,163 @@
+package chromium
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-rod/rod"
+	"strings"
+)
+
+// SelectorKind is a kind of selector, which is also the prefix of a selector string of the kind.
+type SelectorKind string
+
+const (
+	// SelectorCSS selects elements by CSS selector, which is the default kind of selector strings without prefix.
+	SelectorCSS SelectorKind = "css"
+	// SelectorXPath selects elements by XPath, such as "xpath=//a[@href]". Selector strings beginning with '/' are XPath too.
+	SelectorXPath SelectorKind = "xpath"
+	// SelectorText selects the innermost element whose own text contains the value, such as "text=Sign in".
+	SelectorText SelectorKind = "text"
+	// SelectorARIA selects an element by its accessible label of aria-label attribute, such as "aria=Close".
+	SelectorARIA SelectorKind = "aria"
+	// SelectorDeep selects elements by CSS selector, looking into open shadow roots as well, such as "deep=input.name".
+	SelectorDeep SelectorKind = "deep"
+)
+
+// deepQueryJS returns the first element matching the selector in the document or any open shadow root within it,
+// looking into the light DOM of each root before its shadow roots.
+const deepQueryJS = `(selector) => {
+	const query = (root) => {
+		const found = root.querySelector(selector);
+		if (found) return found;
+		for (const el of root.querySelectorAll('*')) {
+			if (el.shadowRoot) {
+				const inner = query(el.shadowRoot);
+				if (inner) return inner;
+			}
+		}
+		return null;
+	};
+	return query(document);
+}`
+
+// deepQueryAllJS returns every element matching the selector in the document and any open shadow root within it,
+// in the order that deepQueryJS looks them up.
+const deepQueryAllJS = `(selector) => {
+	const found = [];
+	const query = (root) => {
+		found.push(...root.querySelectorAll(selector));
+		for (const el of root.querySelectorAll('*')) {
+			if (el.shadowRoot) query(el.shadowRoot);
+		}
+	};
+	query(document);
+	return found;
+}`
+
+// Selector locates elements of a page by the kind.
+// Helpers of Page accept the string form of a Selector wherever they take a selector,
+// such that XPath, text and ARIA selectors can be used alike CSS selectors.
+type Selector struct {
+	Kind  SelectorKind
+	Value string
+}
+
+// String returns the form of the selector that helpers of Page accept, which is prefixed by its kind unless CSS.
+func (s Selector) String() string {
+	if s.Kind == SelectorCSS || len(s.Kind) == 0 {
+		return s.Value
+	}
+	return string(s.Kind) + "=" + s.Value
+}
+
+// ParseSelector parses a selector string by its prefix, such as "xpath=", "text=" and "aria=".
+// Strings without known prefix are CSS selectors, except those beginning with '/' which are XPath.
+func ParseSelector(selector string) Selector {
+	for _, kind := range []SelectorKind{SelectorCSS, SelectorXPath, SelectorText, SelectorARIA, SelectorDeep} {
+		if prefix := string(kind) + "="; strings.HasPrefix(selector, prefix) {
+			return Selector{Kind: kind, Value: selector[len(prefix):]}
+		}
+	}
+	if strings.HasPrefix(selector, "/") {
+		return Selector{Kind: SelectorXPath, Value: selector}
+	}
+	return Selector{Kind: SelectorCSS, Value: selector}
+}
+
+// ByXPath returns a selector string of the XPath.
+func ByXPath(xpath string) string {
+	return Selector{Kind: SelectorXPath, Value: xpath}.String()
+}
+
+// ByText returns a selector string of the innermost element whose own text contains the text.
+func ByText(text string) string {
+	return Selector{Kind: SelectorText, Value: text}.String()
+}
+
+// ByARIA returns a selector string of an element whose aria-label is the label.
+func ByARIA(label string) string {
+	return Selector{Kind: SelectorARIA, Value: label}.String()
+}
+
+// ByDeep returns a selector string of the CSS selector that also matches elements inside open shadow roots.
+func ByDeep(selector string) string {
+	return Selector{Kind: SelectorDeep, Value: selector}.String()
+}
+
+// xpath returns the XPath that the selector is equivalent to, reporting false for CSS and deep selectors.
+func (s Selector) xpath() (string, bool) {
+	switch s.Kind {
+	case SelectorXPath:
+		return s.Value, true
+	case SelectorText:
+		return fmt.Sprintf("//*[text()[contains(normalize-space(.), %s)]]", xpathLiteral(s.Value)), true
+	case SelectorARIA:
+		return fmt.Sprintf("//*[@aria-label=%s]", xpathLiteral(s.Value)), true
+	}
+	return "", false
+}
+
+// has looks up the first element matching the selector string on the page, without waiting for it.
+func has(page *rod.Page, selector string) (bool, *rod.Element, error) {
+	s := ParseSelector(selector)
+	if xpath, ok := s.xpath(); ok {
+		return page.HasX(xpath)
+	}
+	switch s.Kind {
+	case SelectorDeep:
+		el, err := page.Sleeper(rod.NotFoundSleeper).ElementByJS(rod.Eval(deepQueryJS, s.Value))
+		if errors.Is(err, &rod.ErrElementNotFound{}) {
+			return false, nil, nil
+		} else if err != nil {
+			return false, nil, err
+		}
+		return true, el, nil
+	default:
+		return page.Has(s.Value)
+	}
+}
+
+// elements looks up every element matching the selector string on the page, without waiting for them.
+func elements(page *rod.Page, selector string) (rod.Elements, error) {
+	s := ParseSelector(selector)
+	if xpath, ok := s.xpath(); ok {
+		return page.ElementsX(xpath)
+	} else if s.Kind == SelectorDeep {
+		return page.ElementsByJS(rod.Eval(deepQueryAllJS, s.Value))
+	}
+	return page.Elements(s.Value)
+}
+
+// xpathLiteral quotes the value as a string literal of XPath, which has no escape sequence.
+func xpathLiteral(value string) string {
+	if !strings.Contains(value, `"`) {
+		return `"` + value + `"`
+	} else if !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+	parts := strings.Split(value, `"`)
+	for i, part := range parts {
+		parts[i] = `"` + part + `"`
+	}
+	return "concat(" + strings.Join(parts, `, '"', `) + ")"
+}
@@ -0,0 +1,88 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ParseSelector_Parses_Kind_By_Prefix(t *testing.T) {
+	assert.Equal(t, Selector{Kind: SelectorCSS, Value: "li > a"}, ParseSelector("li > a"))
+	assert.Equal(t, Selector{Kind: SelectorCSS, Value: "a"}, ParseSelector("css=a"))
+	assert.Equal(t, Selector{Kind: SelectorXPath, Value: "//a"}, ParseSelector("xpath=//a"))
+	assert.Equal(t, Selector{Kind: SelectorXPath, Value: "//a"}, ParseSelector("//a"))
+	assert.Equal(t, Selector{Kind: SelectorText, Value: "Sign in"}, ParseSelector("text=Sign in"))
+	assert.Equal(t, Selector{Kind: SelectorARIA, Value: "Close"}, ParseSelector("aria=Close"))
+	assert.Equal(t, Selector{Kind: SelectorDeep, Value: "input"}, ParseSelector("deep=input"))
+	assert.Equal(t, Selector{Kind: SelectorCSS, Value: "input[name=q]"}, ParseSelector("input[name=q]"))
+}
+
+func Test_Selector_String_Round_Trips(t *testing.T) {
+	for _, s := range []string{"li", ByXPath("//li"), ByText("first"), ByARIA("Close"), ByDeep("input")} {
+		assert.Equal(t, s, ParseSelector(s).String())
+	}
+	assert.Equal(t, "li", Selector{Value: "li"}.String())
+}
+
+func Test_xpathLiteral_Quotes_Value(t *testing.T) {
+	assert.Equal(t, `"it's"`, xpathLiteral(`it's`))
+	assert.Equal(t, `'say "hi"'`, xpathLiteral(`say "hi"`))
+	assert.Equal(t, `concat("it's ", '"', "hi", '"', "")`, xpathLiteral(`it's "hi"`))
+}
+
+func Test_Selector_xpath_Converts_XPath_Kinds(t *testing.T) {
+	xpath, ok := ParseSelector("aria=Close").xpath()
+	assert.True(t, ok)
+	assert.Equal(t, `//*[@aria-label="Close"]`, xpath)
+	xpath, ok = ParseSelector("//li").xpath()
+	assert.True(t, ok)
+	assert.Equal(t, "//li", xpath)
+	_, ok = ParseSelector("deep=li").xpath()
+	assert.False(t, ok)
+	_, ok = ParseSelector("li").xpath()
+	assert.False(t, ok)
+}
+
+func Test_HasElement_Accepts_Selector_Kinds(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => document.body.insertAdjacentHTML("beforeend", '<button aria-label="Close">x</button><p>Sign <b>in</b> here</p>')`)
+
+	el, err := p.HasElement(ByXPath("//li"))
+	assert.NoError(t, err)
+	assert.Equal(t, "LI", el.MustEval(`function() { return this.tagName }`).Str())
+
+	el, err = p.HasElement(ByARIA("Close"))
+	assert.NoError(t, err)
+	assert.Equal(t, "x", el.MustText())
+
+	el, err = p.HasElement(ByText("here"))
+	assert.NoError(t, err)
+	assert.Equal(t, "P", el.MustEval(`function() { return this.tagName }`).Str())
+
+	_, err = p.HasElement(ByText("never"))
+	assert.ErrorIs(t, err, ElementMissing)
+}
+
+func Test_HasElementDeep_Finds_Element_In_Shadow_Root(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => {
+		const outer = document.createElement("div");
+		document.body.appendChild(outer);
+		const inner = document.createElement("section");
+		outer.attachShadow({mode: "open"}).appendChild(inner);
+		inner.attachShadow({mode: "open"}).innerHTML = '<input class="name">';
+	}`)
+
+	_, err := p.HasElement("input.name")
+	assert.ErrorIs(t, err, ElementMissing)
+	el, err := p.HasElementDeep("input.name")
+	assert.NoError(t, err)
+	assert.NotNil(t, el)
+	assert.NoError(t, p.TryInput(ByDeep("input.name"), "test"))
+	assert.Equal(t, "test", el.MustProperty("value").Str())
+
+	_, err = p.HasElementDeep("#never")
+	assert.ErrorIs(t, err, ElementMissing)
+}
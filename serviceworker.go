@@ -0,0 +1,56 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// unregisterServiceWorkersJS unregisters the service workers of the current origin, of which there is none on
+// insecure or opaque origins such as about:blank.
+const unregisterServiceWorkersJS = `async () => {
+	if (!navigator.serviceWorker) return;
+	const registrations = await navigator.serviceWorker.getRegistrations();
+	await Promise.all(registrations.map(r => r.unregister()));
+}`
+
+// BypassServiceWorkers lets requests of this page bypass service workers if bypass is set, such that progressive web
+// apps that cache aggressively do not serve stale content to repeated navigations, or lets them through otherwise.
+// Bypassing leaves service workers registered, unlike UnregisterServiceWorkers.
+// The bypass persists across navigations, and is restored when the tab of the page is replaced by the browser.
+func (p *Page) BypassServiceWorkers(bypass bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := bypassServiceWorkers(p.Page, bypass); err != nil {
+		return err
+	}
+	p.bypassWorkers = bypass
+	return nil
+}
+
+// restoreServiceWorkers bypasses service workers of given tab if this page does so, as the tab is about to replace
+// the current tab.
+func (p *Page) restoreServiceWorkers(page *rod.Page) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if !p.bypassWorkers {
+		return nil
+	}
+	return bypassServiceWorkers(page, true)
+}
+
+// bypassServiceWorkers sets the bypass to the tab, leaving the network domain enabled, which keeps it across navigations.
+func bypassServiceWorkers(page *rod.Page, bypass bool) error {
+	if err := (proto.NetworkEnable{}).Call(page); err != nil {
+		return replaceAbortedError(err)
+	}
+	return replaceAbortedError(proto.NetworkSetBypassServiceWorker{Bypass: bypass}.Call(page))
+}
+
+// UnregisterServiceWorkers unregisters the service workers of the current origin of this page, such that the next
+// navigation is served by the network. Documents that are controlled by the workers are left controlled until they
+// are navigated away, thus reload the page to take effect.
+// Note that service workers are shared by pages of the same browser context and origin.
+func (p *Page) UnregisterServiceWorkers() error {
+	_, err := p.Eval(unregisterServiceWorkersJS)
+	return replaceTimeoutError(replaceAbortedError(err))
+}
@@ -0,0 +1,100 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newServiceWorkerServer returns a server whose page registers a service worker, which answers /data with "stale"
+// while the server answers it with "fresh".
+func newServiceWorkerServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><script>navigator.serviceWorker.register("/sw.js")</script></body></html>`))
+	})
+	mux.HandleFunc("/sw.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript")
+		_, _ = w.Write([]byte(`
+			self.addEventListener("install", () => self.skipWaiting());
+			self.addEventListener("activate", (e) => e.waitUntil(self.clients.claim()));
+			self.addEventListener("fetch", (e) => {
+				if (new URL(e.request.url).pathname === "/data") e.respondWith(new Response("stale"));
+			});`))
+	})
+	mux.HandleFunc("/data", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("fresh"))
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// controlledPage returns a page of the shared setup that is controlled by the service worker of newServiceWorkerServer.
+func controlledPage(t *testing.T) *Page {
+	_, p, _ := setup(t)
+	navigateControlled(t, p)
+	return p
+}
+
+// navigateControlled navigates the page to a new server of newServiceWorkerServer, then waits for its service worker
+// to control the page.
+func navigateControlled(t *testing.T, p *Page) {
+	s := newServiceWorkerServer(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	p.MustEval(`() => navigator.serviceWorker.ready.then(() => new Promise(resolve => {
+		if (navigator.serviceWorker.controller) return resolve();
+		navigator.serviceWorker.addEventListener("controllerchange", resolve);
+	}))`)
+}
+
+// fetchDataJS fetches /data of the current origin as text.
+const fetchDataJS = `() => fetch("/data").then(r => r.text())`
+
+func Test_ServiceWorker_Answers_Controlled_Page(t *testing.T) {
+	p := controlledPage(t)
+	assert.Equal(t, "stale", p.MustEval(fetchDataJS).String())
+}
+
+func Test_BypassServiceWorkers_Sends_Requests_To_Network(t *testing.T) {
+	p := controlledPage(t)
+	assert.NoError(t, p.BypassServiceWorkers(true))
+	assert.Equal(t, "fresh", p.MustEval(fetchDataJS).String())
+}
+
+func Test_BypassServiceWorkers_Can_Be_Turned_Off(t *testing.T) {
+	p := controlledPage(t)
+	assert.NoError(t, p.BypassServiceWorkers(true))
+	assert.NoError(t, p.BypassServiceWorkers(false))
+	assert.Equal(t, "stale", p.MustEval(fetchDataJS).String())
+}
+
+func Test_BypassServiceWorkers_Is_Restored_After_Page_Is_Recycled(t *testing.T) {
+	p, _ := recycledPage(t, func(p *Page) { assert.NoError(t, p.BypassServiceWorkers(true)) })
+	navigateControlled(t, p)
+	assert.Equal(t, "fresh", p.MustEval(fetchDataJS).String())
+}
+
+func Test_BypassServiceWorkers_Is_Carried_By_Duplicate(t *testing.T) {
+	p := controlledPage(t)
+	assert.NoError(t, p.BypassServiceWorkers(true))
+	dup, err := p.Duplicate()
+	assert.NoError(t, err)
+	t.Cleanup(dup.CleanUp)
+	dup.MustWaitLoad()
+	assert.Equal(t, "fresh", dup.MustEval(fetchDataJS).String())
+}
+
+func Test_UnregisterServiceWorkers_Removes_Registrations(t *testing.T) {
+	p := controlledPage(t)
+	assert.NoError(t, p.UnregisterServiceWorkers())
+	count := p.MustEval(`() => navigator.serviceWorker.getRegistrations().then(rs => rs.length)`)
+	assert.Equal(t, 0, count.Int())
+}
+
+func Test_UnregisterServiceWorkers_Ignores_Page_Without_Origin(t *testing.T) {
+	_, p, _ := setup(t)
+	assert.NoError(t, p.Navigate(blankURL))
+	assert.NoError(t, p.UnregisterServiceWorkers())
+}
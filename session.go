@@ -0,0 +1,104 @@
+package chromium
+
+import (
+	"encoding/json"
+	"github.com/go-rod/rod/lib/proto"
+	"io"
+)
+
+// Session is a persisted login state of a page, which consists of cookies of the browser context,
+// and storages of the origin of the page.
+type Session struct {
+	URL            string                 `json:"url"`
+	Cookies        []*proto.NetworkCookie `json:"cookies"`
+	LocalStorage   map[string]string      `json:"localStorage"`
+	SessionStorage map[string]string      `json:"sessionStorage"`
+}
+
+// storagesJS collects localStorage and sessionStorage of the current origin.
+const storagesJS = `() => {
+	const entries = (storage) => {
+		const items = {};
+		for (let i = 0; i < storage.length; i++) {
+			const key = storage.key(i);
+			items[key] = storage.getItem(key);
+		}
+		return items;
+	};
+	return {url: location.href, localStorage: entries(localStorage), sessionStorage: entries(sessionStorage)};
+}`
+
+// restoreStoragesJS fills storages on every new document of the same origin as the session, before scripts of the page.
+const restoreStoragesJS = `(session) => {
+	if (location.origin !== new URL(session.url).origin) return;
+	for (const [key, value] of Object.entries(session.localStorage || {})) localStorage.setItem(key, value);
+	for (const [key, value] of Object.entries(session.sessionStorage || {})) sessionStorage.setItem(key, value);
+}`
+
+// SaveSession writes the session of this page as JSON, which consists of all cookies of the browser context,
+// along with localStorage and sessionStorage of the current origin.
+func (p *Page) SaveSession(w io.Writer) error {
+	session := &Session{}
+	if err := p.evalJSON(session, storagesJS); err != nil {
+		return err
+	}
+	cookies, err := proto.NetworkGetAllCookies{}.Call(p)
+	if err != nil {
+		return replaceAbortedError(err)
+	}
+	session.Cookies = cookies.Cookies
+	return json.NewEncoder(w).Encode(session)
+}
+
+// RestoreSession reads a session that is written by SaveSession, then restores its cookies and storages,
+// and navigates this page to the URL of the session.
+// Storages are filled before scripts of the page run, such that the page finds itself logged in on its first load.
+func (p *Page) RestoreSession(r io.Reader) error {
+	session := &Session{}
+	if err := json.NewDecoder(r).Decode(session); err != nil {
+		return err
+	}
+	if len(session.Cookies) > 0 {
+		params := make([]*proto.NetworkCookieParam, 0, len(session.Cookies))
+		for _, c := range session.Cookies {
+			params = append(params, cookieParamOf(c))
+		}
+		if err := (proto.NetworkSetCookies{Cookies: params}).Call(p); err != nil {
+			return replaceAbortedError(err)
+		}
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	remove, err := p.EvalOnNewDocument("(" + restoreStoragesJS + ")(" + string(data) + ")")
+	if err != nil {
+		return replaceAbortedError(err)
+	}
+	defer func() { _ = remove() }()
+	if err = p.Navigate(session.URL); err != nil {
+		return replaceAbortedError(err)
+	}
+	return replaceAbortedError(p.WaitLoad())
+}
+
+// cookieParamOf converts the cookie into a parameter to set it back as it was.
+func cookieParamOf(c *proto.NetworkCookie) *proto.NetworkCookieParam {
+	param := &proto.NetworkCookieParam{
+		Name:         c.Name,
+		Value:        c.Value,
+		Domain:       c.Domain,
+		Path:         c.Path,
+		Secure:       c.Secure,
+		HTTPOnly:     c.HTTPOnly,
+		SameSite:     c.SameSite,
+		Priority:     c.Priority,
+		SameParty:    c.SameParty,
+		SourceScheme: c.SourceScheme,
+		PartitionKey: c.PartitionKey,
+	}
+	if !c.Session {
+		param.Expires = c.Expires
+	}
+	return param
+}
@@ -0,0 +1,43 @@
+package chromium
+
+import (
+	"bytes"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_cookieParamOf_Drops_Expiry_Of_Session_Cookie(t *testing.T) {
+	param := cookieParamOf(&proto.NetworkCookie{Name: "a", Value: "b", Domain: "example.com", Session: true, Expires: -1})
+	assert.Equal(t, "a", param.Name)
+	assert.Equal(t, "example.com", param.Domain)
+	assert.Zero(t, param.Expires)
+	assert.Equal(t, proto.TimeSinceEpoch(2000), cookieParamOf(&proto.NetworkCookie{Expires: 2000}).Expires)
+}
+
+func Test_RestoreSession_Returns_Err_When_Malformed(t *testing.T) {
+	assert.Error(t, newPage(nil, func() {}).RestoreSession(strings.NewReader("{")))
+}
+
+func Test_SaveSession_Then_RestoreSession_Restores_Cookies_And_Storages(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => {
+		document.cookie = 'session=test';
+		localStorage.setItem('token', 'local');
+		sessionStorage.setItem('tab', 'session');
+	}`)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, p.SaveSession(buf))
+
+	assert.NoError(t, p.ClearCookies())
+	p.MustEval(`() => { localStorage.clear(); sessionStorage.clear(); }`)
+	p.MustNavigate(blankURL).MustWaitLoad()
+
+	assert.NoError(t, p.RestoreSession(buf))
+	assert.Contains(t, p.MustEval(`() => document.cookie`).String(), "session=test")
+	assert.Equal(t, "local", p.MustEval(`() => localStorage.getItem('token')`).String())
+	assert.Equal(t, "session", p.MustEval(`() => sessionStorage.getItem('tab')`).String())
+}
@@ -1,13 +1,15 @@
 package chromium
 
 import (
+	"context"
+	"github.com/go-rod/rod"
 	"github.com/state303/chromium/internal/test/testfile"
 	"github.com/state303/chromium/internal/test/testserver"
 	"testing"
 )
 
 // Prepares and brings a new instance of browser, or fail test if browser instantiation fails
-func PrepareBrowser(t *testing.T, pagePoolSize int) *Browser {
+func PrepareBrowser(t testing.TB, pagePoolSize int) *Browser {
 	b, err := NewBrowser(pagePoolSize)
 	if err != nil {
 		t.Logf("failed to instantiate new browser: %+v", err.Error())
@@ -32,6 +34,22 @@ func setup(t *testing.T, payload ...[]byte) (*Browser, *Page, *testserver.TestSe
 	return b, p, s
 }
 
+// setupBenchmark is a setup for benchmarks, which is not run in parallel.
+func setupBenchmark(b *testing.B, payload ...[]byte) (*Page, *testserver.TestServer) {
+	browser := PrepareBrowser(b, 1)
+	p := browser.GetPage()
+	b.Cleanup(func() { browser.PutPage(p); browser.CleanUp() })
+	s := testserver.WithRotatingResponses(b, payload...)
+	b.Cleanup(s.Close)
+	return p, s
+}
+
+// detachedPage returns a page without a tab, bound to the context, for tests of helpers that only need the context
+// of a page.
+func detachedPage(ctx context.Context) *Page {
+	return newPage((&rod.Page{}).Context(ctx), func() {})
+}
+
 // makeItems makes slice of type T that are filled with n copies of 'before', and single 'after' item.
 // Simply expect total size of slice will be n+1.
 // Also given n is negative, the value will be set as 0
@@ -0,0 +1,36 @@
+package chromium
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Shutdown stops handing out pages, drains submitted tasks, then cleans up the browser, all within given timeout.
+// It returns TaskTimeout if the shutdown does not complete within the timeout, while the clean-up continues in background.
+// Zero or negative timeout waits indefinitely.
+func (b *Browser) Shutdown(timeout time.Duration) error {
+	b.stopServing()
+	done := make(chan struct{})
+	go func() { defer close(done); b.CleanUp() }()
+	if timeout <= 0 {
+		<-done
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return TaskTimeout
+	}
+}
+
+// HandleSignals blocks until SIGINT or SIGTERM arrives or the context is done, then shuts the browser down.
+// Shutdown and its timeout follow Browser.Shutdown.
+func (b *Browser) HandleSignals(ctx context.Context, timeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	return b.Shutdown(timeout)
+}
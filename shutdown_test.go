@@ -0,0 +1,51 @@
+package chromium
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_GetPage_Returns_Nil_After_Stop_Serving(t *testing.T) {
+	b := &Browser{pagePool: make(PagePool, 1), mu: &sync.Mutex{}, closed: make(chan struct{})}
+	b.pagePool.Put(newPage(nil, func() {}))
+	b.stopServing()
+	b.stopServing()
+	assert.Nil(t, b.GetPage())
+}
+
+func Test_GetPage_Unblocks_When_Stop_Serving(t *testing.T) {
+	b := &Browser{pagePool: make(PagePool, 1), mu: &sync.Mutex{}, closed: make(chan struct{})}
+	time.AfterFunc(time.Millisecond*10, b.stopServing)
+	assert.Nil(t, b.GetPage())
+}
+
+func Test_RunAll_Returns_BrowserClosed_After_Stop_Serving(t *testing.T) {
+	b := newTaskBrowser(1, 0)
+	b.closed = make(chan struct{})
+	b.stopServing()
+	results, err := RunAll(b, []int{1}, func(p *Page, in int) (int, error) { return in, nil })
+	assert.ErrorIs(t, err, BrowserClosed)
+	assert.ErrorIs(t, results[0].Err, BrowserClosed)
+}
+
+func Test_HandleSignals_Shuts_Down_When_Context_Done(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond*10, cancel)
+	assert.NoError(t, b.HandleSignals(ctx, time.Second*5))
+	assert.Nil(t, b.GetPage())
+}
+
+func Test_Shutdown_Returns_TaskTimeout_When_Page_Is_Not_Returned(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1)
+	assert.NoError(t, err)
+	p := b.GetPage()
+	assert.ErrorIs(t, b.Shutdown(time.Millisecond*50), TaskTimeout)
+	b.PutPage(p)
+}
@@ -0,0 +1,93 @@
+package chromium
+
+// PageState is a snapshot of a page, from which an interrupted flow can resume close to where it left off.
+type PageState struct {
+	URL     string       `json:"url"`
+	ScrollX float64      `json:"scrollX"`
+	ScrollY float64      `json:"scrollY"`
+	Fields  []FieldState `json:"fields"`
+}
+
+// FieldState is a value of a form field, located by a selector that is generated on the snapshot.
+type FieldState struct {
+	Selector string   `json:"selector"`
+	Value    string   `json:"value,omitempty"`
+	Values   []string `json:"values,omitempty"` // selected values of a multiple select.
+	Checked  bool     `json:"checked,omitempty"`
+}
+
+// snapshotStateJS collects the scroll position and values of form fields, except passwords and files.
+const snapshotStateJS = `() => {
+	const selectorOf = (el) => {
+		const path = [];
+		for (; el && el.nodeType === Node.ELEMENT_NODE; el = el.parentElement) {
+			if (el.id) {
+				path.unshift('#' + CSS.escape(el.id));
+				break;
+			}
+			let index = 1;
+			for (let sibling = el.previousElementSibling; sibling; sibling = sibling.previousElementSibling) {
+				if (sibling.tagName === el.tagName) index++;
+			}
+			path.unshift(el.tagName.toLowerCase() + ':nth-of-type(' + index + ')');
+		}
+		return path.join(' > ');
+	};
+	const skipped = ['password', 'file', 'submit', 'button', 'image', 'reset', 'hidden'];
+	const fields = [];
+	for (const el of document.querySelectorAll('input, textarea, select')) {
+		if (skipped.includes(el.type)) continue;
+		const field = {selector: selectorOf(el)};
+		if (el.type === 'checkbox' || el.type === 'radio') {
+			field.checked = el.checked;
+		} else if (el.multiple) {
+			field.values = Array.from(el.selectedOptions).map(o => o.value);
+		} else {
+			field.value = el.value;
+		}
+		fields.push(field);
+	}
+	return {url: location.href, scrollX: window.scrollX, scrollY: window.scrollY, fields: fields};
+}`
+
+// restoreStateJS applies values of form fields and the scroll position, dispatching events as if a user typed them.
+const restoreStateJS = `(state) => {
+	for (const field of state.fields || []) {
+		const el = document.querySelector(field.selector);
+		if (!el) continue;
+		if (el.type === 'checkbox' || el.type === 'radio') {
+			el.checked = !!field.checked;
+		} else if (el.multiple) {
+			const values = field.values || [];
+			for (const option of el.options) option.selected = values.includes(option.value);
+		} else {
+			el.value = field.value || '';
+		}
+		el.dispatchEvent(new Event('input', {bubbles: true}));
+		el.dispatchEvent(new Event('change', {bubbles: true}));
+	}
+	window.scrollTo(state.scrollX, state.scrollY);
+}`
+
+// SnapshotState captures the URL, scroll position, and values of form fields of this page.
+// Password and file fields are not captured.
+func (p *Page) SnapshotState() (*PageState, error) {
+	state := &PageState{}
+	if err := p.evalJSON(state, snapshotStateJS); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// RestoreState navigates this page to the URL of the state, then restores values of form fields and scroll position.
+// Fields that no longer exist on the page are skipped.
+func (p *Page) RestoreState(state *PageState) error {
+	if err := p.Navigate(state.URL); err != nil {
+		return replaceAbortedError(err)
+	}
+	if err := p.WaitLoad(); err != nil {
+		return replaceAbortedError(err)
+	}
+	_, err := p.Eval(restoreStateJS, state)
+	return replaceAbortedError(err)
+}
@@ -0,0 +1,39 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_SnapshotState_Captures_URL_And_Fields(t *testing.T) {
+	_, p, s := setup(t, testfile.InputTestHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.NoError(t, p.TryInput("#item1", "hello"))
+
+	state, err := p.SnapshotState()
+	assert.NoError(t, err)
+	assert.Equal(t, p.MustInfo().URL, state.URL)
+	assert.Contains(t, state.Fields, FieldState{Selector: "#item1", Value: "hello"})
+	assert.Len(t, state.Fields, 4)
+}
+
+func Test_RestoreState_Restores_Fields_On_Fresh_Document(t *testing.T) {
+	_, p, s := setup(t, testfile.InputTestHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.NoError(t, p.TryInput("#item2", "world"))
+	state, err := p.SnapshotState()
+	assert.NoError(t, err)
+
+	p.MustNavigate(blankURL)
+	assert.NoError(t, p.RestoreState(state))
+	assert.Equal(t, "world", p.MustElement("#item2").MustText())
+}
+
+func Test_SnapshotState_Returns_Err_When_Page_Closed(t *testing.T) {
+	_, p, _ := setup(t)
+	p.CleanUp()
+	state, err := p.SnapshotState()
+	assert.Nil(t, state)
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"strings"
+)
+
+// stealthJS patches the traits by which websites tell a headless browser apart from a desktop one.
+// Each evasion is applied only if the trait gives the browser away, so that it does not break a headful browser.
+const stealthJS = `(() => {
+	const define = (target, name, get) => {
+		try { Object.defineProperty(target, name, {get, configurable: true}); } catch (e) {}
+	};
+
+	// navigator.webdriver is true under automation, while undefined for a regular browser.
+	if (navigator.webdriver !== undefined) {
+		define(Navigator.prototype, 'webdriver', () => undefined);
+	}
+
+	// headless browsers report neither languages nor plugins.
+	if (!navigator.languages || navigator.languages.length === 0) {
+		define(Navigator.prototype, 'languages', () => ['en-US', 'en']);
+	}
+	if (navigator.plugins.length === 0) {
+		const names = ['PDF Viewer', 'Chrome PDF Viewer', 'Chromium PDF Viewer', 'Microsoft Edge PDF Viewer', 'WebKit built-in PDF'];
+		const plugins = Object.create(PluginArray.prototype);
+		names.forEach((name, i) => {
+			const plugin = Object.create(Plugin.prototype);
+			define(plugin, 'name', () => name);
+			define(plugin, 'filename', () => 'internal-pdf-viewer');
+			define(plugin, 'description', () => 'Portable Document Format');
+			define(plugin, 'length', () => 0);
+			plugins[i] = plugin;
+		});
+		define(plugins, 'length', () => names.length);
+		plugins.item = i => plugins[i] || null;
+		plugins.namedItem = name => Array.from({length: names.length}, (_, i) => plugins[i]).find(p => p.name === name) || null;
+		plugins.refresh = () => {};
+		define(Navigator.prototype, 'plugins', () => plugins);
+	}
+
+	// user agent and app version carry "HeadlessChrome" instead of "Chrome".
+	const userAgent = navigator.userAgent.replace('HeadlessChrome', 'Chrome');
+	if (userAgent !== navigator.userAgent) {
+		define(Navigator.prototype, 'userAgent', () => userAgent);
+		define(Navigator.prototype, 'appVersion', () => userAgent.replace(/^Mozilla\//, ''));
+	}
+
+	// WebGL of headless browsers is rendered by software, which is named after Google SwiftShader.
+	const UNMASKED_VENDOR = 37445, UNMASKED_RENDERER = 37446;
+	for (const context of [window.WebGLRenderingContext, window.WebGL2RenderingContext]) {
+		if (!context) continue;
+		const getParameter = context.prototype.getParameter;
+		context.prototype.getParameter = function (parameter) {
+			if (parameter === UNMASKED_VENDOR) return 'Intel Inc.';
+			if (parameter === UNMASKED_RENDERER) return 'Intel Iris OpenGL Engine';
+			return getParameter.call(this, parameter);
+		};
+	}
+
+	// window.chrome and its runtime are present in a regular browser.
+	if (!window.chrome) {
+		Object.defineProperty(window, 'chrome', {value: {}, writable: true, configurable: true});
+	}
+	if (!window.chrome.runtime) {
+		window.chrome.runtime = {connect: () => {}, sendMessage: () => {}, id: undefined};
+	}
+
+	// permission of notifications is queried as "prompt" while Notification.permission is "denied" in headless.
+	if (navigator.permissions && window.Notification) {
+		const query = navigator.permissions.query.bind(navigator.permissions);
+		navigator.permissions.query = parameters => parameters && parameters.name === 'notifications'
+			? Promise.resolve({state: Notification.permission, onchange: null})
+			: query(parameters);
+	}
+})()`
+
+// WithStealth makes pages of the pool evade the common detections of headless browsers, as SetStealth does.
+func WithStealth() BrowserOption {
+	return func(o *browserOptions) {
+		o.stealth = true
+	}
+}
+
+// SetStealth applies the usual evasions of headless detections to this page, which removes navigator.webdriver,
+// fakes plugins and languages if missing, patches the vendor and renderer of WebGL, shims chrome.runtime,
+// and replaces "HeadlessChrome" by "Chrome" of the user agent, including the one sent along with requests.
+// The evasions are applied to documents loaded afterwards, and persist even if the page is replaced by a fresh tab.
+// Note that ApplyIdentity with a user agent overrides the user agent set by this.
+func (p *Page) SetStealth() error {
+	if _, err := p.AddInitScript(stealthJS); err != nil {
+		return err
+	}
+	if err := setStealthUserAgent(p.Page); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stealth = true
+	return nil
+}
+
+// restoreStealth sets the user agent of given tab, which is about to replace the current tab, if this page is stealth.
+// Init scripts of the evasions are restored by restoreInitScripts.
+func (p *Page) restoreStealth(page *rod.Page) error {
+	p.mu.RLock()
+	stealth := p.stealth
+	p.mu.RUnlock()
+	if !stealth {
+		return nil
+	}
+	return setStealthUserAgent(page)
+}
+
+// setStealthUserAgent overrides the user agent of the tab by the one of the browser without "Headless".
+func setStealthUserAgent(page *rod.Page) error {
+	version, err := proto.BrowserGetVersion{}.Call(page.Browser())
+	if err != nil {
+		return replaceAbortedError(err)
+	}
+	userAgent := strings.Replace(version.UserAgent, "HeadlessChrome", "Chrome", 1)
+	if userAgent == version.UserAgent {
+		return nil
+	}
+	return replaceAbortedError(page.SetUserAgent(&proto.NetworkSetUserAgentOverride{UserAgent: userAgent}))
+}
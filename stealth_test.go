@@ -0,0 +1,40 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_WithStealth_Hides_Headless_Traits(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithStealth())
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+	p := b.GetPage()
+	defer b.PutPage(p)
+
+	check := func() {
+		p.MustNavigate(s.URL).MustWaitLoad()
+		traits := p.MustEval(`() => ({
+			webdriver: navigator.webdriver === undefined,
+			plugins: navigator.plugins.length > 0,
+			languages: navigator.languages.length > 0,
+			runtime: !!(window.chrome && window.chrome.runtime),
+			userAgent: navigator.userAgent,
+		})`)
+		assert.True(t, traits.Get("webdriver").Bool())
+		assert.True(t, traits.Get("plugins").Bool())
+		assert.True(t, traits.Get("languages").Bool())
+		assert.True(t, traits.Get("runtime").Bool())
+		assert.NotContains(t, traits.Get("userAgent").Str(), "Headless")
+		requests := s.Requests()
+		assert.NotContains(t, requests[len(requests)-1].UserAgent(), "Headless")
+	}
+	check()
+	assert.NoError(t, b.recyclePage(p))
+	check()
+}
@@ -0,0 +1,63 @@
+package chromium
+
+// storage names of the window, which are passed to the JavaScript below.
+const (
+	localStorageName   = "localStorage"
+	sessionStorageName = "sessionStorage"
+)
+
+// setStorageItemJS sets the item of the storage of the current origin.
+const setStorageItemJS = `(name, key, value) => window[name].setItem(key, value)`
+
+// LocalStorage returns the items of localStorage of the current origin of this page, such as feature flags and tokens
+// stored on the client side. It returns an error if the origin has no storage, such as about:blank.
+func (p *Page) LocalStorage() (map[string]string, error) {
+	return p.storageItems(localStorageName)
+}
+
+// SessionStorage returns the items of sessionStorage of the current origin of this page, as LocalStorage does.
+func (p *Page) SessionStorage() (map[string]string, error) {
+	return p.storageItems(sessionStorageName)
+}
+
+// SetLocalStorageItem sets the item of localStorage of the current origin of this page, such that the client side
+// state can be seeded. Note that the page may have read its storage already, thus reload it to take effect.
+// It returns an error if the origin has no storage, such as about:blank.
+func (p *Page) SetLocalStorageItem(key, value string) error {
+	return p.setStorageItem(localStorageName, key, value)
+}
+
+// SetSessionStorageItem sets the item of sessionStorage of the current origin of this page, as SetLocalStorageItem does.
+func (p *Page) SetSessionStorageItem(key, value string) error {
+	return p.setStorageItem(sessionStorageName, key, value)
+}
+
+// ClearStorage clears localStorage and sessionStorage of the current origin of this page.
+// Note that localStorage is shared by pages of the same browser context and origin.
+// It does nothing if the origin has no storage, such as about:blank.
+func (p *Page) ClearStorage() error {
+	_, err := p.Eval(clearStorageJS)
+	return replaceTimeoutError(replaceAbortedError(err))
+}
+
+// storageItems returns the items of the storage of the name, as collected by storagesJS of SaveSession.
+func (p *Page) storageItems(name string) (map[string]string, error) {
+	session := &Session{}
+	if err := p.evalJSON(session, storagesJS); err != nil {
+		return nil, err
+	}
+	items := session.LocalStorage
+	if name == sessionStorageName {
+		items = session.SessionStorage
+	}
+	if items == nil {
+		items = make(map[string]string)
+	}
+	return items, nil
+}
+
+// setStorageItem sets the item of the storage of the name.
+func (p *Page) setStorageItem(name, key, value string) error {
+	_, err := p.Eval(setStorageItemJS, name, key, value)
+	return replaceTimeoutError(replaceAbortedError(err))
+}
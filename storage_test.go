@@ -0,0 +1,51 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_LocalStorage_Returns_Err_Without_Storage(t *testing.T) {
+	_, p, _ := setup(t)
+	assert.NoError(t, p.Navigate(blankURL))
+	_, err := p.LocalStorage()
+	assert.Error(t, err)
+}
+
+func Test_SetLocalStorageItem_Seeds_LocalStorage(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.SetLocalStorageItem("flag", "on"))
+	local, err := p.LocalStorage()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"flag": "on"}, local)
+}
+
+func Test_SetSessionStorageItem_Seeds_SessionStorage(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.SetSessionStorageItem("token", "secret"))
+	session, err := p.SessionStorage()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"token": "secret"}, session)
+}
+
+func Test_ClearStorage_Clears_Both_Storages(t *testing.T) {
+	_, p, s := setup(t)
+	assert.NoError(t, p.Navigate(s.URL))
+	assert.NoError(t, p.SetLocalStorageItem("flag", "on"))
+	assert.NoError(t, p.SetSessionStorageItem("token", "secret"))
+	assert.NoError(t, p.ClearStorage())
+	local, err := p.LocalStorage()
+	assert.NoError(t, err)
+	assert.Empty(t, local)
+	session, err := p.SessionStorage()
+	assert.NoError(t, err)
+	assert.Empty(t, session)
+}
+
+func Test_ClearStorage_Ignores_Page_Without_Storage(t *testing.T) {
+	_, p, _ := setup(t)
+	assert.NoError(t, p.Navigate(blankURL))
+	assert.NoError(t, p.ClearStorage())
+}
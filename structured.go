@@ -0,0 +1,191 @@
+package chromium
+
+import (
+	"encoding/json"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"strings"
+)
+
+// ExtractJSONLD returns the objects of JSON-LD embedded in the current document of this page, such as schema.org data
+// of products and articles, in the order they appear. Top-level arrays and "@graph" of each block are flattened into
+// their objects. Blocks that are not valid JSON are skipped, as pages often carry broken ones along with valid ones.
+func (p *Page) ExtractJSONLD() ([]map[string]any, error) {
+	source, err := p.documentHTML()
+	if err != nil {
+		return nil, err
+	}
+	return parseJSONLD(source)
+}
+
+// ExtractMicrodata returns the top-level items of microdata in the current document of this page, in the order they appear.
+// Each item maps its properties to their values, along with "@type" and "@id" from itemtype and itemid if present.
+// A property with multiple values is mapped to a slice of them, and a nested item to a map as well.
+// Values are read as browsers do, such as content of meta, href of links and src of images, without resolving URLs.
+func (p *Page) ExtractMicrodata() ([]map[string]any, error) {
+	source, err := p.documentHTML()
+	if err != nil {
+		return nil, err
+	}
+	return parseMicrodata(source)
+}
+
+// documentHTML returns the HTML of the current document of this page.
+func (p *Page) documentHTML() (string, error) {
+	ctx, cancel := p.timeoutContext()
+	defer cancel()
+	source, err := p.Context(ctx).HTML()
+	if err != nil {
+		return "", replaceAbortedError(err)
+	}
+	return source, nil
+}
+
+// parseJSONLD parses the blocks of JSON-LD in the HTML, by the rules of ExtractJSONLD.
+func parseJSONLD(source string) ([]map[string]any, error) {
+	root, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]map[string]any, 0)
+	walkNodes(root, func(n *html.Node) bool {
+		if n.DataAtom != atom.Script || !strings.EqualFold(strings.TrimSpace(attribute(n, "type")), "application/ld+json") {
+			return true
+		}
+		var v any
+		if n.FirstChild != nil && json.Unmarshal([]byte(n.FirstChild.Data), &v) == nil {
+			res = appendJSONLD(res, v)
+		}
+		return false
+	})
+	return res, nil
+}
+
+// appendJSONLD appends objects of the value to the slice, flattening arrays and graphs.
+func appendJSONLD(res []map[string]any, v any) []map[string]any {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			res = appendJSONLD(res, item)
+		}
+	case map[string]any:
+		if graph, ok := v["@graph"].([]any); ok {
+			return appendJSONLD(res, graph)
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+// parseMicrodata parses the items of microdata in the HTML, by the rules of ExtractMicrodata.
+func parseMicrodata(source string) ([]map[string]any, error) {
+	root, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]map[string]any, 0)
+	walkNodes(root, func(n *html.Node) bool {
+		if !hasAttr(n, "itemscope") {
+			return true
+		}
+		if !hasAttr(n, "itemprop") {
+			res = append(res, microdataItem(n))
+		}
+		return false // nested items are read by their parents.
+	})
+	return res, nil
+}
+
+// microdataItem reads the properties of the item whose element has itemscope.
+func microdataItem(scope *html.Node) map[string]any {
+	item := make(map[string]any)
+	if t := strings.TrimSpace(attribute(scope, "itemtype")); len(t) > 0 {
+		item["@type"] = t
+	}
+	if id := strings.TrimSpace(attribute(scope, "itemid")); len(id) > 0 {
+		item["@id"] = id
+	}
+	for c := scope.FirstChild; c != nil; c = c.NextSibling {
+		walkNodes(c, func(n *html.Node) bool {
+			names := strings.Fields(attribute(n, "itemprop"))
+			if len(names) > 0 {
+				var value any
+				if hasAttr(n, "itemscope") {
+					value = microdataItem(n)
+				} else {
+					value = microdataValue(n)
+				}
+				for _, name := range names {
+					addProperty(item, name, value)
+				}
+			}
+			return !hasAttr(n, "itemscope") // properties of nested items belong to them.
+		})
+	}
+	return item
+}
+
+// addProperty adds the value to the property of the item, turning the property into a slice on its second value.
+func addProperty(item map[string]any, name string, value any) {
+	switch prev := item[name].(type) {
+	case nil:
+		item[name] = value
+	case []any:
+		item[name] = append(prev, value)
+	default:
+		item[name] = []any{prev, value}
+	}
+}
+
+// microdataValue returns the value of the property of the element, as of the microdata specification.
+func microdataValue(n *html.Node) string {
+	switch n.DataAtom {
+	case atom.Meta:
+		return attribute(n, "content")
+	case atom.Audio, atom.Embed, atom.Iframe, atom.Img, atom.Source, atom.Track, atom.Video:
+		return attribute(n, "src")
+	case atom.A, atom.Area, atom.Link:
+		return attribute(n, "href")
+	case atom.Object:
+		return attribute(n, "data")
+	case atom.Data, atom.Meter:
+		return attribute(n, "value")
+	case atom.Time:
+		if hasAttr(n, "datetime") {
+			return attribute(n, "datetime")
+		}
+	}
+	return strings.Join(strings.Fields(nodeText(n)), " ")
+}
+
+// walkNodes visits the node and its descendants in document order, skipping descendants of a node for which fn is false.
+func walkNodes(n *html.Node, fn func(*html.Node) bool) {
+	if n.Type == html.ElementNode && !fn(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkNodes(c, fn)
+	}
+}
+
+// nodeText returns the concatenated text of the node and its descendants.
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	sb := &strings.Builder{}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(nodeText(c))
+	}
+	return sb.String()
+}
+
+// hasAttr reports whether the node has the attribute.
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package chromium
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const structuredHTML = `<html><head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Product", "name": "Lamp"}</script>
+<script type="application/ld+json">[{"@type": "Offer", "price": "10"}, {"@type": "Brand"}]</script>
+<script type="application/ld+json">{"@context": "https://schema.org", "@graph": [{"@type": "WebPage"}]}</script>
+<script type="application/ld+json">{broken</script>
+</head><body>
+<div itemscope itemtype="https://schema.org/Product" itemid="#lamp">
+	<span itemprop="name">Desk
+		Lamp</span>
+	<img itemprop="image" src="/lamp.png">
+	<meta itemprop="sku" content="L-1">
+	<a itemprop="url" href="/lamp">lamp</a>
+	<span itemprop="color">red</span><span itemprop="color">blue</span>
+	<div itemprop="offers" itemscope itemtype="https://schema.org/Offer">
+		<data itemprop="price" value="10">$10</data>
+		<time itemprop="validFrom" datetime="2024-01-01">Jan 1</time>
+	</div>
+</div>
+<div itemscope><span itemprop="name">Other</span></div>
+</body></html>`
+
+func Test_parseJSONLD_Flattens_Blocks(t *testing.T) {
+	res, err := parseJSONLD(structuredHTML)
+	assert.NoError(t, err)
+	types := make([]any, 0, len(res))
+	for _, v := range res {
+		types = append(types, v["@type"])
+	}
+	assert.Equal(t, []any{"Product", "Offer", "Brand", "WebPage"}, types)
+	assert.Equal(t, "Lamp", res[0]["name"])
+}
+
+func Test_parseMicrodata_Reads_Items(t *testing.T) {
+	res, err := parseMicrodata(structuredHTML)
+	assert.NoError(t, err)
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, map[string]any{
+			"@type": "https://schema.org/Product",
+			"@id":   "#lamp",
+			"name":  "Desk Lamp",
+			"image": "/lamp.png",
+			"sku":   "L-1",
+			"url":   "/lamp",
+			"color": []any{"red", "blue"},
+			"offers": map[string]any{
+				"@type":     "https://schema.org/Offer",
+				"price":     "10",
+				"validFrom": "2024-01-01",
+			},
+		}, res[0])
+		assert.Equal(t, map[string]any{"name": "Other"}, res[1])
+	}
+}
+
+func Test_Extract_Structured_Data_Of_Page(t *testing.T) {
+	_, p, s := setup(t, []byte(structuredHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	objects, err := p.ExtractJSONLD()
+	assert.NoError(t, err)
+	assert.Len(t, objects, 4)
+
+	items, err := p.ExtractMicrodata()
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+}
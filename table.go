@@ -0,0 +1,226 @@
+package chromium
+
+import (
+	"encoding"
+	"fmt"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxTableSpan caps colspan and rowspan of a cell, as browsers do, such that a malformed table cannot blow up its grid.
+const maxTableSpan = 1000
+
+// ExtractTable returns the text of the cells of the first table matching the selector, row by row, including its header rows.
+// Text of each cell is trimmed with its whitespaces collapsed. A cell spanning multiple columns or rows by colspan or
+// rowspan repeats its text in each of them, such that every row is laid out as the table is displayed.
+// Rows of tables nested in cells are left out.
+func (p *Page) ExtractTable(selector string) ([][]string, error) {
+	el, err := p.HasElement(selector)
+	if err != nil {
+		return nil, err
+	}
+	source, err := el.HTML()
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	rows, err := parseTable(source)
+	if err != nil {
+		return nil, &Error{Op: opTable, Selector: selector, Err: fmt.Errorf("%w: %v", DecodeFailed, err)}
+	}
+	return rows, nil
+}
+
+// UnmarshalTable extracts the first table matching the selector as ExtractTable does, then decodes each row after the
+// first one into T, whose fields are mapped to the columns by names in the first row.
+// A field is mapped by its `table:"name"` tag, or by its name case-insensitively if it has none, while `table:"-"`
+// skips the field. Fields may be strings, booleans, numbers, their pointers, or encoding.TextUnmarshaler, where commas
+// and spaces of numbers such as "1,234" are ignored. Columns without a field are ignored, while fields without a column
+// or of empty cells are left as is.
+// It returns an error wrapping DecodeFailed if T is not a struct, or a cell cannot be decoded into its field.
+func UnmarshalTable[T any](p *Page, selector string) ([]T, error) {
+	rows, err := p.ExtractTable(selector)
+	if err != nil {
+		return nil, err
+	}
+	res, err := decodeTable[T](rows)
+	if err != nil {
+		return nil, &Error{Op: opTable, Selector: selector, Err: err}
+	}
+	return res, nil
+}
+
+// decodeTable decodes the rows after the header row into T, by the rules of UnmarshalTable.
+func decodeTable[T any](rows [][]string) ([]T, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v is not a struct", DecodeFailed, t)
+	}
+	if len(rows) == 0 {
+		return []T{}, nil
+	}
+	fields := tableFields(t, rows[0])
+	res := make([]T, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		var v T
+		rv := reflect.ValueOf(&v).Elem()
+		for col, field := range fields {
+			if field < 0 || col >= len(row) {
+				continue
+			}
+			if err := setText(rv.Field(field), row[col]); err != nil {
+				return nil, fmt.Errorf("%w: row %d, column %q: %v", DecodeFailed, i+1, rows[0][col], err)
+			}
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+// tableFields returns the index of the field of the struct type for each column of the header, or -1 if none.
+func tableFields(t reflect.Type, header []string) []int {
+	fields := make([]int, len(header))
+	for col, name := range header {
+		fields[col] = -1
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag, ok := f.Tag.Lookup("table")
+			if tag == "-" {
+				continue
+			}
+			if (ok && tag == name) || (!ok && strings.EqualFold(f.Name, name)) {
+				fields[col] = i
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// setText decodes the text into the value, which must be settable. Empty text leaves the value as is.
+func setText(v reflect.Value, text string) error {
+	if len(strings.TrimSpace(text)) == 0 {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setText(ptr.Elem(), text); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+	number := strings.NewReplacer(",", "", " ", "").Replace(text)
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(number, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(number, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(number, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+// tableSpan is a cell spanning rows below its own, which fills its column of the rows.
+type tableSpan struct {
+	text string
+	rows int
+}
+
+// parseTable parses the rows of the first table in the HTML, by the rules of ExtractTable.
+func parseTable(source string) ([][]string, error) {
+	root, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil, err
+	}
+	var table *html.Node
+	walkNodes(root, func(n *html.Node) bool {
+		if table == nil && n.DataAtom == atom.Table {
+			table = n
+		}
+		return table == nil
+	})
+	rows := make([][]string, 0)
+	if table == nil {
+		return rows, nil
+	}
+	spans := make(map[int]*tableSpan)
+	spanned := func(row []string) []string {
+		for s := spans[len(row)]; s != nil && s.rows > 0; s = spans[len(row)] {
+			row = append(row, s.text)
+			s.rows--
+		}
+		return row
+	}
+	for c := table.FirstChild; c != nil; c = c.NextSibling {
+		walkNodes(c, func(tr *html.Node) bool {
+			if tr.DataAtom == atom.Table {
+				return false
+			}
+			if tr.DataAtom != atom.Tr {
+				return true
+			}
+			row := make([]string, 0)
+			for cell := tr.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.DataAtom != atom.Td && cell.DataAtom != atom.Th {
+					continue
+				}
+				row = spanned(row)
+				text := strings.Join(strings.Fields(nodeText(cell)), " ")
+				cols, down := tableSpanOf(cell, "colspan"), tableSpanOf(cell, "rowspan")
+				for i := 0; i < cols; i++ {
+					if down > 1 {
+						spans[len(row)] = &tableSpan{text: text, rows: down - 1}
+					}
+					row = append(row, text)
+				}
+			}
+			rows = append(rows, spanned(row))
+			return false
+		})
+	}
+	return rows, nil
+}
+
+// tableSpanOf returns the span of the cell by the attribute, which is 1 unless it is a number in range.
+func tableSpanOf(cell *html.Node, key string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(attribute(cell, key)))
+	if err != nil || n < 1 {
+		return 1
+	}
+	if n > maxTableSpan {
+		return maxTableSpan
+	}
+	return n
+}
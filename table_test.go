@@ -0,0 +1,69 @@
+package chromium
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const tableHTML = `<html><body><table id="prices">
+<thead><tr><th>Name</th><th>Price</th><th colspan="2">Stock</th></tr></thead>
+<tbody>
+<tr><td rowspan="2">Lamp</td><td>1,200</td><td>yes</td><td>3</td></tr>
+<tr><td>  980 </td><td>no</td><td></td></tr>
+<tr><td>Desk <b>Large</b></td><td>5</td><td>true</td><td><table><tr><td>nested</td></tr></table></td></tr>
+</tbody>
+</table></body></html>`
+
+type priceRow struct {
+	Name    string
+	Price   int
+	InStock bool `table:"Stock"`
+	Note    string
+	skipped string
+}
+
+func Test_parseTable_Lays_Out_Spans(t *testing.T) {
+	rows, err := parseTable(tableHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Price", "Stock", "Stock"},
+		{"Lamp", "1,200", "yes", "3"},
+		{"Lamp", "980", "no", ""},
+		{"Desk Large", "5", "true", "nested"},
+	}, rows)
+}
+
+func Test_decodeTable_Maps_Columns(t *testing.T) {
+	rows := [][]string{{"Name", "Price", "Stock"}, {"Lamp", "1,200", "true"}, {"Desk", "", "false"}}
+	res, err := decodeTable[priceRow](rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []priceRow{{Name: "Lamp", Price: 1200, InStock: true}, {Name: "Desk"}}, res)
+
+	price := 0.0
+	ptr, err := decodeTable[struct{ Price *float64 }]([][]string{{"price"}, {"0"}, {""}})
+	assert.NoError(t, err)
+	assert.Equal(t, &price, ptr[0].Price)
+	assert.Nil(t, ptr[1].Price)
+
+	_, err = decodeTable[priceRow]([][]string{{"Price"}, {"cheap"}})
+	assert.True(t, errors.Is(err, DecodeFailed))
+	_, err = decodeTable[string](rows)
+	assert.True(t, errors.Is(err, DecodeFailed))
+}
+
+func Test_UnmarshalTable_Decodes_Rows(t *testing.T) {
+	_, p, s := setup(t, []byte(tableHTML))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	rows, err := p.ExtractTable("#prices")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+
+	_, err = UnmarshalTable[priceRow](p, "#prices")
+	assert.True(t, errors.Is(err, DecodeFailed)) // "yes" is not a boolean.
+
+	res, err := UnmarshalTable[struct{ Name string }](p, "#prices")
+	assert.NoError(t, err)
+	assert.Equal(t, "Desk Large", res[2].Name)
+}
@@ -0,0 +1,82 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"regexp"
+)
+
+// Pages returns the tabs of the browser that are not a part of the page pool, such as popups and redirects of OAuth,
+// print previews, or tabs opened by another client of a remote browser, wrapped as pages of this package.
+// The pages are not a part of any page pool, thus closing them is up to the caller, such as by their CleanUp.
+// Note that each call wraps the tabs anew, hence states of pages such as dialogs are not shared between calls.
+func (b *Browser) Pages() ([]*Page, error) {
+	return b.adopt(nil)
+}
+
+// PageWithURL returns the first tab of the browser whose URL matches the pattern, which is not a part of the page pool,
+// wrapped as a page of this package as Pages does. The pattern is a wildcard as of Intercept, such as "*/callback?*".
+// It returns an error wrapping PageMissing if no tab matches the pattern.
+func (b *Browser) PageWithURL(pattern string) (*Page, error) {
+	reg, err := regexp.Compile(proto.PatternToReg(pattern))
+	if err != nil {
+		return nil, err
+	}
+	pages, err := b.adopt(reg)
+	if err != nil {
+		return nil, err
+	} else if len(pages) == 0 {
+		return nil, &Error{Op: opFind, URL: pattern, Err: PageMissing}
+	}
+	return pages[0], nil
+}
+
+// adopt wraps the tabs of the browser that are not pooled, and whose URL matches the pattern if any,
+// stopping at the first match if the pattern is given.
+func (b *Browser) adopt(pattern *regexp.Regexp) ([]*Page, error) {
+	list, err := proto.TargetGetTargets{}.Call(b)
+	if err != nil {
+		return nil, replaceAbortedError(err)
+	}
+	pages := make([]*Page, 0)
+	for _, target := range list.TargetInfos {
+		if target.Type != proto.TargetTargetInfoTypePage || b.isPooled(target.TargetID) {
+			continue
+		} else if pattern != nil && !pattern.MatchString(target.URL) {
+			continue
+		}
+		page, err := b.PageFromTarget(target.TargetID)
+		if err != nil {
+			return nil, replaceAbortedError(err)
+		}
+		p := newPage(page, func() {})
+		p.limiter, p.robots = b.limiter, b.robots
+		p.collector, p.tracer, p.logger, p.artifactDir = b.collector, b.tracer, b.logger, b.artifactDir
+		p.auth = b.auth()
+		pages = append(pages, p)
+		if pattern != nil {
+			break
+		}
+	}
+	return pages, nil
+}
+
+// isPooled tells whether the target is the tab of a page of the pool.
+func (b *Browser) isPooled(id proto.TargetTargetID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pooled[id]
+}
+
+// setPooled marks the target as the tab of a page of the pool, or unmarks it.
+func (b *Browser) setPooled(id proto.TargetTargetID, pooled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if pooled {
+		if b.pooled == nil {
+			b.pooled = make(map[proto.TargetTargetID]bool)
+		}
+		b.pooled[id] = true
+	} else {
+		delete(b.pooled, id)
+	}
+}
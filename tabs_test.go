@@ -0,0 +1,40 @@
+package chromium
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Pages_Adopts_Tabs_Outside_Of_Pool(t *testing.T) {
+	b, p, s := setup(t, testfile.BlankHTML)
+	before, err := b.Pages()
+	assert.NoError(t, err)
+	for _, page := range before {
+		assert.NotEqual(t, p.TargetID, page.TargetID)
+	}
+
+	external := b.MustPage(s.URL + "/callback?code=1")
+	defer external.MustClose()
+	after, err := b.Pages()
+	assert.NoError(t, err)
+	assert.Len(t, after, len(before)+1)
+
+	adopted, err := b.PageWithURL("*/callback?*")
+	assert.NoError(t, err)
+	assert.Equal(t, external.TargetID, adopted.TargetID)
+	assert.Equal(t, "1", adopted.MustEval(`() => new URL(location.href).searchParams.get('code')`).Str())
+
+	p.MustNavigate(s.URL + "/pooled").MustWaitLoad()
+	_, err = b.PageWithURL("*/pooled")
+	assert.ErrorIs(t, err, PageMissing)
+}
+
+func Test_setPooled_Marks_And_Unmarks_Targets(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	b.setPooled("a", true)
+	assert.True(t, b.isPooled("a"))
+	b.setPooled("a", false)
+	assert.False(t, b.isPooled(proto.TargetTargetID("a")))
+}
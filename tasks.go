@@ -0,0 +1,157 @@
+package chromium
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Future is a handle of a task that is submitted to the Browser.
+type Future interface {
+	// Done returns a channel that is closed when the task is finished.
+	Done() <-chan struct{}
+	// Wait blocks until the task is finished, then returns its error.
+	Wait() error
+}
+
+// task is a unit of work that runs against a page from the pool, and an implementation of Future.
+type task struct {
+	run  func(*Page) error
+	done chan struct{}
+	err  error
+}
+
+func newTask(run func(*Page) error) *task {
+	return &task{run: run, done: make(chan struct{})}
+}
+
+func (t *task) Done() <-chan struct{} {
+	return t.done
+}
+
+func (t *task) Wait() error {
+	<-t.done
+	return t.err
+}
+
+// finish records the error of this task, then signals waiters.
+func (t *task) finish(err error) {
+	t.err = err
+	close(t.done)
+}
+
+// taskQueue holds tasks that are submitted to the Browser, which are consumed by a worker per pooled page.
+type taskQueue struct {
+	tasks   chan *task
+	timeout time.Duration
+	start   *sync.Once
+	workers *sync.WaitGroup
+	mu      *sync.RWMutex
+	closed  bool
+}
+
+func newTaskQueue(size int, timeout time.Duration) *taskQueue {
+	if size < 0 {
+		size = 0
+	}
+	return &taskQueue{
+		tasks:   make(chan *task, size),
+		timeout: timeout,
+		start:   &sync.Once{},
+		workers: &sync.WaitGroup{},
+		mu:      &sync.RWMutex{},
+	}
+}
+
+// Submit queues given task to be run against a page from the pool, and returns its Future.
+// Workers are started on the first submission, one for each pooled page.
+// The Future resolves with QueueFull if the queue has no room, or QueueClosed if the browser is draining.
+// Panics from the task are recovered and reported as its error.
+func (b *Browser) Submit(task func(*Page) error) Future {
+	t := newTask(task)
+	q := b.tasks
+	q.start.Do(func() { q.startWorkers(b, cap(b.pagePool)) })
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		t.finish(QueueClosed)
+		return t
+	}
+	select {
+	case q.tasks <- t:
+	default:
+		t.finish(QueueFull)
+	}
+	return t
+}
+
+// Drain stops accepting tasks, then waits for queued and running tasks to finish for given timeout.
+// It returns TaskTimeout if tasks are still running after the timeout. Zero or negative timeout waits indefinitely.
+func (b *Browser) Drain(timeout time.Duration) error {
+	done := make(chan struct{})
+	go func() { defer close(done); b.tasks.drain() }()
+	if timeout <= 0 {
+		<-done
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return TaskTimeout
+	}
+}
+
+func (q *taskQueue) startWorkers(b *Browser, n int) {
+	q.workers.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer q.workers.Done()
+			for t := range q.tasks { // take pages from the pool directly, as tasks are drained while shutting down
+				p := b.checkPage(b.pagePool.Get())
+				b.acquired(p)
+				t.finish(q.run(p, t))
+				b.released(p)
+				b.resetPage(p)
+				b.pagePool.Put(p)
+			}
+		}()
+	}
+}
+
+// run runs the task against the page, bounding the page by the task timeout if any.
+func (q *taskQueue) run(p *Page, t *task) error {
+	return runTask(p, q.timeout, t.run)
+}
+
+// runTask runs the function against the page, bounding the page by given timeout if positive.
+// The function gets a view of the page whose tab is bound to the timeout, which shares the state of the page,
+// such that the page itself is left as it is. Panics from the function are recovered and returned as an error.
+func runTask(p *Page, timeout time.Duration, fn func(*Page) error) (err error) {
+	if timeout > 0 {
+		view := &Page{Page: p.Page.Timeout(timeout), pageShared: p.pageShared}
+		defer view.Page.CancelTimeout()
+		p = view
+	}
+	defer func() {
+		if pe := recover(); isError(pe) {
+			err, _ = pe.(error)
+		} else if pe != nil {
+			err = fmt.Errorf("task panicked: %+v", pe)
+		}
+		err = replaceTimeoutError(replaceAbortedError(err))
+	}()
+	return fn(p)
+}
+
+// drain closes the queue once, then waits for the workers to finish.
+func (q *taskQueue) drain() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.tasks)
+	}
+	q.mu.Unlock()
+	q.workers.Wait()
+}
@@ -0,0 +1,162 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTaskBrowser returns a browser that only consists of page pool and task queue, which are filled with blank pages.
+func newTaskBrowser(poolSize, queueSize int) *Browser {
+	pool := make(PagePool, poolSize)
+	for i := 0; i < poolSize; i++ {
+		pool <- detachedPage(context.Background())
+	}
+	return &Browser{pagePool: pool, tasks: newTaskQueue(queueSize, 0), mu: &sync.Mutex{}, closed: make(chan struct{})}
+}
+
+func Test_Submit_Runs_Task_And_Resolves_Future(t *testing.T) {
+	b := newTaskBrowser(2, 4)
+	expected := errors.New("test error")
+	f := b.Submit(func(p *Page) error { return expected })
+	assert.ErrorIs(t, f.Wait(), expected)
+	select {
+	case <-f.Done():
+	default:
+		t.Fatal("expected done channel to be closed")
+	}
+	assert.NoError(t, b.Drain(time.Second))
+}
+
+func Test_Submit_Returns_QueueFull_When_Queue_Has_No_Room(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	block := make(chan struct{})
+	running := make(chan struct{})
+	first := b.Submit(func(p *Page) error { close(running); <-block; return nil })
+	<-running
+	second := b.Submit(func(p *Page) error { return nil })
+	third := b.Submit(func(p *Page) error { return nil })
+	assert.ErrorIs(t, third.Wait(), QueueFull)
+	close(block)
+	assert.NoError(t, first.Wait())
+	assert.NoError(t, second.Wait())
+}
+
+func Test_Submit_Returns_QueueClosed_After_Drain(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	assert.NoError(t, b.Drain(time.Second))
+	assert.ErrorIs(t, b.Submit(func(p *Page) error { return nil }).Wait(), QueueClosed)
+}
+
+func Test_Submit_Recovers_Panic_From_Task(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	expected := errors.New("test panic")
+	assert.ErrorIs(t, b.Submit(func(p *Page) error { panic(expected) }).Wait(), expected)
+	assert.ErrorContains(t, b.Submit(func(p *Page) error { panic("test") }).Wait(), "test")
+}
+
+func Test_Submit_Runs_No_More_Tasks_Than_Pool_Size(t *testing.T) {
+	b := newTaskBrowser(3, 100)
+	lock, running, max := &sync.Mutex{}, 0, 0
+	futures := make([]Future, 0)
+	for i := 0; i < 30; i++ {
+		futures = append(futures, b.Submit(func(p *Page) error {
+			lock.Lock()
+			running++
+			if running > max {
+				max = running
+			}
+			lock.Unlock()
+			time.Sleep(time.Millisecond)
+			lock.Lock()
+			running--
+			lock.Unlock()
+			return nil
+		}))
+	}
+	for _, f := range futures {
+		assert.NoError(t, f.Wait())
+	}
+	assert.LessOrEqual(t, max, 3)
+}
+
+func Test_Drain_Returns_TaskTimeout_When_Tasks_Outlive_Timeout(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+	b.Submit(func(p *Page) error { <-block; return nil })
+	assert.ErrorIs(t, b.Drain(time.Millisecond*10), TaskTimeout)
+}
+
+func Test_Submit_Returns_TaskTimeout_When_Task_Exceeds_Timeout(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithTaskQueue(1, time.Millisecond*50))
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	err = b.Submit(func(p *Page) error {
+		_, err := p.Element("#never")
+		return err
+	}).Wait()
+	assert.ErrorIs(t, err, TaskTimeout)
+}
+
+func Test_Submit_Restores_Page_After_Timeout(t *testing.T) {
+	t.Parallel()
+	b, err := NewBrowser(1, WithTaskQueue(1, time.Millisecond*50))
+	assert.NoError(t, err)
+	t.Cleanup(b.CleanUp)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+	err = b.Submit(func(p *Page) error { time.Sleep(time.Millisecond * 100); return p.Navigate(s.URL) }).Wait()
+	assert.ErrorIs(t, err, TaskTimeout)
+	p := b.GetPage()
+	defer b.PutPage(p)
+	assert.NoError(t, p.Navigate(s.URL))
+}
+
+func Test_runTask_Bounds_View_Without_Touching_Page(t *testing.T) {
+	p := detachedPage(context.Background())
+	tab := p.Page
+	err := runTask(p, time.Hour, func(view *Page) error {
+		assert.NotSame(t, tab, view.Page)
+		_, bounded := view.GetContext().Deadline()
+		assert.True(t, bounded)
+		assert.Same(t, tab, p.Page)
+		view.SetPollInterval(time.Second)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Same(t, tab, p.Page)
+	assert.Equal(t, time.Second, p.pollInterval())
+}
+
+func Test_GetPageTimeout_Returns_TaskTimeout_When_Pool_Is_Exhausted(t *testing.T) {
+	b := newTaskBrowser(1, 1)
+	p, err := b.GetPageTimeout(time.Millisecond * 10)
+	assert.NoError(t, err)
+	_, err = b.GetPageTimeout(time.Millisecond * 10)
+	assert.ErrorIs(t, err, TaskTimeout)
+	_, ok := b.TryGetPage()
+	assert.False(t, ok)
+	b.PutPage(p)
+	got, ok := b.TryGetPage()
+	assert.True(t, ok)
+	assert.Equal(t, p, got)
+}
+
+func Test_GetPageContext_Returns_BrowserClosed_After_Stop(t *testing.T) {
+	b := newTaskBrowser(0, 1)
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		b.stopServing()
+	}()
+	_, err := b.GetPageContext(context.Background())
+	assert.ErrorIs(t, err, BrowserClosed)
+	_, ok := b.TryGetPage()
+	assert.False(t, ok)
+}
@@ -0,0 +1,227 @@
+package chromium
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"strings"
+)
+
+// TextOptions configures TextContent.
+type TextOptions struct {
+	// Bullet prefixes items of unordered lists, which defaults to "- ". Items of ordered lists are numbered instead.
+	Bullet string
+	// LinkURLs appends the href of each link after its text, such as "text (https://example.com)".
+	LinkURLs bool
+}
+
+// TextContent returns plain text of the first element matching the selector, keeping the structure of its content:
+// blocks and paragraphs are separated by line breaks, list items are bulleted, and cells of a row are separated by tabs.
+func (p *Page) TextContent(selector string, opts TextOptions) (string, error) {
+	el, err := p.HasElement(selector)
+	if err != nil {
+		return "", err
+	}
+	source, err := el.HTML()
+	if err != nil {
+		return "", replaceAbortedError(err)
+	}
+	return htmlToText(source, opts)
+}
+
+// htmlToText converts the HTML into plain text, by the rules of TextContent.
+func htmlToText(source string, opts TextOptions) (string, error) {
+	root, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return "", err
+	}
+	if len(opts.Bullet) == 0 {
+		opts.Bullet = "- "
+	}
+	w := &textWriter{opts: opts}
+	w.walk(root)
+	return w.String(), nil
+}
+
+// textWriter accumulates text while walking a tree of HTML nodes.
+type textWriter struct {
+	opts  TextOptions
+	sb    strings.Builder
+	space bool // whether a whitespace is pending before the next text.
+	pre   int  // depth of preformatted elements.
+	lists []int
+}
+
+var paragraphs = map[atom.Atom]bool{
+	atom.P: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Table: true, atom.Figure: true,
+}
+
+var blocks = map[atom.Atom]bool{
+	atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true, atom.Main: true,
+	atom.Nav: true, atom.Aside: true, atom.Form: true, atom.Fieldset: true, atom.Address: true, atom.Ul: true,
+	atom.Ol: true, atom.Dl: true, atom.Dt: true, atom.Dd: true, atom.Tr: true, atom.Hr: true, atom.Figcaption: true,
+	atom.Caption: true, atom.Details: true, atom.Summary: true,
+}
+
+var skipped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true, atom.Head: true,
+}
+
+func (w *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			w.walk(c)
+		}
+		return
+	}
+
+	if skipped[n.DataAtom] {
+		return
+	}
+	switch {
+	case n.DataAtom == atom.Br:
+		w.newline()
+		return
+	case n.DataAtom == atom.Li:
+		w.item()
+	case n.DataAtom == atom.Td || n.DataAtom == atom.Th:
+		if w.sb.Len() > 0 && !w.atLineStart() {
+			w.sb.WriteByte('\t')
+			w.space = false
+		}
+	case paragraphs[n.DataAtom]:
+		w.paragraph()
+	case blocks[n.DataAtom]:
+		w.newline()
+	}
+
+	if n.DataAtom == atom.Ul || n.DataAtom == atom.Ol {
+		w.lists = append(w.lists, listKind(n))
+		defer func() { w.lists = w.lists[:len(w.lists)-1] }()
+	}
+	if n.DataAtom == atom.Pre {
+		w.pre++
+		defer func() { w.pre-- }()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+
+	switch {
+	case n.DataAtom == atom.A && w.opts.LinkURLs:
+		if href := attribute(n, "href"); len(href) > 0 && !strings.HasPrefix(href, "#") {
+			w.text(" (" + href + ")")
+		}
+	case n.DataAtom == atom.Li:
+		w.newline()
+	case paragraphs[n.DataAtom]:
+		w.paragraph()
+	case blocks[n.DataAtom]:
+		w.newline()
+	}
+}
+
+// listKind returns -1 for unordered list, or the number preceding the first item for ordered list.
+func listKind(n *html.Node) int {
+	if n.DataAtom == atom.Ul {
+		return -1
+	}
+	start := 1
+	if s := attribute(n, "start"); len(s) > 0 {
+		_, _ = fmt.Sscanf(s, "%d", &start)
+	}
+	return start - 1
+}
+
+// item begins a list item, indented by the depth of lists and prefixed by a bullet or number.
+func (w *textWriter) item() {
+	w.newline()
+	depth := len(w.lists)
+	if depth == 0 {
+		w.sb.WriteString(w.opts.Bullet)
+		return
+	}
+	w.sb.WriteString(strings.Repeat("  ", depth-1))
+	if kind := w.lists[depth-1]; kind < 0 {
+		w.sb.WriteString(w.opts.Bullet)
+	} else {
+		w.lists[depth-1]++
+		w.sb.WriteString(fmt.Sprintf("%d. ", w.lists[depth-1]))
+	}
+	w.space = false
+}
+
+// text writes the text, collapsing whitespaces unless within preformatted elements.
+func (w *textWriter) text(s string) {
+	if w.pre > 0 {
+		w.sb.WriteString(s)
+		return
+	}
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		if len(s) > 0 {
+			w.space = true
+		}
+		return
+	}
+	if (w.space || startsWithSpace(s)) && !w.atLineStart() {
+		w.sb.WriteByte(' ')
+	}
+	w.sb.WriteString(strings.Join(fields, " "))
+	w.space = endsWithSpace(s)
+}
+
+func (w *textWriter) atLineStart() bool {
+	str := w.sb.String()
+	return len(str) == 0 || strings.HasSuffix(str, "\n") || strings.HasSuffix(str, "\t") || strings.HasSuffix(str, " ")
+}
+
+// newline ends the current line, unless nothing is written on it.
+func (w *textWriter) newline() {
+	w.space = false
+	if str := w.sb.String(); len(str) > 0 && !strings.HasSuffix(str, "\n") {
+		w.sb.WriteByte('\n')
+	}
+}
+
+// paragraph ends the current line, then leaves a blank line.
+func (w *textWriter) paragraph() {
+	w.newline()
+	if str := w.sb.String(); len(str) > 0 && !strings.HasSuffix(str, "\n\n") {
+		w.sb.WriteByte('\n')
+	}
+}
+
+// String returns the text without trailing spaces of lines and surrounding blank lines.
+func (w *textWriter) String() string {
+	lines := strings.Split(w.sb.String(), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+func startsWithSpace(s string) bool {
+	return len(s) > 0 && strings.TrimLeft(s[:1], " \t\r\n\f") == ""
+}
+
+func endsWithSpace(s string) bool {
+	return len(s) > 0 && strings.TrimRight(s[len(s)-1:], " \t\r\n\f") == ""
+}
+
+// attribute returns the value of the attribute of the node, or empty string if missing.
+func attribute(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package chromium
+
+import (
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_htmlToText_Separates_Blocks_And_Paragraphs(t *testing.T) {
+	text, err := htmlToText(`<div><h1>Title</h1><p>First   paragraph
+	with <b>bold</b> text.</p><div>block</div>tail<br>next line</div>`, TextOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Title\n\nFirst paragraph with bold text.\n\nblock\ntail\nnext line", text)
+}
+
+func Test_htmlToText_Bullets_And_Numbers_Lists(t *testing.T) {
+	text, err := htmlToText(`<ul><li>one</li><li>two<ol start="3"><li>three</li><li>four</li></ol></li></ul>`, TextOptions{Bullet: "* "})
+	assert.NoError(t, err)
+	assert.Equal(t, "* one\n* two\n  3. three\n  4. four", text)
+}
+
+func Test_htmlToText_Appends_Link_URLs_When_Requested(t *testing.T) {
+	source := `<p>see <a href="https://example.com">example</a> and <a href="#top">top</a></p>`
+	text, err := htmlToText(source, TextOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "see example and top", text)
+	text, err = htmlToText(source, TextOptions{LinkURLs: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "see example (https://example.com) and top", text)
+}
+
+func Test_htmlToText_Separates_Cells_By_Tab_And_Skips_Scripts(t *testing.T) {
+	text, err := htmlToText(`<table><tr><th>a</th><th>b</th></tr><tr><td>1</td><td>2</td></tr></table><script>var x = 1;</script>`, TextOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "a\tb\n1\t2", text)
+}
+
+func Test_htmlToText_Keeps_Preformatted_Text(t *testing.T) {
+	text, err := htmlToText("<pre>line 1\n  line 2</pre>", TextOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "line 1\n  line 2", text)
+}
+
+func Test_TextContent_Returns_Structured_Text(t *testing.T) {
+	_, p, s := setup(t, testfile.ItemsHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	text, err := p.TextContent("ul", TextOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "- item0\n- item1\n- item2\n- item3\n- item4", text)
+}
+
+func Test_TextContent_Returns_Err_When_Element_Missing(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL)
+	_, err := p.TextContent("ul", TextOptions{})
+	assert.ErrorIs(t, err, ElementMissing)
+}
@@ -0,0 +1,54 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer of this package, as of the instrumentation scope of its spans.
+const tracerName = "github.com/state303/chromium"
+
+// WithTracerProvider makes TryNavigate, TryNavigateWithPolicy, ClickNavigate, TryInput and WaitJSObjectFor of every
+// page of the browser emit a span of the provider, telling the URL, selector or object name as its attributes,
+// and the error as its status. Spans of the Context variants are children of the span of their ctx, such that time
+// spent in the browser shows up inside distributed traces, whereas the others begin a trace of their own.
+// Pages out of the pool, such as of NewPageWithProxy and Pages, emit spans as well.
+func WithTracerProvider(tp trace.TracerProvider) BrowserOption {
+	return func(o *browserOptions) {
+		o.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// startSpan starts a span of the step as a child of the span of ctx if this page has a tracer, returning ctx with
+// the span, and a function that ends the span with the error it points to. Without a tracer, ctx is returned as is.
+func (p *Page) startSpan(ctx context.Context, step Step) (context.Context, func(err *error)) {
+	if p.tracer == nil {
+		return ctx, func(*error) {}
+	}
+	attrs := make([]otelattr.KeyValue, 0, 3)
+	if len(step.URL) > 0 {
+		attrs = append(attrs, otelattr.String("chromium.url", step.URL))
+	}
+	if len(step.Selector) > 0 {
+		attrs = append(attrs, otelattr.String("chromium.selector", step.Selector))
+	}
+	if step.Action == ActionWaitJS {
+		attrs = append(attrs, otelattr.String("chromium.object", step.Text))
+	}
+	ctx, span := p.tracer.Start(ctx, "chromium."+step.Action, trace.WithAttributes(attrs...))
+	return ctx, func(err *error) {
+		defer span.End()
+		if *err == nil {
+			return
+		}
+		var e *Error
+		if errors.As(*err, &e) && e.Attempt > 0 {
+			span.SetAttributes(otelattr.Int("chromium.attempt", e.Attempt))
+		}
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+}
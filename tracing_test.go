@@ -0,0 +1,88 @@
+package chromium
+
+import (
+	"context"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/state303/chromium/internal/test/testserver"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+	"time"
+)
+
+// tracedPage returns a page without a tab that emits spans to the returned recorder.
+func tracedPage() (*Page, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	p := newPage(nil, func() {})
+	p.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+	return p, recorder
+}
+
+func Test_startSpan_Records_Attributes_And_Error(t *testing.T) {
+	p, recorder := tracedPage()
+	_, end := p.startSpan(context.Background(), Step{Action: ActionNavigate, URL: "https://example.com/"})
+	var err error = &Error{Op: ActionNavigate, URL: "https://example.com/", Attempt: 2, Err: TaskTimeout}
+	end(&err)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "chromium.navigate", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		attrs := make(map[string]any)
+		for _, kv := range spans[0].Attributes() {
+			attrs[string(kv.Key)] = kv.Value.AsInterface()
+		}
+		assert.Equal(t, map[string]any{"chromium.url": "https://example.com/", "chromium.attempt": int64(2)}, attrs)
+		assert.Len(t, spans[0].Events(), 1)
+	}
+}
+
+func Test_startSpan_Is_Child_Of_Context(t *testing.T) {
+	p, recorder := tracedPage()
+	ctx, parent := p.tracer.Start(context.Background(), "parent")
+	assert.NoError(t, p.WaitJSObjectForContext(ctx, "", time.Second))
+	parent.End()
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "chromium.waitJS", spans[0].Name())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	}
+}
+
+func Test_startSpan_Without_Tracer_Returns_Context(t *testing.T) {
+	p := newPage(nil, func() {})
+	ctx := context.Background()
+	got, end := p.startSpan(ctx, Step{Action: ActionInput, Selector: "#name"})
+	var err error
+	end(&err)
+	assert.Equal(t, ctx, got)
+}
+
+func Test_WithTracerProvider_Traces_TryNavigate(t *testing.T) {
+	t.Parallel()
+	recorder := tracetest.NewSpanRecorder()
+	b, err := NewBrowser(1, WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(b.CleanUp)
+	s := testserver.WithRotatingResponses(t, testfile.BlankHTML)
+	t.Cleanup(s.Close)
+
+	p := b.GetPage()
+	defer b.PutPage(p)
+	assert.NoError(t, p.TryNavigate(s.URL, func(*Page) bool { return true }, time.Millisecond))
+	assert.Error(t, p.TryInput("#missing", "text"))
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "chromium.navigate", spans[0].Name())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, "chromium.input", spans[1].Name())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	}
+}
@@ -0,0 +1,149 @@
+package chromium
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// unmarshalJS reads the fields of the spec from each element matching the root selector, returning a record of values
+// per element, whose values are in the order of the fields.
+const unmarshalJS = `(root, spec) => {
+	const value = (el, attr) => attr ? el.getAttribute(attr) : el.textContent.replace(/\s+/g, ' ').trim();
+	const read = (el, fields) => fields.map(f => {
+		const nodes = f.sel ? Array.from(el.querySelectorAll(f.sel)) : [el];
+		const values = (f.many ? nodes : nodes.slice(0, 1)).map(n => f.nested ? read(n, f.fields) : value(n, f.attr));
+		return f.many ? values : (values.length > 0 ? values[0] : null);
+	});
+	return Array.from(document.querySelectorAll(root)).map(el => read(el, spec));
+}`
+
+// unmarshalField is a field of a struct to be read from the DOM, which is sent to unmarshalJS as its spec.
+type unmarshalField struct {
+	index    int
+	Selector string            `json:"sel"`
+	Attr     string            `json:"attr"`
+	Many     bool              `json:"many"`
+	Nested   bool              `json:"nested"`
+	Fields   []*unmarshalField `json:"fields"`
+}
+
+// Unmarshal decodes each element matching the root selector into T, whose fields are read from the element by
+// `chromium:"selector,attr"` tags. A field gets the text of the first descendant matching its selector, with its
+// whitespaces collapsed, or the value of the attribute if attr is given. Empty selector reads the element itself,
+// such as `chromium:",href"`. A slice field gets all descendants matching its selector, and a struct field or a slice of
+// structs is read from its descendants by the tags of the struct in turn. Fields without tags are left as is, and so are
+// fields without a matching descendant or with empty text. Values are decoded as UnmarshalTable does.
+// It returns an error wrapping DecodeFailed if T is not a struct, or a value cannot be decoded into its field.
+func Unmarshal[T any](p *Page, rootSel string) ([]T, error) {
+	fields, err := unmarshalFields(reflect.TypeOf((*T)(nil)).Elem(), map[reflect.Type]bool{})
+	if err != nil {
+		return nil, &Error{Op: opUnmarshal, Selector: rootSel, Err: err}
+	}
+	ctx, cancel := p.timeoutContext()
+	defer cancel()
+	obj, err := p.Context(ctx).Eval(unmarshalJS, rootSel, fields)
+	if err != nil {
+		return nil, &Error{Op: opUnmarshal, Selector: rootSel, Err: replaceTimeoutError(replaceAbortedError(err))}
+	}
+	var records [][]any
+	if err = decodeJSON(obj, &records); err != nil {
+		return nil, &Error{Op: opUnmarshal, Selector: rootSel, Err: classify(DecodeFailed, err)}
+	}
+	res := make([]T, len(records))
+	for i, record := range records {
+		if err = fillFields(reflect.ValueOf(&res[i]).Elem(), fields, record); err != nil {
+			return nil, &Error{Op: opUnmarshal, Selector: rootSel, Err: fmt.Errorf("%w: element %d, %v", DecodeFailed, i, err)}
+		}
+	}
+	return res, nil
+}
+
+// unmarshalFields returns the fields of the struct type to be read by their tags, refusing types that contain themselves.
+func unmarshalFields(t reflect.Type, visiting map[reflect.Type]bool) ([]*unmarshalField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v is not a struct", DecodeFailed, t)
+	}
+	if visiting[t] {
+		return nil, fmt.Errorf("%w: %v contains itself", DecodeFailed, t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	fields := make([]*unmarshalField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("chromium")
+		if !ok || tag == "-" || !f.IsExported() {
+			continue
+		}
+		selector, attr, _ := strings.Cut(tag, ",")
+		field := &unmarshalField{index: i, Selector: strings.TrimSpace(selector), Attr: strings.TrimSpace(attr)}
+		elem := f.Type
+		if elem.Kind() == reflect.Slice {
+			field.Many, elem = true, elem.Elem()
+		}
+		if isNestedStruct(elem) {
+			nested, err := unmarshalFields(elem, visiting)
+			if err != nil {
+				return nil, err
+			}
+			field.Nested, field.Fields = true, nested
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// isNestedStruct reports whether the type is a struct to be read by its tags, rather than decoded from text.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
+}
+
+// fillFields sets the values of the record to the fields of the struct value.
+func fillFields(v reflect.Value, fields []*unmarshalField, record []any) error {
+	if len(record) != len(fields) {
+		return fmt.Errorf("%d values for %d fields", len(record), len(fields))
+	}
+	for i, field := range fields {
+		if record[i] == nil {
+			continue
+		}
+		target := v.Field(field.index)
+		if !field.Many {
+			if err := fillValue(target, field, record[i]); err != nil {
+				return fmt.Errorf("field %s: %v", v.Type().Field(field.index).Name, err)
+			}
+			continue
+		}
+		values, ok := record[i].([]any)
+		if !ok {
+			return fmt.Errorf("field %s: not a list", v.Type().Field(field.index).Name)
+		}
+		slice := reflect.MakeSlice(target.Type(), len(values), len(values))
+		for j, value := range values {
+			if err := fillValue(slice.Index(j), field, value); err != nil {
+				return fmt.Errorf("field %s[%d]: %v", v.Type().Field(field.index).Name, j, err)
+			}
+		}
+		target.Set(slice)
+	}
+	return nil
+}
+
+// fillValue sets a single value of the field, which is a record of a nested struct or a text.
+func fillValue(v reflect.Value, field *unmarshalField, value any) error {
+	if field.Nested {
+		record, ok := value.([]any)
+		if !ok {
+			return errors.New("not a record")
+		}
+		return fillFields(v, field.Fields, record)
+	}
+	text, ok := value.(string)
+	if !ok {
+		return nil // missing attribute.
+	}
+	return setText(v, text)
+}
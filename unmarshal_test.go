@@ -0,0 +1,78 @@
+package chromium
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type unmarshalOffer struct {
+	Price float64 `chromium:".price"`
+	Store string  `chromium:".store"`
+}
+
+type unmarshalProduct struct {
+	Name   string           `chromium:"h2"`
+	URL    string           `chromium:"a,href"`
+	ID     int              `chromium:",data-id"`
+	Tags   []string         `chromium:".tag"`
+	Offers []unmarshalOffer `chromium:".offer"`
+	Rating *int             `chromium:".rating"`
+	Note   string
+}
+
+type unmarshalNode struct {
+	Children []unmarshalNode `chromium:"li"`
+}
+
+func Test_unmarshalFields_Reads_Tags(t *testing.T) {
+	fields, err := unmarshalFields(reflect.TypeOf(unmarshalProduct{}), map[reflect.Type]bool{})
+	assert.NoError(t, err)
+	if assert.Len(t, fields, 6) {
+		assert.Equal(t, unmarshalField{index: 1, Selector: "a", Attr: "href"}, *fields[1])
+		assert.Equal(t, unmarshalField{index: 2, Attr: "data-id"}, *fields[2])
+		assert.True(t, fields[3].Many)
+		assert.True(t, fields[4].Many && fields[4].Nested)
+		assert.Len(t, fields[4].Fields, 2)
+	}
+
+	_, err = unmarshalFields(reflect.TypeOf(unmarshalNode{}), map[reflect.Type]bool{})
+	assert.True(t, errors.Is(err, DecodeFailed))
+	_, err = unmarshalFields(reflect.TypeOf(""), map[reflect.Type]bool{})
+	assert.True(t, errors.Is(err, DecodeFailed))
+}
+
+func Test_Unmarshal_Fills_Structs(t *testing.T) {
+	_, p, s := setup(t, []byte(`<html><body>
+<div class="product" data-id="1">
+	<h2> Desk
+		Lamp </h2><a href="/lamp">lamp</a>
+	<span class="tag">light</span><span class="tag">desk</span>
+	<div class="offer"><span class="price">1,200.5</span><span class="store">A</span></div>
+	<div class="offer"><span class="price">990</span><span class="store">B</span></div>
+	<span class="rating">4</span>
+</div>
+<div class="product" data-id="2"><h2>Chair</h2></div>
+<div class="product" data-id="x"></div>
+</body></html>`))
+	p.MustNavigate(s.URL).MustWaitLoad()
+
+	res, err := Unmarshal[unmarshalProduct](p, ".product:not([data-id=x])")
+	assert.NoError(t, err)
+	rating := 4
+	assert.Equal(t, []unmarshalProduct{
+		{
+			Name: "Desk Lamp", URL: "/lamp", ID: 1, Tags: []string{"light", "desk"},
+			Offers: []unmarshalOffer{{Price: 1200.5, Store: "A"}, {Price: 990, Store: "B"}}, Rating: &rating,
+		},
+		{Name: "Chair", ID: 2, Tags: []string{}, Offers: []unmarshalOffer{}},
+	}, res)
+
+	_, err = Unmarshal[unmarshalProduct](p, ".product")
+	assert.True(t, errors.Is(err, DecodeFailed))
+
+	res, err = Unmarshal[unmarshalProduct](p, ".missing")
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
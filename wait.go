@@ -0,0 +1,248 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-rod/rod/lib/proto"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// jsObjectNamePattern matches names of WaitJSObjectFor, which are JavaScript identifiers delimited by dots.
+var jsObjectNamePattern = regexp.MustCompile(`^[A-Za-z_$][\w$]*(\.[A-Za-z_$][\w$]*)*$`)
+
+// isContextLost tells whether an evaluation has failed as the document has been replaced meanwhile, such as by a
+// navigation, which is worth another evaluation in the next document.
+func isContextLost(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "Execution context was destroyed") || strings.Contains(msg, "Cannot find context with specified id")
+}
+
+// Await polls fn by given interval until it reports ok, then returns the value from it.
+// It returns the error from fn immediately, TaskTimeout once the timeout elapses, or the error of ctx once ctx is done.
+// Zero or negative interval polls by 100 milliseconds, and zero or negative timeout polls until ctx is done.
+func Await[T any](ctx context.Context, fn func() (T, bool, error), interval, timeout time.Duration) (T, error) {
+	var zero T
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		value, ok, err := fn()
+		if err != nil {
+			return zero, replaceTimeoutError(replaceAbortedError(err))
+		} else if ok {
+			return value, nil
+		}
+		select {
+		case <-ctx.Done():
+			return zero, replaceTimeoutError(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// AwaitEval evaluates the JavaScript function on the page by given interval until it returns neither null nor undefined,
+// then returns the result decoded into T as JSON.
+// Zero or negative timeout falls back to the default timeout of the page.
+func AwaitEval[T any](p *Page, js string, interval, timeout time.Duration) (T, error) {
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	return Await(ctx, func() (T, bool, error) {
+		var value *T
+		obj, err := page.Eval(js)
+		if err != nil {
+			return *new(T), false, err
+		}
+		if err = decodeJSON(obj, &value); err != nil || value == nil {
+			return *new(T), false, err
+		}
+		return *value, true, nil
+	}, interval, 0)
+}
+
+// WaitNetworkIdle waits until no request of this page has been in flight for idleFor.
+// Requests that have begun before the call are not tracked, thus call it right after the action that triggers requests.
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns TaskTimeout if the network does not settle in time, and context.Canceled if the page is closed meanwhile.
+func (p *Page) WaitNetworkIdle(idleFor, timeout time.Duration) error {
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	p.Context(ctx).WaitRequestIdle(idleFor, nil, nil)()
+	return replaceTimeoutError(ctx.Err())
+}
+
+// hasTextJS tells whether the element contains the text.
+const hasTextJS = `function(text) { return (this.innerText ?? this.textContent).includes(text) }`
+
+// WaitText waits until the first element matching the selector contains the text, polling by the poll interval of this page.
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping TaskTimeout if the text does not show up in time.
+func (p *Page) WaitText(selector, text string, timeout time.Duration) error {
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	_, err := Await(ctx, func() (bool, bool, error) {
+		found, el, err := has(page, selector)
+		if err != nil || !found {
+			return false, false, err
+		}
+		obj, err := el.Eval(hasTextJS, text)
+		if err != nil {
+			return false, false, err
+		}
+		return true, obj.Value.Bool(), nil
+	}, p.pollInterval(), 0)
+	if errors.Is(err, TaskTimeout) {
+		return elementError(opWaitText, selector, TaskTimeout)
+	}
+	return err
+}
+
+// WaitURL waits until the URL of this page matches the pattern, polling by the poll interval of this page.
+// The pattern is a wildcard as of Intercept, such as "*/done?id=*".
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping TaskTimeout if the URL does not match in time.
+func (p *Page) WaitURL(pattern string, timeout time.Duration) error {
+	reg, err := regexp.Compile(proto.PatternToReg(pattern))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	_, err = Await(ctx, func() (bool, bool, error) {
+		info, err := page.Info()
+		if err != nil {
+			return false, false, err
+		}
+		return true, reg.MatchString(info.URL), nil
+	}, p.pollInterval(), 0)
+	if errors.Is(err, TaskTimeout) {
+		return &Error{Op: opWaitURL, URL: pattern, Err: TaskTimeout}
+	}
+	return err
+}
+
+// waitJSTrueJS evaluates the JavaScript, calling it if it is a function and awaiting it if it is a promise,
+// then tells whether it results in true. Exceptions tell false, as the state that it examines may not be set up yet.
+const waitJSTrueJS = `async () => {
+	try {
+		let value = (%s);
+		if (typeof value === 'function') {
+			value = value();
+		}
+		return (await value) === true;
+	} catch (e) {
+		return false;
+	}
+}`
+
+// WaitJSTrue waits until the JavaScript results in true, polling by the interval, such that a single page application
+// can be waited for to be ready, such as by "window.app.state === 'ready'". The JavaScript may be an expression,
+// or a function without parameters such as "() => document.fonts.status === 'loaded'", which may be async.
+// Results other than true, including exceptions, are examined again on the next poll, as is a navigation meanwhile.
+// Zero or negative timeout falls back to the default timeout of the page, and zero or negative interval falls back to
+// the poll interval of the page.
+// It returns an error wrapping TaskTimeout if the JavaScript does not result in true in time,
+// or WaitFailed if it cannot be evaluated, such as by a syntax error.
+func (p *Page) WaitJSTrue(js string, timeout, interval time.Duration) error {
+	if interval <= 0 {
+		interval = p.pollInterval()
+	}
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	script := fmt.Sprintf(waitJSTrueJS, js)
+	_, err := Await(ctx, func() (bool, bool, error) {
+		obj, err := page.Eval(script)
+		if err != nil && isContextLost(err) {
+			return false, false, nil
+		} else if err != nil {
+			return false, false, err
+		}
+		return true, obj.Value.Bool(), nil
+	}, interval, 0)
+	if err != nil {
+		return elementError(opWaitJSTrue, js, classify(WaitFailed, err))
+	}
+	return nil
+}
+
+// WaitElementGone waits until no element matching the selector is shown, polling by the poll interval of this page,
+// such that a flow can wait for a spinner or a skeleton to disappear. The element is gone once it is removed, or it is
+// hidden such as by display: none. Only the first element matching the selector is examined on each poll.
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping TaskTimeout if the element is still shown in time.
+func (p *Page) WaitElementGone(selector string, timeout time.Duration) error {
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	_, err := Await(ctx, func() (bool, bool, error) {
+		found, el, err := has(page, selector)
+		if err != nil || !found {
+			return false, !found && err == nil, err
+		}
+		visible, err := el.Visible()
+		if err != nil {
+			return false, false, nil // the element may have been detached meanwhile, which the next poll tells.
+		}
+		return true, !visible, nil
+	}, p.pollInterval(), 0)
+	if err != nil {
+		return elementError(opWaitGone, selector, classify(WaitFailed, err))
+	}
+	return nil
+}
+
+// domStableJS resolves once the document has not been mutated for the window in milliseconds.
+const domStableJS = `(window) => new Promise(resolve => {
+	const done = () => {
+		observer.disconnect();
+		resolve(true);
+	};
+	let timer = setTimeout(done, window);
+	const observer = new MutationObserver(() => {
+		clearTimeout(timer);
+		timer = setTimeout(done, window);
+	});
+	observer.observe(document, {subtree: true, childList: true, attributes: true, characterData: true});
+})`
+
+// WaitDOMStable waits until the document of this page has not been mutated for the window, such as by nodes being
+// added or removed, or attributes or texts being changed, such that a flow can wait for the page to settle after
+// rendering. Mutations are observed in the page, hence the page is not polled meanwhile. A navigation meanwhile
+// restarts the observation in the next document.
+// Zero or negative timeout falls back to the default timeout of the page.
+// It returns an error wrapping TaskTimeout if the document keeps changing in time.
+func (p *Page) WaitDOMStable(window, timeout time.Duration) error {
+	ctx, cancel := p.waitContext(timeout)
+	defer cancel()
+	page := p.Context(ctx)
+	for {
+		_, err := page.Eval(domStableJS, window.Milliseconds())
+		if err == nil {
+			return nil
+		} else if isContextLost(err) && ctx.Err() == nil {
+			continue
+		}
+		return &Error{Op: opWaitDOMStable, Err: classify(WaitFailed, err)}
+	}
+}
+
+// waitContext returns a context of this page bound to the timeout, or to the default timeout of the page if not positive.
+func (p *Page) waitContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(p.GetContext(), timeout)
+	}
+	return p.timeoutContext()
+}
@@ -0,0 +1,174 @@
+package chromium
+
+import (
+	"context"
+	"errors"
+	"github.com/state303/chromium/internal/test/testfile"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Await_Returns_Value_Once_Ok(t *testing.T) {
+	count := 0
+	value, err := Await(context.Background(), func() (int, bool, error) {
+		count++
+		return count, count == 3, nil
+	}, time.Millisecond, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+}
+
+func Test_Await_Returns_Err_From_Func_Immediately(t *testing.T) {
+	target, count := errors.New("test error"), 0
+	_, err := Await(context.Background(), func() (string, bool, error) {
+		count++
+		return "", false, target
+	}, time.Millisecond, time.Second)
+	assert.ErrorIs(t, err, target)
+	assert.Equal(t, 1, count)
+}
+
+func Test_Await_Returns_TaskTimeout_When_Timeout_Elapses(t *testing.T) {
+	begin := time.Now()
+	value, err := Await(context.Background(), func() (int, bool, error) { return 1, false, nil }, time.Millisecond, time.Millisecond*20)
+	assert.ErrorIs(t, err, TaskTimeout)
+	assert.Zero(t, value)
+	assert.GreaterOrEqual(t, time.Since(begin), time.Millisecond*20)
+}
+
+func Test_Await_Returns_Err_When_Context_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond*10, cancel)
+	_, err := Await(ctx, func() (int, bool, error) { return 0, false, nil }, 0, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_AwaitEval_Returns_Decoded_Value_Once_Defined(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	time.AfterFunc(time.Millisecond*50, func() { p.MustEval(`() => window.result = {name: "test", count: 2}`) })
+	type result struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	value, err := AwaitEval[result](p, `() => window.result`, time.Millisecond*10, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, result{Name: "test", Count: 2}, value)
+}
+
+func Test_AwaitEval_Returns_TaskTimeout_When_Never_Defined(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	_, err := AwaitEval[string](p, `() => window.missing`, time.Millisecond*10, time.Millisecond*50)
+	assert.ErrorIs(t, err, TaskTimeout)
+}
+
+func Test_WaitNetworkIdle_Returns_Nil_Once_Requests_Settle(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => { for (let i = 0; i < 3; i++) fetch(location.href) }`)
+	assert.NoError(t, p.WaitNetworkIdle(time.Millisecond*100, time.Second*5))
+}
+
+func Test_WaitNetworkIdle_Returns_TaskTimeout_When_Requests_Keep_Going(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => { setInterval(() => fetch(location.href), 10) }`)
+	assert.ErrorIs(t, p.WaitNetworkIdle(time.Millisecond*200, time.Millisecond*500), TaskTimeout)
+}
+
+func Test_WaitText_Returns_Nil_Once_Text_Shows_Up(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetPollInterval(time.Millisecond * 10)
+	p.MustEval(`() => setTimeout(() => document.body.innerHTML = "<p>loading done</p>", 100)`)
+	assert.NoError(t, p.WaitText("p", "done", time.Second*5))
+	err := p.WaitText("p", "never", time.Millisecond*100)
+	assert.ErrorIs(t, err, TaskTimeout)
+}
+
+func Test_WaitURL_Returns_Nil_Once_URL_Matches(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetPollInterval(time.Millisecond * 10)
+	p.MustEval(`() => setTimeout(() => history.pushState(null, "", "/done?id=1"), 100)`)
+	assert.NoError(t, p.WaitURL("*/done?id=*", time.Second*5))
+	assert.ErrorIs(t, p.WaitURL("*/never", time.Millisecond*100), TaskTimeout)
+}
+
+func Test_isContextLost(t *testing.T) {
+	assert.True(t, isContextLost(errors.New("{-32000 Execution context was destroyed. }")))
+	assert.True(t, isContextLost(errors.New("{-32000 Cannot find context with specified id }")))
+	assert.False(t, isContextLost(errors.New("net::ERR_FAILED")))
+}
+
+func Test_WaitJSTrue_Waits_For_Expression(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => { setTimeout(() => { window.app = {state: 'ready'} }, 100) }`)
+	begin := time.Now()
+	assert.NoError(t, p.WaitJSTrue("window.app.state === 'ready'", time.Second, 10*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(begin), 90*time.Millisecond)
+}
+
+func Test_WaitJSTrue_Accepts_Async_Function(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	assert.NoError(t, p.WaitJSTrue("async () => (await Promise.resolve(1)) === 1", time.Second, 0))
+}
+
+func Test_WaitJSTrue_Returns_Errors(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	err := p.WaitJSTrue("'true'", 100*time.Millisecond, 10*time.Millisecond)
+	assert.ErrorIs(t, err, TaskTimeout)
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, opWaitJSTrue, e.Op)
+	}
+	assert.ErrorIs(t, p.WaitJSTrue("(", time.Second, 0), WaitFailed)
+}
+
+func Test_WaitElementGone_Waits_For_Removal_And_Hiding(t *testing.T) {
+	_, p, s := setup(t, []byte(`<html><body><div id="spinner">loading</div><div id="skeleton">...</div></body></html>`))
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.SetPollInterval(10 * time.Millisecond)
+	p.MustEval(`() => { setTimeout(() => document.querySelector('#spinner').remove(), 100) }`)
+	p.MustEval(`() => { setTimeout(() => document.querySelector('#skeleton').style.display = 'none', 100) }`)
+
+	begin := time.Now()
+	assert.NoError(t, p.WaitElementGone("#spinner", time.Second))
+	assert.GreaterOrEqual(t, time.Since(begin), 90*time.Millisecond)
+	assert.NoError(t, p.WaitElementGone("#skeleton", time.Second))
+	assert.NoError(t, p.WaitElementGone("#missing", time.Second))
+}
+
+func Test_WaitElementGone_Returns_TaskTimeout(t *testing.T) {
+	_, p, s := setup(t, []byte(`<html><body><div id="spinner">loading</div></body></html>`))
+	p.MustNavigate(s.URL).MustWaitLoad()
+	err := p.WaitElementGone("#spinner", 100*time.Millisecond)
+	assert.ErrorIs(t, err, TaskTimeout)
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, "#spinner", e.Selector)
+	}
+}
+
+func Test_WaitDOMStable_Waits_For_Mutations_To_Stop(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => { let n = 0; const id = setInterval(() => { document.body.append(String(n)); if (++n === 10) clearInterval(id) }, 20) }`)
+
+	begin := time.Now()
+	assert.NoError(t, p.WaitDOMStable(100*time.Millisecond, 2*time.Second))
+	assert.GreaterOrEqual(t, time.Since(begin), 250*time.Millisecond)
+	assert.Equal(t, "0123456789", p.MustElement("body").MustText())
+}
+
+func Test_WaitDOMStable_Returns_TaskTimeout(t *testing.T) {
+	_, p, s := setup(t, testfile.BlankHTML)
+	p.MustNavigate(s.URL).MustWaitLoad()
+	p.MustEval(`() => { setInterval(() => document.body.append('.'), 10) }`)
+	assert.ErrorIs(t, p.WaitDOMStable(100*time.Millisecond, 300*time.Millisecond), TaskTimeout)
+}